@@ -16,10 +16,12 @@ package apply
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/render"
 	"github.com/pkg/errors"
@@ -34,6 +36,12 @@ var ErrTreeContainsErrors = errors.New("apply had errors, check graph")
 
 // Apply the actions in a Graph of resource.Tasks
 func Apply(ctx context.Context, in *graph.Graph) (*graph.Graph, error) {
+	return ApplyWithNotify(ctx, in, nil)
+}
+
+// ApplyWithNotify calls Apply with a notifier. The graph must already be
+// planned.
+func ApplyWithNotify(ctx context.Context, in *graph.Graph, notify *graph.Notifier) (*graph.Graph, error) {
 	renderingPlant, err := render.NewFactory(ctx, in)
 	if err != nil {
 		return nil, err
@@ -41,7 +49,7 @@ func Apply(ctx context.Context, in *graph.Graph) (*graph.Graph, error) {
 	pipeline := func(g *graph.Graph, id string) executor.Pipeline {
 		return Pipeline(g, id, renderingPlant)
 	}
-	return execPipeline(ctx, in, pipeline, renderingPlant, nil)
+	return execPipeline(ctx, in, pipeline, renderingPlant, notify)
 }
 
 // PlanAndApply plans and applies each node
@@ -63,17 +71,29 @@ func WithNotify(ctx context.Context, in *graph.Graph, notify *graph.Notifier) (*
 
 // Apply the actions in a Graph of resource.Tasks
 func execPipeline(ctx context.Context, in *graph.Graph, pipelineF MkPipelineF, renderingPlant *render.Factory, notify *graph.Notifier) (*graph.Graph, error) {
-	var hasErrors error
+	var (
+		hasErrors    error
+		errorsLock   sync.Mutex
+		setGraphOnce sync.Once
+	)
+
+	// nodes are applied concurrently, so the shared state below is only ever
+	// touched once or under the lock
+	markErrors := func() {
+		errorsLock.Lock()
+		defer errorsLock.Unlock()
+		hasErrors = ErrTreeContainsErrors
+	}
 
 	out, err := in.Transform(ctx,
 		notify.Transform(func(meta *node.Node, out *graph.Graph) error {
-			renderingPlant.Graph = out
+			setGraphOnce.Do(func() { renderingPlant.Graph = out })
 			pipeline := pipelineF(out, meta.ID)
 
 			val, pipelineError := pipeline.Exec(ctx, meta.Value())
 
 			if pipelineError != nil {
-				hasErrors = ErrTreeContainsErrors
+				markErrors()
 				return pipelineError
 			}
 			asResult, ok := val.(*Result)
@@ -81,8 +101,9 @@ func execPipeline(ctx context.Context, in *graph.Graph, pipelineF MkPipelineF, r
 				return fmt.Errorf("expected asResult but got %T", val)
 			}
 
-			if nil != asResult.Error() {
-				hasErrors = ErrTreeContainsErrors
+			if nil != asResult.Error() && !load.IgnoreErrors(meta) {
+				markErrors()
+				executor.GetFailFast(ctx).Fail(meta.ID)
 			}
 
 			out.Add(meta.WithValue(asResult))
@@ -94,5 +115,12 @@ func execPipeline(ctx context.Context, in *graph.Graph, pipelineF MkPipelineF, r
 		return out, err
 	}
 
+	if hasErrors != nil && rollbackOnFailure(ctx) {
+		out, err = Rollback(ctx, out, notify)
+		if err != nil {
+			return out, err
+		}
+	}
+
 	return out, hasErrors
 }
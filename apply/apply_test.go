@@ -16,12 +16,15 @@ package apply_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/asteris-llc/converge/apply"
+	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	"github.com/asteris-llc/converge/helpers/faketask"
 	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/stretchr/testify/assert"
@@ -94,6 +97,91 @@ func TestApplyErrorsBelow(t *testing.T) {
 	assert.EqualError(t, rootNode.Error(), `error in dependency "root/err"`)
 }
 
+// TestApplyIgnoreErrors tests that dependents of a node that ignores errors
+// are still applied, and that its error does not fail the apply
+func TestApplyIgnoreErrors(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.NoOp()}))
+	errNode := node.New("root/err", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.Error()})
+	require.NoError(t, errNode.AddMetadata(load.MetaIgnoreErrors, true))
+	g.Add(errNode)
+
+	g.ConnectParent("root", "root/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := apply.Apply(context.Background(), g)
+	assert.NoError(t, err)
+
+	assert.EqualError(t, getResult(t, out, "root/err").Error(), "error")
+
+	root := getResult(t, out, "root")
+	assert.NoError(t, root.Error())
+	assert.True(t, root.Ran)
+}
+
+// TestApplyIgnoreErrorsInherited tests that a node that ignores errors but was
+// not run because its own dependency failed still blocks its dependents
+func TestApplyIgnoreErrorsInherited(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.NoOp()}))
+	ignoring := node.New("root/ignoring", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.NoOp()})
+	require.NoError(t, ignoring.AddMetadata(load.MetaIgnoreErrors, true))
+	g.Add(ignoring)
+	g.Add(node.New("root/ignoring/err", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.Error()}))
+
+	g.ConnectParent("root", "root/ignoring")
+	g.ConnectParent("root/ignoring", "root/ignoring/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := apply.Apply(context.Background(), g)
+	assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+	assert.EqualError(t, getResult(t, out, "root/ignoring/err").Error(), "error")
+
+	ignored := getResult(t, out, "root/ignoring")
+	assert.False(t, ignored.Ran)
+	assert.EqualError(t, ignored.Error(), `error in dependency "root/ignoring/err"`)
+
+	root := getResult(t, out, "root")
+	assert.False(t, root.Ran)
+	assert.EqualError(t, root.Error(), `error in dependency "root/ignoring"`)
+}
+
+// TestApplyFailFast tests that no nodes are applied after the first failure
+// when failing fast
+func TestApplyFailFast(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.NoOp()}))
+	g.Add(node.New("root/a", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.NoOp()}))
+	g.Add(node.New("root/a/err", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.Error()}))
+
+	g.ConnectParent("root", "root/a")
+	g.ConnectParent("root/a", "root/a/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := apply.Apply(executor.WithFailFast(context.Background()), g)
+	assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+	assert.EqualError(t, getResult(t, out, "root/a/err").Error(), "error")
+	assert.False(t, getResult(t, out, "root/a/err").Skipped())
+
+	for _, id := range []string{"root/a", "root"} {
+		result := getResult(t, out, id)
+		assert.False(t, result.Ran, id)
+		assert.True(t, result.Skipped(), id)
+		assert.EqualError(t, result.Error(), `not run: failing fast after error in "root/a/err"`, id)
+	}
+}
+
 func TestApplyStillChange(t *testing.T) {
 	defer logging.HideLogs(t)()
 
@@ -134,3 +222,202 @@ func getResult(t *testing.T, src *graph.Graph, key string) *apply.Result {
 
 	return result
 }
+
+// TestApplyHandler tests that handlers are only applied when a notifier changed
+func TestApplyHandler(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	handlerGraphWithNotifier := func(notifier *node.Node) *graph.Graph {
+		g := graph.New()
+		g.Add(node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWontChange}, Task: faketask.NoOp()}))
+		g.Add(notifier)
+
+		handler := node.New("root/handler", &plan.Result{Status: &resource.Status{Level: resource.StatusWontChange}, Task: faketask.NoOp()})
+		require.NoError(t, handler.AddMetadata(load.MetaNotifiers, []string{"root/notifier"}))
+		g.Add(handler)
+
+		g.ConnectParent("root", "root/notifier")
+		g.ConnectParent("root", "root/handler")
+		g.Connect("root/handler", "root/notifier")
+
+		require.NoError(t, g.Validate())
+		return g
+	}
+
+	handlerGraph := func(notifierLevel resource.StatusLevel) *graph.Graph {
+		return handlerGraphWithNotifier(node.New("root/notifier", &plan.Result{Status: &resource.Status{Level: notifierLevel}, Task: faketask.NoOp()}))
+	}
+
+	t.Run("notified", func(t *testing.T) {
+		applied, err := apply.Apply(context.Background(), handlerGraph(resource.StatusWillChange))
+		require.NoError(t, err)
+
+		result := getResult(t, applied, "root/handler")
+		assert.True(t, result.Ran)
+		assert.True(t, result.IsHandler())
+		assert.Equal(t, []string{"root/notifier"}, result.TriggeredBy())
+	})
+
+	t.Run("not notified", func(t *testing.T) {
+		g := handlerGraph(resource.StatusWontChange)
+		meta, _ := g.Get("root/handler")
+		g.Add(meta.WithValue(&plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.WillChange()}))
+
+		applied, err := apply.Apply(context.Background(), g)
+		require.NoError(t, err)
+
+		result := getResult(t, applied, "root/handler")
+		assert.False(t, result.Ran)
+		assert.True(t, result.IsHandler())
+		assert.Empty(t, result.TriggeredBy())
+	})
+
+	t.Run("notifier failed", func(t *testing.T) {
+		notifier := node.New("root/notifier", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: faketask.Error()})
+		require.NoError(t, notifier.AddMetadata(load.MetaIgnoreErrors, true))
+
+		applied, err := apply.Apply(context.Background(), handlerGraphWithNotifier(notifier))
+		require.NoError(t, err)
+
+		assert.Error(t, getResult(t, applied, "root/notifier").Error())
+
+		result := getResult(t, applied, "root/handler")
+		assert.False(t, result.Ran)
+		assert.Empty(t, result.TriggeredBy())
+	})
+}
+
+// TestApplyRetries tests that nodes with a retry policy are applied until they
+// succeed or run out of retries
+func TestApplyRetries(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	retryGraph := func(task resource.Task, retries int) *graph.Graph {
+		g := graph.New()
+		root := node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: task})
+		require.NoError(t, root.AddMetadata(load.MetaRetryPolicy, &resource.RetryPolicy{Retries: retries, Backoff: 1}))
+		g.Add(root)
+
+		require.NoError(t, g.Validate())
+		return g
+	}
+
+	t.Run("succeeds", func(t *testing.T) {
+		task := faketask.Flaky(1)
+		applied, err := apply.Apply(context.Background(), retryGraph(task, 2))
+		require.NoError(t, err)
+
+		result := getResult(t, applied, "root")
+		assert.True(t, result.Ran)
+		assert.NoError(t, result.Error())
+		assert.Equal(t, 2, task.Attempts)
+		assert.Equal(
+			t,
+			[]string{"attempt 1 of 3 failed: root still has changes after apply: flaky", "attempt 2 of 3 succeeded"},
+			result.Attempts,
+		)
+		assert.Contains(t, result.Messages(), "attempt 2 of 3 succeeded")
+	})
+
+	t.Run("runs out", func(t *testing.T) {
+		task := faketask.Flaky(5)
+		applied, err := apply.Apply(context.Background(), retryGraph(task, 2))
+		assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+		result := getResult(t, applied, "root")
+		assert.Error(t, result.Error())
+		assert.Equal(t, 3, task.Attempts)
+		assert.Len(t, result.Attempts, 3)
+	})
+}
+
+// TestApplyRollback tests that nodes that changed are rolled back in reverse
+// dependency order when another node fails
+func TestApplyRollback(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	rollbackGraph := func(log *[]string) *graph.Graph {
+		willChange := &resource.Status{Level: resource.StatusWillChange}
+
+		g := graph.New()
+		g.Add(node.New("root", &plan.Result{Status: willChange, Task: faketask.NoOp()}))
+		g.Add(node.New("root/a", &plan.Result{Status: willChange, Task: faketask.Rollbacker("a", log)}))
+		g.Add(node.New("root/b", &plan.Result{Status: willChange, Task: faketask.Rollbacker("b", log)}))
+		g.Add(node.New("root/c", &plan.Result{Status: willChange, Task: faketask.Swapper()}))
+		g.Add(node.New("root/err", &plan.Result{Status: willChange, Task: faketask.Error()}))
+
+		g.ConnectParent("root", "root/a")
+		g.ConnectParent("root", "root/b")
+		g.ConnectParent("root", "root/c")
+		g.ConnectParent("root", "root/err")
+		g.Connect("root/b", "root/a")
+		g.Connect("root/err", "root/a")
+
+		require.NoError(t, g.Validate())
+		return g
+	}
+
+	t.Run("on failure", func(t *testing.T) {
+		var log []string
+		applied, err := apply.Apply(apply.WithRollbackOnFailure(context.Background()), rollbackGraph(&log))
+		assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+		assert.Equal(t, []string{"b", "a"}, log)
+
+		for _, id := range []string{"root/a", "root/b"} {
+			result := getResult(t, applied, id)
+			assert.True(t, result.RolledBack, id)
+			assert.Contains(t, result.Messages(), "rolled back", id)
+		}
+
+		// c changed but can't be rolled back
+		c := getResult(t, applied, "root/c")
+		assert.NoError(t, c.Error())
+		assert.False(t, c.RolledBack)
+
+		assert.False(t, getResult(t, applied, "root/err").RolledBack)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		var log []string
+		applied, err := apply.Apply(context.Background(), rollbackGraph(&log))
+		assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+		assert.Empty(t, log)
+		assert.False(t, getResult(t, applied, "root/a").RolledBack)
+	})
+}
+
+// TestApplyTimeout tests that nodes with a timeout are reported as timed out
+// when they take too long
+func TestApplyTimeout(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	task := new(hangingTask)
+
+	g := graph.New()
+	root := node.New("root", &plan.Result{Status: &resource.Status{Level: resource.StatusWillChange}, Task: task})
+	require.NoError(t, root.AddMetadata(load.MetaTimeout, 10*time.Millisecond))
+	g.Add(root)
+
+	require.NoError(t, g.Validate())
+
+	applied, err := apply.Apply(context.Background(), g)
+	assert.Equal(t, apply.ErrTreeContainsErrors, err)
+
+	result := getResult(t, applied, "root")
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "apply timed out after 10ms")
+}
+
+// hangingTask blocks in Apply until its context is cancelled
+type hangingTask struct{}
+
+func (h *hangingTask) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	return &resource.Status{Level: resource.StatusWillChange}, nil
+}
+
+func (h *hangingTask) Apply(ctx context.Context) (resource.TaskStatus, error) {
+	<-ctx.Done()
+	return &resource.Status{}, ctx.Err()
+}
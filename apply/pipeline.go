@@ -16,9 +16,12 @@ package apply
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/render"
 	"github.com/asteris-llc/converge/resource"
@@ -33,7 +36,9 @@ type pipelineGen struct {
 }
 
 type resultWrapper struct {
-	Plan *plan.Result
+	Plan      *plan.Result
+	Notifiers []string
+	Triggered []string
 }
 
 // Pipeline generates a pipeline to evaluate a single graph node
@@ -60,12 +65,23 @@ func (g *pipelineGen) GetTask(ctx context.Context, idi interface{}) (interface{}
 // encountered it returns `Left error`, if failing dependencies are encountered
 // it returns `Right (Left apply.Result)` and otherwise returns `Right (Right
 // plan.Result)`. The return values are structured to short-circuit `PlanNode`
-// if we have failures.
+// if we have failures. Dependencies that ignore errors are only considered
+// failing when they were not run because of a failure further down, and when
+// failing fast every node after the first failure is short-circuited.
 func (g *pipelineGen) DependencyCheck(ctx context.Context, taskI interface{}) (interface{}, error) {
 	result, ok := taskI.(resultWrapper)
 	if !ok {
 		return nil, errors.New("input node is not a task wrapper")
 	}
+	if failed, ok := executor.GetFailFast(ctx).Failed(); ok {
+		return &Result{
+			Ran:    false,
+			Status: &resource.Status{Level: resource.StatusWillChange},
+			Task:   result.Plan.Task,
+			Plan:   result.Plan,
+			Err:    &executor.FailFastError{Failed: failed},
+		}, nil
+	}
 	for _, depID := range graph.Targets(g.Graph.DownEdges(g.ID)) {
 		meta, ok := g.Graph.Get(depID)
 		if !ok {
@@ -77,11 +93,11 @@ func (g *pipelineGen) DependencyCheck(ctx context.Context, taskI interface{}) (i
 		if !ok {
 			return nil, fmt.Errorf("apply.DependencyCheck: expected %s to have type executor.Status but got type %T", depID, elem)
 		}
-		if err := dep.Error(); err != nil {
+		if executor.BlocksDependents(dep.Error(), load.IgnoreErrors(meta)) {
 			errResult := &Result{
 				Ran:    false,
 				Status: &resource.Status{Level: resource.StatusWillChange},
-				Err:    fmt.Errorf("error in dependency %q", depID),
+				Err:    &executor.DependencyError{Dependency: depID},
 			}
 			return errResult, nil
 		}
@@ -100,6 +116,24 @@ func (g *pipelineGen) maybeSkipApplication(ctx context.Context, resultI interfac
 	if !ok {
 		return nil, fmt.Errorf("expected *Result or *resultWrapper but got type %T", resultI)
 	}
+	if notifiers, ok := g.notifiers(); ok {
+		asPlan.Notifiers = notifiers
+		asPlan.Triggered = g.triggeredBy(notifiers)
+
+		// handlers are applied when notified, whether or not their plan has
+		// changes, and skipped otherwise
+		if len(asPlan.Triggered) > 0 {
+			return asPlan, nil
+		}
+		return &Result{
+			Ran:       false,
+			Status:    asPlan.Plan.Status,
+			Task:      asPlan.Plan.Task,
+			Plan:      asPlan.Plan,
+			Err:       asPlan.Plan.Err,
+			Notifiers: notifiers,
+		}, nil
+	}
 	if !asPlan.Plan.Status.HasChanges() {
 		return &Result{
 			Ran:    false,
@@ -112,6 +146,37 @@ func (g *pipelineGen) maybeSkipApplication(ctx context.Context, resultI interfac
 	return asPlan, nil
 }
 
+// notifiers returns the IDs of the nodes that notify this node, if it is a
+// handler
+func (g *pipelineGen) notifiers() ([]string, bool) {
+	meta, ok := g.Graph.Get(g.ID)
+	if !ok {
+		return nil, false
+	}
+	raw, ok := meta.LookupMetadata(load.MetaNotifiers)
+	if !ok {
+		return nil, false
+	}
+	notifiers, ok := raw.([]string)
+	return notifiers, ok
+}
+
+// triggeredBy returns the notifiers that changed during this application.
+// Notifiers that are not in the graph (for example because they were
+// excluded) or that failed to apply are treated as unchanged.
+func (g *pipelineGen) triggeredBy(notifiers []string) (out []string) {
+	for _, id := range notifiers {
+		meta, ok := g.Graph.Get(id)
+		if !ok {
+			continue
+		}
+		if result, ok := meta.Value().(*Result); ok && result.Changed() {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
 // applyNode runs apply on the node, it takes an Either *apply.Result
 // *plan.Result and, if the input value is Left, returns it as a Right value,
 // otherwise it attempts to run apply on the *plan.Result.Task and returns an
@@ -125,7 +190,23 @@ func (g *pipelineGen) applyNode(ctx context.Context, val interface{}) (interface
 		return nil, fmt.Errorf("apply expected a resultWrappert but got %T", val)
 	}
 
-	status, err := twrapper.Plan.Task.Apply(ctx)
+	if policy, ok := g.retryPolicy(); ok {
+		return g.applyWithRetries(ctx, twrapper, policy)
+	}
+	return g.applyTask(ctx, twrapper)
+}
+
+// applyTask runs apply on the task in the wrapper once
+func (g *pipelineGen) applyTask(ctx context.Context, twrapper resultWrapper) (*Result, error) {
+	var timeout time.Duration
+	if meta, ok := g.Graph.Get(g.ID); ok {
+		timeout = load.Timeout(meta)
+	}
+
+	applied, err := resource.RunWithTimeout(ctx, "apply", timeout, func(ctx context.Context) (interface{}, error) {
+		return twrapper.Plan.Task.Apply(ctx)
+	})
+	status, _ := applied.(resource.TaskStatus)
 
 	if status == nil {
 		status = &resource.Status{}
@@ -143,23 +224,85 @@ func (g *pipelineGen) applyNode(ctx context.Context, val interface{}) (interface
 	}
 
 	return &Result{
-		Ran:    true,
-		Status: status,
-		Task:   twrapper.Plan.Task,
-		Plan:   twrapper.Plan,
-		Err:    status.Error(),
+		Ran:       true,
+		Status:    status,
+		Task:      twrapper.Plan.Task,
+		Plan:      twrapper.Plan,
+		Err:       status.Error(),
+		Notifiers: twrapper.Notifiers,
+		Triggered: twrapper.Triggered,
 	}, nil
 }
 
+// applyWithRetries applies the task until it succeeds or runs out of retries.
+// An attempt fails if it returns an error or if the task still has changes
+// afterwards, so every attempt is followed by the final check. Attempts are
+// recorded in the messages of the result.
+func (g *pipelineGen) applyWithRetries(ctx context.Context, twrapper resultWrapper, policy *resource.RetryPolicy) (*Result, error) {
+	logger := logging.GetLogger(ctx).WithField("id", g.ID)
+
+	var attempts []string
+	for attempt := 1; ; attempt++ {
+		applied, err := g.applyTask(ctx, twrapper)
+		if err != nil {
+			return nil, err
+		}
+
+		checked, err := g.maybeRunFinalCheck(ctx, applied)
+		if err != nil {
+			return nil, err
+		}
+		result := checked.(*Result)
+
+		if result.Err == nil {
+			if attempt > 1 {
+				attempts = append(attempts, fmt.Sprintf("attempt %d of %d succeeded", attempt, policy.Attempts()))
+			}
+			result.Attempts = attempts
+			return result, nil
+		}
+
+		attempts = append(attempts, fmt.Sprintf("attempt %d of %d failed: %s", attempt, policy.Attempts(), result.Err))
+		if attempt >= policy.Attempts() {
+			result.Attempts = attempts
+			return result, nil
+		}
+
+		delay := policy.Delay(attempt)
+		logger.WithError(result.Err).WithField("attempt", attempt).WithField("delay", delay).Warn("apply failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			result.Attempts = append(attempts, "retries cancelled")
+			return result, nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryPolicy returns the retry policy of this node, if it has one
+func (g *pipelineGen) retryPolicy() (*resource.RetryPolicy, bool) {
+	meta, ok := g.Graph.Get(g.ID)
+	if !ok {
+		return nil, false
+	}
+	raw, ok := meta.LookupMetadata(load.MetaRetryPolicy)
+	if !ok {
+		return nil, false
+	}
+	policy, ok := raw.(*resource.RetryPolicy)
+	return policy, ok
+}
+
 // maybeRunFinalCheck :: *Result -> Either error *Result; looks to see if the
 // current result ran, and if so it re-runs plan and sets PostCheck to the
-// resulting status.
+// resulting status. Results that have already been checked are returned as-is.
 func (g *pipelineGen) maybeRunFinalCheck(ctx context.Context, resultI interface{}) (interface{}, error) {
 	result, ok := resultI.(*Result)
 	if !ok {
 		return nil, fmt.Errorf("expected *Result but got %T", resultI)
 	}
-	if !result.Ran {
+	if !result.Ran || result.PostCheck != nil {
 		return result, nil
 	}
 	task := result.Plan.Task
@@ -15,6 +15,7 @@
 package apply
 
 import (
+	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/resource"
 )
@@ -28,14 +29,41 @@ type Result struct {
 	Ran       bool
 	Plan      *plan.Result
 	PostCheck resource.TaskStatus
+
+	// Notifiers are the nodes that notify this node, if it is a handler.
+	// Triggered are the notifiers that changed and caused it to be applied.
+	Notifiers []string
+	Triggered []string
+
+	// Attempts records each attempt to apply a node that has a retry policy
+	Attempts []string
+
+	// RolledBack is set when the application was undone because another node
+	// failed. Rollback is the status returned by the rollback, if any.
+	RolledBack bool
+	Rollback   resource.TaskStatus
 }
 
-// Messages returns any result status messages supplied by the task
+// Messages returns any result status messages supplied by the task, followed
+// by the record of apply attempts and the rollback, if there was one
 func (r *Result) Messages() []string {
+	var messages []string
 	if r.Status != nil {
-		return r.Status.Messages()
+		messages = r.Status.Messages()
 	}
-	return nil
+	if len(r.Attempts) > 0 {
+		messages = append(append([]string{}, messages...), r.Attempts...)
+	}
+	if r.RolledBack {
+		messages = append([]string{}, messages...)
+		if r.Rollback != nil {
+			messages = append(messages, r.Rollback.Messages()...)
+		}
+		if r.Err == nil {
+			messages = append(messages, "rolled back")
+		}
+	}
+	return messages
 }
 
 // Changes returns the fields that changed
@@ -53,9 +81,26 @@ func (r *Result) Changes() map[string]resource.Diff {
 // HasChanges indicates if this result ran
 func (r *Result) HasChanges() bool { return r.Ran }
 
+// Changed indicates if this result was applied successfully to make the
+// changes that were planned for it. Unlike HasChanges it is false for results
+// that failed or were rolled back.
+func (r *Result) Changed() bool {
+	if !r.Ran || r.Err != nil || r.RolledBack {
+		return false
+	}
+	return r.Plan != nil && r.Plan.HasChanges()
+}
+
 // Error returns the error assigned to this Result, if any
 func (r *Result) Error() error { return r.Err }
 
+// Skipped indicates if this result was not applied because another node failed
+// while failing fast
+func (r *Result) Skipped() bool {
+	_, ok := r.Err.(*executor.FailFastError)
+	return ok
+}
+
 // Warning returns the warning assigned to this Result, if any
 func (r *Result) Warning() string {
 	if r.Status != nil {
@@ -64,6 +109,13 @@ func (r *Result) Warning() string {
 	return ""
 }
 
+// IsHandler indicates if this result is for a node that is only applied when
+// notified
+func (r *Result) IsHandler() bool { return len(r.Notifiers) > 0 }
+
+// TriggeredBy returns the notifiers that caused this handler to be applied
+func (r *Result) TriggeredBy() []string { return r.Triggered }
+
 // GetStatus returns the current task status
 func (r *Result) GetStatus() resource.TaskStatus { return r.Status }
 
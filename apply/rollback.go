@@ -0,0 +1,128 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"sort"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+var rollbackKey = struct{ name string }{"rollback-on-failure"}
+
+// WithRollbackOnFailure makes applies started with the returned context roll
+// back the nodes that changed if any node fails
+func WithRollbackOnFailure(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rollbackKey, true)
+}
+
+func rollbackOnFailure(ctx context.Context) bool {
+	rollback, _ := ctx.Value(rollbackKey).(bool)
+	return rollback
+}
+
+// Rollback undoes the application of every node that was applied without
+// error, for tasks that implement resource.Rollbacker. Nodes are rolled back
+// one at a time in reverse dependency order, so a node is always rolled back
+// before the nodes it depends on. Nodes that can't be rolled back are left as
+// they are.
+func Rollback(ctx context.Context, in *graph.Graph, notify *graph.Notifier) (*graph.Graph, error) {
+	logger := logging.GetLogger(ctx).WithField("function", "Rollback")
+
+	out := in.Copy()
+	for _, id := range rollbackOrder(in) {
+		meta, _ := out.Get(id)
+		result, ok := meta.Value().(*Result)
+		if !ok || !result.Ran || result.Err != nil {
+			continue
+		}
+
+		task, ok := resource.ResolveTask(result)
+		if !ok {
+			continue
+		}
+		rollbacker, ok := task.(resource.Rollbacker)
+		if !ok {
+			logger.WithField("id", id).Debug("cannot be rolled back")
+			continue
+		}
+
+		if notify != nil && notify.Pre != nil {
+			if err := notify.Pre(meta); err != nil {
+				return out, err
+			}
+		}
+
+		logger.WithField("id", id).Info("rolling back")
+		status, err := resource.RunWithTimeout(ctx, "rollback", load.Timeout(meta), func(ctx context.Context) (interface{}, error) {
+			return rollbacker.Rollback(ctx)
+		})
+
+		rolledBack := *result
+		rolledBack.RolledBack = true
+		rolledBack.Rollback, _ = status.(resource.TaskStatus)
+		if err != nil {
+			rolledBack.Err = errors.Wrap(err, "rollback failed")
+		}
+
+		meta = meta.WithValue(&rolledBack)
+		out.Add(meta)
+
+		if notify != nil && notify.Post != nil {
+			if err := notify.Post(meta); err != nil {
+				return out, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// rollbackOrder returns the IDs in a graph so that every node comes before
+// the nodes it depends on. Ties are broken by ID to keep the order stable.
+func rollbackOrder(g *graph.Graph) []string {
+	dependents := map[string]int{}
+	for _, id := range g.Vertices() {
+		dependents[id] = len(g.UpEdges(id))
+	}
+
+	var ready, order []string
+	for id, count := range dependents {
+		if count == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, dep := range graph.Targets(g.DownEdges(id)) {
+			dependents[dep]--
+			if dependents[dep] == 0 {
+				ready = append(ready, dep)
+			}
+		}
+	}
+
+	return order
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/asteris-llc/converge/graph"
@@ -34,7 +35,12 @@ var applyCmd = &cobra.Command{
 	Use:   "apply",
 	Short: "apply what needs to change in the system",
 	Long: `application is where the actual work of making your execution graph
-real happens.`,
+real happens.
+
+Plans saved with "converge plan --out" can be given instead of a module. In this
+case only the saved plan will be applied, and apply will refuse to run if
+anything has changed since the plan was saved. Modules are verified if either
+the plan was saved with --verify-modules or apply is run with it.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return errors.New("Need at least one module filename as argument, got 0")
@@ -53,6 +59,9 @@ real happens.`,
 
 		maybeSetToken()
 
+		release := acquireLocalApplyLock(ctx, cmd, strings.Join(args, ", "))
+		defer release()
+
 		if err := maybeStartSelfHostedRPC(ctx); err != nil {
 			clog.WithError(err).Fatal("could not start RPC")
 		}
@@ -63,6 +72,9 @@ real happens.`,
 		}
 
 		rpcParams := getParamsRPC(cmd)
+		parallelism, kindParallelism := getParallelismRPC(cmd)
+		targets, excludes := getPruneRPC(cmd)
+		lockTimeout := getLockTimeoutRPC(cmd)
 
 		verifyModules := viper.GetBool("verify-modules")
 		if !verifyModules {
@@ -75,14 +87,40 @@ real happens.`,
 
 			flog.Debug("applying")
 
-			stream, err := client.Apply(
-				ctx,
-				&pb.LoadRequest{
-					Location:   fname,
-					Parameters: rpcParams,
-					Verify:     verifyModules,
-				},
-			)
+			request := &pb.LoadRequest{
+				Location:          fname,
+				Parameters:        rpcParams,
+				ParamsFile:        paramsFiles,
+				Verify:            verifyModules,
+				Parallelism:       parallelism,
+				KindParallelism:   kindParallelism,
+				Targets:           targets,
+				Excludes:          excludes,
+				FailFast:          viper.GetBool("fail-fast"),
+				RollbackOnFailure: viper.GetBool("rollback-on-failure"),
+				LockTimeout:       lockTimeout,
+			}
+
+			saved, content, err := maybeReadSavedPlan(fname)
+			if err != nil {
+				flog.WithError(err).Fatal("could not read saved plan")
+			}
+			if saved != nil {
+				flog = flog.WithField("location", saved.Location)
+				flog.Info("applying saved plan")
+
+				request.Location = saved.Location
+				request.Parameters = saved.Parameters
+				request.ParamsFile = nil
+				// the plan file isn't signed, so it can ask for verification but
+				// never turn it off
+				request.Verify = verifyModules || saved.VerifyModules
+				request.Targets = saved.Targets
+				request.Excludes = saved.Excludes
+				request.Plan = content
+			}
+
+			stream, err := client.Apply(ctx, request)
 			if err != nil {
 				flog.WithError(err).Fatal("error getting RPC stream")
 			}
@@ -125,7 +163,7 @@ real happens.`,
 						details := resp.GetDetails()
 						if details != nil {
 							printable := details.ToPrintable()
-							if printable.Error() != nil {
+							if printable.Error() != nil && !details.IgnoreErrors {
 								applyError = true
 							}
 							g.Add(node.New(resp.Id, printable))
@@ -158,6 +196,7 @@ real happens.`,
 			fmt.Print("\n")
 			fmt.Print(out)
 			if applyError {
+				release()
 				os.Exit(1)
 			}
 		}
@@ -165,13 +204,18 @@ real happens.`,
 }
 
 func init() {
-	applyCmd.Flags().Bool("show-meta", false, "show metadata (params and modules)")
+	applyCmd.Flags().Bool("show-meta", false, "show metadata (params, locals, and modules)")
 	applyCmd.Flags().Bool("only-show-changes", false, "only show changes")
 	applyCmd.Flags().Bool("verify-modules", false, "verify module signatures")
+	applyCmd.Flags().Bool("fail-fast", false, "stop starting new nodes as soon as any node fails")
+	applyCmd.Flags().Bool("rollback-on-failure", false, "if any node fails, roll back the nodes that changed, in reverse dependency order")
 	registerRPCFlags(applyCmd.Flags())
 	registerLocalRPCFlags(applyCmd.Flags())
 	registerSSLFlags(applyCmd.Flags())
 	registerParamsFlags(applyCmd.Flags())
+	registerParallelismFlags(applyCmd.Flags())
+	registerPruneFlags(applyCmd.Flags())
+	registerLockFlags(applyCmd.Flags())
 
 	RootCmd.AddCommand(applyCmd)
 }
@@ -0,0 +1,69 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/render/extensions/facts"
+	"github.com/spf13/cobra"
+)
+
+// factsCmd represents the facts command
+var factsCmd = &cobra.Command{
+	Use:   "facts [path]",
+	Short: "show the facts about this system available to templates",
+	Long: `facts gathers information about the system converge is running on and
+prints it as JSON. These are the same values templates get from the fact
+function, so "converge facts net.eth0" shows what {{fact "net.eth0.ipv4"}}
+would be chosen from.`,
+	Example: `converge facts
+converge facts memory.total`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("Need at most one fact path as argument, got %d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		flog := log.WithField("component", "facts")
+
+		f, err := facts.NewCache().Get()
+		if err != nil {
+			flog.WithError(err).Fatal("could not gather facts")
+		}
+
+		var out interface{} = f
+		if len(args) == 1 {
+			out, err = f.Get(args[0])
+			if err != nil {
+				flog.WithError(err).Fatal("could not get fact")
+			}
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			flog.WithError(err).Fatal("could not print facts")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(factsCmd)
+}
@@ -17,10 +17,12 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/parse"
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -45,7 +47,7 @@ var fmtCmd = &cobra.Command{
 				flog.WithError(err).Fatal("could not read")
 			}
 
-			formatted, err := printer.Format(content)
+			formatted, err := format(content)
 			if err != nil {
 				flog.WithError(err).Fatal("could not format content")
 			}
@@ -69,6 +71,75 @@ var fmtCmd = &cobra.Command{
 	},
 }
 
+// format formats a module. HCL doesn't know about include directives, so the
+// content between them is formatted separately and the directives are kept on
+// lines of their own. Whether a directive is separated from its neighbors by a
+// blank line is kept as it was.
+func format(content []byte) ([]byte, error) {
+	_, includes := parse.ExtractIncludes(content)
+	if len(includes) == 0 {
+		return printer.Format(content)
+	}
+
+	var (
+		out   bytes.Buffer
+		chunk [][]byte
+	)
+
+	// write adds a block to the output, after a blank line if there was one
+	// before it in the source
+	write := func(block []byte, blankBefore bool) {
+		if out.Len() > 0 {
+			if blankBefore {
+				out.WriteByte('\n')
+			}
+			out.WriteByte('\n')
+		}
+		out.Write(block)
+	}
+
+	blank := func(line []byte) bool { return len(bytes.TrimSpace(line)) == 0 }
+
+	flush := func() error {
+		if len(chunk) == 0 || blank(bytes.Join(chunk, nil)) {
+			return nil
+		}
+
+		formatted, err := printer.Format(bytes.Join(chunk, []byte("\n")))
+		if err != nil {
+			return err
+		}
+		write(bytes.TrimRight(formatted, "\n"), blank(chunk[0]))
+		return nil
+	}
+
+	lines := bytes.Split(content, []byte("\n"))
+	next := 0
+	for i, line := range lines {
+		if next < len(includes) && includes[next].Line == i+1 {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+
+			write(
+				[]byte(fmt.Sprintf("%s %q", parse.IncludeKeyword, includes[next].Pattern)),
+				i > 0 && blank(lines[i-1]),
+			)
+			chunk = nil
+			next++
+			continue
+		}
+		chunk = append(chunk, line)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
 func init() {
 	fmtCmd.Flags().Bool("check", false, "only check, no writing")
 
@@ -0,0 +1,93 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/load"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+// getCmd represents the get command
+var getCmd = &cobra.Command{
+	Use:   "get",
+	Short: "fetch the modules a module calls and lock their checksums",
+	Long: `get loads a module and every module it calls, storing the modules that
+aren't local files in .converge/modules next to it. The checksum of every
+module is recorded in converge.lock next to the module. After that, loading the
+module uses the cached modules and fails if any module doesn't match its
+checksum. Run get again to update the cache and lockfile after changing module
+calls.`,
+	Example: `converge get app.hcl`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("Need at least one module filename as argument, got 0")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		// set up execution context
+		ctx, cancel := context.WithCancel(context.Background())
+		GracefulExit(cancel)
+
+		verifyModules := viper.GetBool("verify-modules")
+		if !verifyModules {
+			log.WithField("component", "client").Warn("skipping module verification")
+		}
+
+		params, err := load.ReadParamsFiles(paramsFiles)
+		if err != nil {
+			log.WithError(err).Fatal("could not read params file")
+		}
+		for k, v := range getParamsRPC(cmd) {
+			params[k] = v
+		}
+		ctx = load.WithParams(ctx, params)
+
+		for _, fname := range args {
+			flog := log.WithField("file", fname)
+
+			lock, err := load.Get(ctx, fname, verifyModules)
+			if err != nil {
+				flog.WithError(err).Fatal("could not get modules")
+			}
+
+			ids := make([]string, 0, len(lock.Modules))
+			for id := range lock.Modules {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			for _, id := range ids {
+				flog.WithField("module", id).WithField("source", lock.Modules[id].Source).Info("locked")
+			}
+
+			flog.WithField("lockfile", filepath.Join(filepath.Dir(fname), load.LockfileName)).Info("modules locked")
+		}
+	},
+}
+
+func init() {
+	getCmd.Flags().Bool("verify-modules", false, "verify module signatures")
+	registerParamsFlags(getCmd.Flags())
+
+	RootCmd.AddCommand(getCmd)
+}
@@ -72,6 +72,7 @@ You can pipe the output directly to the 'dot' command, for example:
 			&pb.LoadRequest{
 				Location:   fname,
 				Parameters: getParamsRPC(cmd),
+				ParamsFile: paramsFiles,
 			},
 		)
 		if err != nil {
@@ -63,6 +63,8 @@ not display healthy checks.`,
 		}
 
 		rpcParams := getParamsRPC(cmd)
+		parallelism, kindParallelism := getParallelismRPC(cmd)
+		targets, excludes := getPruneRPC(cmd)
 
 		verifyModules := viper.GetBool("verify-modules")
 		if !verifyModules {
@@ -78,9 +80,14 @@ not display healthy checks.`,
 			stream, err := client.HealthCheck(
 				ctx,
 				&pb.LoadRequest{
-					Location:   fname,
-					Parameters: rpcParams,
-					Verify:     verifyModules,
+					Location:        fname,
+					Parameters:      rpcParams,
+					ParamsFile:      paramsFiles,
+					Verify:          verifyModules,
+					Parallelism:     parallelism,
+					KindParallelism: kindParallelism,
+					Targets:         targets,
+					Excludes:        excludes,
 				},
 			)
 			if err != nil {
@@ -149,6 +156,8 @@ func init() {
 	registerLocalRPCFlags(healthcheckCmd.Flags())
 	registerSSLFlags(healthcheckCmd.Flags())
 	registerParamsFlags(healthcheckCmd.Flags())
+	registerParallelismFlags(healthcheckCmd.Flags())
+	registerPruneFlags(healthcheckCmd.Flags())
 
 	RootCmd.AddCommand(healthcheckCmd)
 }
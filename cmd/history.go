@@ -0,0 +1,209 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/context"
+)
+
+const historyDirFlagName = "history-dir"
+
+func registerHistoryFlags(flags *pflag.FlagSet) {
+	flags.String(historyDirFlagName, history.DefaultDir, "directory to record runs in (empty to disable)")
+}
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "show recorded runs",
+	Long: `the server records every plan, apply, and healthcheck it runs, including
+the status of every node. Use the subcommands to see what was run and when.`,
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list recorded runs, newest first",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		GracefulExit(cancel)
+
+		hlog := log.WithField("component", "client")
+
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			hlog.WithError(err).Fatal("could not read limit")
+		}
+
+		client := getHistoryClientOrDie(ctx, hlog)
+
+		runs, err := client.List(ctx, limit)
+		if err != nil {
+			hlog.WithError(err).Fatal("could not list runs")
+		}
+
+		printRuns(os.Stdout, runs)
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "show the details of a recorded run",
+	Long: `show the details of a recorded run, as they were printed when the run
+finished. Any unique prefix of a run ID can be used.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("Need one run ID as argument, got %d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		GracefulExit(cancel)
+
+		hlog := log.WithField("component", "client").WithField("id", args[0])
+
+		client := getHistoryClientOrDie(ctx, hlog)
+
+		run, err := client.Get(ctx, args[0])
+		if err != nil {
+			hlog.WithError(err).Fatal("could not get run")
+		}
+
+		printRunHeader(os.Stdout, run)
+
+		out, err := getPrinter().Show(ctx, runGraph(run))
+		if err != nil {
+			hlog.WithError(err).Fatal("failed to print results")
+		}
+
+		fmt.Print("\n")
+		fmt.Print(out)
+	},
+}
+
+func getHistoryClientOrDie(ctx context.Context, logger *log.Entry) *rpc.HistoryClient {
+	maybeSetToken()
+
+	if err := maybeStartSelfHostedRPC(ctx); err != nil {
+		logger.WithError(err).Fatal("could not start RPC")
+	}
+
+	client, err := rpc.NewHistoryClient(ctx, getServerURL().Host, getSecurityConfig())
+	if err != nil {
+		logger.WithError(err).Fatal("could not get client")
+	}
+
+	return client
+}
+
+func printRuns(w io.Writer, runs []*history.Run) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCOMMAND\tSTATUS\tSTARTED\tDURATION\tCHANGES\tERRORS\tLOCATION")
+	for _, run := range runs {
+		changes, errors := run.Summary()
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			run.ID,
+			run.Command,
+			run.Status,
+			run.Start.Local().Format(time.RFC3339),
+			runDuration(run),
+			changes,
+			errors,
+			run.Location,
+		)
+	}
+	tw.Flush()
+}
+
+func printRunHeader(w io.Writer, run *history.Run) {
+	changes, errors := run.Summary()
+
+	fmt.Fprintf(w, "ID:       %s\n", run.ID)
+	fmt.Fprintf(w, "Command:  %s\n", run.Command)
+	fmt.Fprintf(w, "Location: %s\n", run.Location)
+	if len(run.Parameters) > 0 {
+		var keys []string
+		for key := range run.Parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintln(w, "Parameters:")
+		for _, key := range keys {
+			fmt.Fprintf(w, "  %s = %q\n", key, run.Parameters[key])
+		}
+	}
+	fmt.Fprintf(w, "Started:  %s\n", run.Start.Local().Format(time.RFC3339))
+	fmt.Fprintf(w, "Duration: %s\n", runDuration(run))
+	fmt.Fprintf(w, "Status:   %s (%d changes, %d errors)\n", run.Status, changes, errors)
+	if run.Error != "" {
+		fmt.Fprintf(w, "Error:    %s\n", run.Error)
+	}
+}
+
+// runGraph rebuilds the graph of a run so that it can be printed
+func runGraph(run *history.Run) *graph.Graph {
+	g := graph.New()
+	for _, edge := range run.Edges {
+		g.Connect(edge.Source, edge.Dest)
+	}
+	for id, recorded := range run.Nodes {
+		if recorded.Details != nil {
+			g.Add(node.New(id, recorded.Details.ToPrintable()))
+		}
+	}
+	return g
+}
+
+// runDuration formats the duration of a run to the millisecond
+func runDuration(run *history.Run) string {
+	if run.End.IsZero() {
+		return "-"
+	}
+	return (run.Duration() / time.Millisecond * time.Millisecond).String()
+}
+
+func init() {
+	historyListCmd.Flags().Int("limit", 20, "maximum number of runs to list (0 lists all runs)")
+	historyShowCmd.Flags().Bool("show-meta", false, "show metadata (params, locals, and modules)")
+	historyShowCmd.Flags().Bool("only-show-changes", false, "only show changes")
+
+	for _, sub := range []*cobra.Command{historyListCmd, historyShowCmd} {
+		registerRPCFlags(sub.Flags())
+		registerLocalRPCFlags(sub.Flags())
+		registerSSLFlags(sub.Flags())
+		historyCmd.AddCommand(sub)
+	}
+
+	RootCmd.AddCommand(historyCmd)
+}
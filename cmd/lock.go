@@ -0,0 +1,95 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/lock"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+const (
+	lockFileFlagName    = "lock-file"
+	lockTimeoutFlagName = "lock-timeout"
+)
+
+func registerLockFileFlag(flags *pflag.FlagSet) {
+	flags.String(lockFileFlagName, lock.DefaultPath, "host-wide lock taken around applies (empty to disable)")
+}
+
+func registerLockFlags(flags *pflag.FlagSet) {
+	registerLockFileFlag(flags)
+	flags.Duration(lockTimeoutFlagName, 0, "how long to wait for another apply to release the lock (0 fails immediately)")
+}
+
+// getLockTimeout reads the lock timeout, logging and exiting upon error
+func getLockTimeout(cmd *cobra.Command) time.Duration {
+	timeout, err := cmd.Flags().GetDuration(lockTimeoutFlagName)
+	if err != nil {
+		log.WithError(err).Fatal("could not read lock timeout")
+	}
+	return timeout
+}
+
+// getLockTimeoutRPC formats the lock timeout for a LoadRequest
+func getLockTimeoutRPC(cmd *cobra.Command) string {
+	timeout := getLockTimeout(cmd)
+	if timeout == 0 {
+		return ""
+	}
+	return timeout.String()
+}
+
+// lockRequired is true when the lock file was set explicitly. The default lock
+// is only taken if it can be, since users other than root usually cannot write
+// to its directory.
+func lockRequired(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed(lockFileFlagName)
+}
+
+// acquireLocalApplyLock takes the apply lock when the RPC server is self-hosted.
+// Remote servers take the lock themselves. The returned function releases the
+// lock and may be called more than once.
+func acquireLocalApplyLock(ctx context.Context, cmd *cobra.Command, module string) func() {
+	path := viper.GetString(lockFileFlagName)
+	if !getLocal() || path == "" {
+		return func() {}
+	}
+
+	llog := log.WithField("component", "client").WithField("lock", path)
+
+	held, err := lock.Acquire(ctx, path, lock.NewHolder(module), getLockTimeout(cmd))
+	if _, unavailable := err.(*lock.UnavailableError); unavailable && !lockRequired(cmd) {
+		llog.WithError(err).Warn("could not open apply lock, applying without it (set --lock-file to require a lock)")
+		return func() {}
+	} else if err != nil {
+		llog.WithError(err).Fatal("could not acquire apply lock")
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if err := held.Release(); err != nil {
+				llog.WithError(err).Warn("could not release apply lock")
+			}
+		})
+	}
+}
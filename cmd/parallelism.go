@@ -0,0 +1,76 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	parallelismFlagName     = "parallelism"
+	kindParallelismFlagName = "kind-parallelism"
+)
+
+func registerParallelismFlags(flags *pflag.FlagSet) {
+	flags.Int(parallelismFlagName, 0, "maximum number of nodes to evaluate at once (0 is unlimited)")
+	flags.StringSlice(kindParallelismFlagName, []string{}, "maximum number of nodes of a kind to evaluate at once, in kind=N format (for example package=1)")
+}
+
+// parseKindLimits parses a list of kind=N pairs into a map of kind to limit
+func parseKindLimits(pairs []string) (map[string]int32, error) {
+	limits := map[string]int32{}
+	for _, raw := range pairs {
+		kind, value, err := parseKVPair(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		limit, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed limit for %q: %v", kind, value)
+		}
+
+		if _, duplicate := limits[kind]; duplicate {
+			return nil, fmt.Errorf("duplicate limit for %q", kind)
+		}
+		limits[kind] = int32(limit)
+	}
+	return limits, nil
+}
+
+// getParallelismRPC reads the parallelism flags, logging and exiting upon error
+func getParallelismRPC(cmd *cobra.Command) (int32, map[string]int32) {
+	parallelism, err := cmd.Flags().GetInt(parallelismFlagName)
+	if err != nil {
+		log.WithError(err).Fatal("could not read parallelism")
+	}
+
+	rawKinds, err := cmd.Flags().GetStringSlice(kindParallelismFlagName)
+	if err != nil {
+		log.WithError(err).Fatal("could not read kind parallelism")
+	}
+
+	kinds, err := parseKindLimits(rawKinds)
+	if err != nil {
+		log.WithError(err).Fatal("could not parse kind parallelism")
+	}
+
+	return int32(parallelism), kinds
+}
@@ -0,0 +1,46 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKindLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		limits, err := parseKindLimits([]string{"package=1", "docker.image=2"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int32{"package": 1, "docker.image": 2}, limits)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := parseKindLimits([]string{"package"})
+		assert.Error(t, err)
+	})
+
+	t.Run("not a number", func(t *testing.T) {
+		_, err := parseKindLimits([]string{"package=one"})
+		assert.EqualError(t, err, `malformed limit for "package": one`)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := parseKindLimits([]string{"package=1", "package=2"})
+		assert.EqualError(t, err, `duplicate limit for "package"`)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/render"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -29,9 +30,14 @@ import (
 var paramsJSON string
 var params []string
 
+// paramsFiles are sent over RPC as-is, to be read by the server relative to its
+// root. Params given with --params or --paramsJSON take precedence over them.
+var paramsFiles []string
+
 func registerParamsFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&paramsJSON, "paramsJSON", "{}", "parameters for the top-level module, in JSON format")
 	flags.StringSliceVarP(&params, "params", "p", []string{}, "parameters for the top-level module in key=value format")
+	flags.StringSliceVar(&paramsFiles, "params-file", []string{}, "YAML, JSON, or HCL file of parameters for the top-level module (may be repeated, later files take precedence)")
 }
 
 // parseKVPair parses an input of the form "key=value" into its
@@ -114,7 +120,12 @@ func getParamsRPC(cmd *cobra.Command) map[string]string {
 
 	clientParams := map[string]string{}
 	for k, v := range params {
-		clientParams[k] = fmt.Sprintf("%v", v)
+		// lists and maps are sent as JSON so that macros can iterate over them
+		encoded, err := load.EncodeParam(v)
+		if err != nil {
+			log.WithError(err).WithField("param", k).Fatal("could not encode parameter")
+		}
+		clientParams[k] = encoded
 	}
 
 	return clientParams
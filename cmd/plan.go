@@ -23,6 +23,7 @@ import (
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/rpc/pb"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -39,6 +40,9 @@ can be done separately to see what needs to be changed before execution.`,
 		if len(args) == 0 {
 			return errors.New("Need at least one module filename as argument, got 0")
 		}
+		if len(args) > 1 && viper.GetString("out") != "" {
+			return fmt.Errorf("Can only save the plan of a single module, got %d", len(args))
+		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -63,6 +67,8 @@ can be done separately to see what needs to be changed before execution.`,
 		}
 
 		rpcParams := getParamsRPC(cmd)
+		parallelism, kindParallelism := getParallelismRPC(cmd)
+		targets, excludes := getPruneRPC(cmd)
 
 		verifyModules := viper.GetBool("verify-modules")
 		if !verifyModules {
@@ -78,9 +84,15 @@ can be done separately to see what needs to be changed before execution.`,
 			stream, err := client.Plan(
 				ctx,
 				&pb.LoadRequest{
-					Location:   fname,
-					Parameters: rpcParams,
-					Verify:     verifyModules,
+					Location:        fname,
+					Parameters:      rpcParams,
+					ParamsFile:      paramsFiles,
+					Verify:          verifyModules,
+					Parallelism:     parallelism,
+					KindParallelism: kindParallelism,
+					Targets:         targets,
+					Excludes:        excludes,
+					FailFast:        viper.GetBool("fail-fast"),
 				},
 			)
 			if err != nil {
@@ -98,6 +110,22 @@ can be done separately to see what needs to be changed before execution.`,
 				g.Connect(edge.Source, edge.Dest)
 			}
 
+			// record the params after the server merged in the params files, so
+			// the saved plan does not depend on files that may change
+			params, err := getParameters(stream)
+			if err != nil {
+				flog.WithError(err).Fatal("error getting RPC metadata")
+			}
+
+			saved := plan.NewSaved(fname, params, verifyModules)
+			saved.Targets = targets
+			saved.Excludes = excludes
+			saved.Edges = edges
+			saved.Checksums, err = getChecksums(stream)
+			if err != nil {
+				flog.WithError(err).Fatal("error getting RPC metadata")
+			}
+
 			timer := new(TimerDisplay)
 			timer.Start()
 			oldOut := flog.Logger.Out
@@ -125,10 +153,11 @@ can be done separately to see what needs to be changed before execution.`,
 						details := resp.GetDetails()
 						if details != nil {
 							printable := details.ToPrintable()
-							if printable.Error() != nil {
+							if printable.Error() != nil && !details.IgnoreErrors {
 								planError = true
 							}
 							g.Add(node.New(resp.Id, printable))
+							saved.AddNode(resp.Meta.Id, details.HasChanges, printable.Changes(), details.Fields)
 						}
 
 					default:
@@ -160,18 +189,29 @@ can be done separately to see what needs to be changed before execution.`,
 			if planError {
 				os.Exit(1)
 			}
+
+			if outFile := viper.GetString("out"); outFile != "" {
+				if err := writeSavedPlan(outFile, saved); err != nil {
+					flog.WithError(err).Fatal("could not save plan")
+				}
+				flog.WithField("out", outFile).Info("saved plan")
+			}
 		}
 	},
 }
 
 func init() {
-	planCmd.Flags().Bool("show-meta", false, "show metadata (params and modules)")
+	planCmd.Flags().Bool("show-meta", false, "show metadata (params, locals, and modules)")
 	planCmd.Flags().Bool("only-show-changes", false, "only show changes")
 	planCmd.Flags().Bool("verify-modules", false, "verify module signatures")
+	planCmd.Flags().Bool("fail-fast", false, "stop starting new nodes as soon as any node fails")
+	planCmd.Flags().String("out", "", "save the plan to this file so it can be applied later")
 	registerRPCFlags(planCmd.Flags())
 	registerLocalRPCFlags(planCmd.Flags())
 	registerSSLFlags(planCmd.Flags())
 	registerParamsFlags(planCmd.Flags())
+	registerParallelismFlags(planCmd.Flags())
+	registerPruneFlags(planCmd.Flags())
 
 	RootCmd.AddCommand(planCmd)
 }
@@ -0,0 +1,47 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	targetFlagName  = "target"
+	excludeFlagName = "exclude"
+)
+
+func registerPruneFlags(flags *pflag.FlagSet) {
+	flags.StringSlice(targetFlagName, []string{}, "only evaluate these nodes, their children, and their dependencies (for example module.web/file.content.index)")
+	flags.StringSlice(excludeFlagName, []string{}, "do not evaluate nodes with these IDs or kinds, or their children (for example package)")
+}
+
+// getPruneRPC reads the target and exclude flags, logging and exiting upon
+// error
+func getPruneRPC(cmd *cobra.Command) (targets, excludes []string) {
+	targets, err := cmd.Flags().GetStringSlice(targetFlagName)
+	if err != nil {
+		log.WithError(err).Fatal("could not read targets")
+	}
+
+	excludes, err = cmd.Flags().GetStringSlice(excludeFlagName)
+	if err != nil {
+		log.WithError(err).Fatal("could not read excludes")
+	}
+
+	return targets, excludes
+}
@@ -50,11 +50,14 @@ func registerRPCFlags(flags *pflag.FlagSet) {
 func registerLocalRPCFlags(flags *pflag.FlagSet) {
 	flags.String(rpcLocalAddrName, addrServerLocal, "address for local RPC connection")
 	flags.Bool(rpcEnableLocalName, false, "self host RPC")
+	registerHistoryFlags(flags)
 }
 
 func maybeStartSelfHostedRPC(ctx context.Context) error {
 	if getLocal() {
-		go startRPC(ctx)
+		// the self-hosted server only serves this process, which takes the apply
+		// lock itself
+		go startRPC(ctx, "", false)
 
 		var err error
 		for i := 0; i < 5; i++ {
@@ -71,7 +74,7 @@ func maybeStartSelfHostedRPC(ctx context.Context) error {
 	return nil
 }
 
-func startRPC(ctx context.Context) error {
+func startRPC(ctx context.Context, lockFile string, lockRequired bool) error {
 	// set context for logging
 	logger := logging.GetLogger(ctx).WithField("component", "rpc")
 	ctx = logging.WithLogger(ctx, logger)
@@ -88,6 +91,9 @@ func startRPC(ctx context.Context) error {
 		Security:             getSecurityConfig(),
 		ResourceRoot:         viper.GetString("root"),
 		EnableBinaryDownload: viper.GetBool("self-serve"),
+		HistoryDir:           viper.GetString(historyDirFlagName),
+		LockFile:             lockFile,
+		LockRequired:         lockRequired,
 	}
 
 	return server.Listen(ctx, loc)
@@ -151,6 +157,54 @@ func getMeta(stream headerer) ([]*graph.Edge, error) {
 	return edges, nil
 }
 
+func getChecksums(stream headerer) (map[string]string, error) {
+	meta, err := stream.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting RPC header")
+	}
+
+	checksums := map[string]string{}
+	if blobs, ok := meta["checksums"]; ok {
+		for _, blob := range blobs {
+			var out map[string]string
+			err := json.Unmarshal([]byte(blob), &out)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not deserialize checksum metadata")
+			}
+
+			for id, sum := range out {
+				checksums[id] = sum
+			}
+		}
+	}
+
+	return checksums, nil
+}
+
+func getParameters(stream headerer) (map[string]string, error) {
+	meta, err := stream.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting RPC header")
+	}
+
+	params := map[string]string{}
+	if blobs, ok := meta["parameters"]; ok {
+		for _, blob := range blobs {
+			var out map[string]string
+			err := json.Unmarshal([]byte(blob), &out)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not deserialize parameter metadata")
+			}
+
+			for name, value := range out {
+				params[name] = value
+			}
+		}
+	}
+
+	return params, nil
+}
+
 // More getters
 
 func setLocal(local bool)  { viper.Set(rpcEnableLocalName, local) }
@@ -0,0 +1,58 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/asteris-llc/converge/plan"
+	"github.com/pkg/errors"
+)
+
+// maybeReadSavedPlan reads a saved plan from a local file. If the file does not
+// exist locally or is not a saved plan, the returned plan is nil. The raw
+// content is returned so it can be sent over RPC unchanged.
+func maybeReadSavedPlan(fname string) (*plan.Saved, []byte, error) {
+	stat, err := os.Stat(fname)
+	if err != nil || stat.IsDir() {
+		return nil, nil, nil
+	}
+
+	content, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read %s", fname)
+	}
+
+	saved, err := plan.ReadSaved(bytes.NewReader(content))
+	if err == plan.ErrNotSaved {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read saved plan %s", fname)
+	}
+
+	return saved, content, nil
+}
+
+// writeSavedPlan writes a saved plan to a local file
+func writeSavedPlan(fname string, saved *plan.Saved) error {
+	var buf bytes.Buffer
+	if err := saved.Write(&buf); err != nil {
+		return errors.Wrap(err, "could not serialize plan")
+	}
+
+	return errors.Wrapf(ioutil.WriteFile(fname, buf.Bytes(), 0600), "could not write plan to %s", fname)
+}
@@ -0,0 +1,142 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/fetch"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/keystore"
+	"github.com/asteris-llc/converge/secret"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "work with encrypted secrets",
+	Long: `Secrets are values encrypted to OpenPGP keys in the keystore. Modules
+decrypt them with the secret template function, and the decrypted values are
+shown as <sensitive> in plans, applies, and the RPC.`,
+}
+
+var secretEncryptCmd = &cobra.Command{
+	Use:   "encrypt [value]",
+	Short: "encrypt a value for use with the secret template function",
+	Long: `Encrypt a value to keys in the keystore. The value is read from standard
+input if it is not given as an argument, without its trailing newline.
+
+The value is encrypted to the keys given with --key, which may be trusted keys
+or secret keys. If no keys are given, the value is encrypted to every secret key
+in the keystore. The result is printed as a single line of base64 that can be
+used in a module or passed as a param:
+
+	file.content "db-password" {
+	  destination = "/etc/app/db-password"
+	  content     = "{{param ` + "`db-password`" + ` | secret}}"
+	}`,
+	Example: `converge secret encrypt --key 74fdf669f18d59f92b0aaccd720351ff475cc928 hunter2
+converge secret encrypt < password.txt`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("Need at most one value as argument, got %d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		slog := log.WithField("component", "client")
+
+		var plaintext []byte
+		if len(args) == 1 {
+			plaintext = []byte(args[0])
+		} else {
+			in, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				slog.WithError(err).Fatal("could not read value")
+			}
+			plaintext = []byte(strings.TrimSuffix(string(in), "\n"))
+		}
+
+		keys, err := cmd.Flags().GetStringSlice("key")
+		if err != nil {
+			slog.WithError(err).Fatal("could not read keys")
+		}
+
+		if len(plaintext) < secret.MinLength {
+			slog.Warnf("values shorter than %d characters are not masked in output when they are decrypted", secret.MinLength)
+		}
+
+		encrypted, err := secret.Encrypt(keystore.Default(), plaintext, keys)
+		if err != nil {
+			slog.WithError(err).Fatal("could not encrypt value")
+		}
+
+		fmt.Println(encrypted)
+	},
+}
+
+var secretImportCmd = &cobra.Command{
+	Use:   "import <key>",
+	Short: "import a secret key for decrypting secrets",
+	Long: `Add an ASCII-armored OpenPGP secret key to the keystore so that secrets
+encrypted to it can be decrypted on this machine. The key must not be protected
+by a passphrase.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("Need one key path as argument, got %d", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		GracefulExit(cancel)
+
+		slog := log.WithField("component", "client")
+		ctx = logging.WithLogger(ctx, slog)
+
+		url, err := fetch.ResolveInContext(args[0], "")
+		if err != nil {
+			slog.WithError(err).Fatal("could not get url")
+		}
+
+		ulog := slog.WithField("url", url)
+
+		key, err := fetch.Any(ctx, url)
+		if err != nil {
+			ulog.WithError(err).Fatal("could not retrieve key")
+		}
+
+		keypath, err := keystore.Default().StoreSecretKey(key)
+		if err != nil {
+			ulog.WithError(err).Fatal("could not add key")
+		}
+
+		ulog.Info("stored secret key at ", keypath)
+	},
+}
+
+func init() {
+	secretEncryptCmd.Flags().StringSlice("key", nil, "fingerprint of a key to encrypt to (may be repeated)")
+
+	secretCmd.AddCommand(secretEncryptCmd)
+	secretCmd.AddCommand(secretImportCmd)
+	RootCmd.AddCommand(secretCmd)
+}
@@ -57,7 +57,7 @@ var serverCmd = &cobra.Command{
 		setLocal(false) // unset local so we get the right flag addresses
 
 		// start RPC server
-		if err := startRPC(ctx); err != nil {
+		if err := startRPC(ctx, viper.GetString(lockFileFlagName), lockRequired(cmd)); err != nil {
 			log.WithError(err).Fatal("serving failed")
 		}
 	},
@@ -73,6 +73,8 @@ func init() {
 	// API
 	serverCmd.Flags().String("root", ".", "location of modules to serve")
 	serverCmd.Flags().Bool("self-serve", false, "serve own binary for bootstrapping")
+	registerHistoryFlags(serverCmd.Flags())
+	registerLockFileFlag(serverCmd.Flags())
 
 	// set RPC logging to use logrus
 	grpclog.SetLogger(log.WithField("component", "grpc"))
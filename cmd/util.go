@@ -28,7 +28,7 @@ import (
 
 func humanProvider(filter human.FilterFunc) *human.Printer {
 	if !viper.GetBool("show-meta") {
-		filter = human.HideByKind("module", "param", "root")
+		filter = human.HideByKind("module", "param", "local", "root")
 	}
 	if viper.GetBool("only-show-changes") {
 		filter = human.AndFilter(human.ShowOnlyChanged, filter)
@@ -0,0 +1,42 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import "fmt"
+
+// DependencyError is the error of nodes that were not run because one of their
+// dependencies failed
+type DependencyError struct {
+	Dependency string
+}
+
+func (e *DependencyError) Error() string {
+	return fmt.Sprintf("error in dependency %q", e.Dependency)
+}
+
+// BlocksDependents returns whether a node that ended with err keeps the nodes
+// that depend on it from running. Ignoring errors only covers the node's own
+// failure: a node that was not run because of a failed dependency blocks its
+// dependents in turn, so they never run after a failure further down the
+// chain.
+func BlocksDependents(err error, ignoreErrors bool) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*DependencyError); ok {
+		return true
+	}
+	return !ignoreErrors
+}
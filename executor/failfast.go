@@ -0,0 +1,82 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+var failFastKey = struct{ name string }{"fail-fast"}
+
+// FailFast tracks the first failure in a run so that no new work is started
+// after it. A nil *FailFast never fails, so it is safe to use when fail-fast
+// is not enabled.
+type FailFast struct {
+	lock   sync.RWMutex
+	failed string
+}
+
+// WithFailFast enables fail-fast for walks started with the returned context
+func WithFailFast(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failFastKey, new(FailFast))
+}
+
+// GetFailFast retrieves the fail-fast state from a context, or nil if it is
+// not enabled
+func GetFailFast(ctx context.Context) *FailFast {
+	if ff, ok := ctx.Value(failFastKey).(*FailFast); ok {
+		return ff
+	}
+	return nil
+}
+
+// FailFastError is the error of nodes that were not run because an earlier node
+// failed while failing fast
+type FailFastError struct {
+	Failed string
+}
+
+func (e *FailFastError) Error() string {
+	return fmt.Sprintf("not run: failing fast after error in %q", e.Failed)
+}
+
+// Fail records the failure of a node. Only the first failure is kept.
+func (ff *FailFast) Fail(id string) {
+	if ff == nil {
+		return
+	}
+
+	ff.lock.Lock()
+	defer ff.lock.Unlock()
+
+	if ff.failed == "" {
+		ff.failed = id
+	}
+}
+
+// Failed returns the ID of the first node to fail, if any
+func (ff *FailFast) Failed() (string, bool) {
+	if ff == nil {
+		return "", false
+	}
+
+	ff.lock.RLock()
+	defer ff.lock.RUnlock()
+
+	return ff.failed, ff.failed != ""
+}
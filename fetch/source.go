@@ -0,0 +1,108 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RegistryModuleFile is the file a versioned module is loaded from, in the
+// directory for its version
+const RegistryModuleFile = "main.hcl"
+
+// Source is where a module call is loaded from
+type Source struct {
+	// URL the module is fetched from
+	URL string
+
+	// Version of the module, for modules from a registry
+	Version string
+}
+
+// IsVersioned tests whether the source refers to a version of a module in a
+// registry
+func IsVersioned(loc string) bool {
+	_, rest := parse(loc)
+	return strings.Contains(rest, "//")
+}
+
+// ResolveSource resolves the source of a module call relative to the module
+// making it. Sources like "https://example.com/modules//nginx?version=1.2"
+// refer to a version of a module in a registry. A registry is a directory with
+// a directory for each module, which in turn has a directory for each version,
+// so that module is loaded from
+// "https://example.com/modules/nginx/1.2/main.hcl". Other sources are resolved
+// with ResolveInContext.
+func ResolveSource(loc, ctx string) (*Source, error) {
+	if !IsVersioned(loc) {
+		resolved, err := ResolveInContext(loc, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Source{URL: resolved}, nil
+	}
+
+	scheme, rest := parse(loc)
+
+	var query string
+	if idx := strings.Index(rest, "?"); idx >= 0 {
+		rest, query = rest[:idx], rest[idx+1:]
+	}
+
+	parts := strings.SplitN(rest, "//", 2)
+	registry, name := parts[0], strings.Trim(parts[1], "/")
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid query: %s", loc, err)
+	}
+	version := values.Get("version")
+
+	switch {
+	case registry == "":
+		return nil, fmt.Errorf("%s: missing registry before //", loc)
+	case name == "":
+		return nil, fmt.Errorf("%s: missing module name after //", loc)
+	case version == "":
+		return nil, fmt.Errorf("%s: missing version, as in ?version=1.0", loc)
+	case !validSegment(version):
+		return nil, fmt.Errorf("%s: invalid version %q", loc, version)
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if !validSegment(segment) {
+			return nil, fmt.Errorf("%s: invalid module name %q", loc, name)
+		}
+	}
+
+	// only relative registries are resolved in context, since the context of a
+	// module from a registry is usually another registry
+	if scheme != "" {
+		registry = scheme + "://" + registry
+	} else if registry, err = ResolveInContext(registry, ctx); err != nil {
+		return nil, err
+	}
+
+	return &Source{
+		URL:     strings.Join([]string{strings.TrimRight(registry, "/"), name, version, RegistryModuleFile}, "/"),
+		Version: version,
+	}, nil
+}
+
+func validSegment(segment string) bool {
+	return segment != "" && segment != "." && segment != ".." && !strings.ContainsAny(segment, "/\\")
+}
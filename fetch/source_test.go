@@ -0,0 +1,70 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSource(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		loc, ctx string
+		expected fetch.Source
+	}{
+		{
+			"basic.hcl", "file:///a/main.hcl",
+			fetch.Source{URL: "file:///a/basic.hcl"},
+		},
+		{
+			"https://example.com/modules//nginx?version=1.2", "file:///a/main.hcl",
+			fetch.Source{URL: "https://example.com/modules/nginx/1.2/main.hcl", Version: "1.2"},
+		},
+		{
+			"https://example.com/modules//nginx?version=1.2", "https://other.com/x/1.0/main.hcl",
+			fetch.Source{URL: "https://example.com/modules/nginx/1.2/main.hcl", Version: "1.2"},
+		},
+		{
+			"registry//web/nginx/?version=1.2", "file:///a/main.hcl",
+			fetch.Source{URL: "file:///a/registry/web/nginx/1.2/main.hcl", Version: "1.2"},
+		},
+	} {
+		source, err := fetch.ResolveSource(test.loc, test.ctx)
+		require.NoError(t, err, test.loc)
+		assert.Equal(t, test.expected, *source, test.loc)
+	}
+}
+
+func TestResolveSourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	for loc, msg := range map[string]string{
+		"https://example.com//nginx":                 "https://example.com//nginx: missing version, as in ?version=1.0",
+		"https://example.com//?version=1":            "https://example.com//?version=1: missing module name after //",
+		"//nginx?version=1":                          "//nginx?version=1: missing registry before //",
+		"https://example.com//nginx?version=..":      `https://example.com//nginx?version=..: invalid version ".."`,
+		"https://example.com//../nginx?version=1.0":  `https://example.com//../nginx?version=1.0: invalid module name "../nginx"`,
+		"https://example.com//nginx?version=%zz":     "https://example.com//nginx?version=%zz: invalid query: invalid URL escape \"%zz\"",
+		"https://example.com//nginx?version=1.0/../": `https://example.com//nginx?version=1.0/../: invalid version "1.0/../"`,
+	} {
+		_, err := fetch.ResolveSource(loc, "")
+		assert.EqualError(t, err, msg, loc)
+	}
+}
@@ -36,6 +36,22 @@ type TransformFunc func(*node.Node, *Graph) error
 
 type walkerFunc func(context.Context, *Graph, WalkFunc) error
 
+// NodeError is an error that already names the node it came from. Walks add
+// the ID of the failing node to every other error, but not to these.
+type NodeError struct {
+	ID  string
+	Err error
+}
+
+func (e *NodeError) Error() string {
+	return e.ID + ": " + e.Err.Error()
+}
+
+// Cause returns the underlying error
+func (e *NodeError) Cause() error {
+	return e.Err
+}
+
 // An Edge is a generic pair of IDs indicating a directed edge in the graph
 type Edge struct {
 	Source     string   `json:"source"`
@@ -294,6 +310,9 @@ func (g *Graph) Dependencies(id string) []string {
 func (g *Graph) dependencies(id string, carry map[string]struct{}) map[string]struct{} {
 	for _, edge := range g.DownEdges(id) {
 		elem := edge.Target().(string)
+		if _, seen := carry[elem]; seen {
+			continue
+		}
 		carry[elem] = struct{}{}
 		carry = g.dependencies(elem, carry)
 	}
@@ -353,6 +372,9 @@ func dependencyWalk(rctx context.Context, g *Graph, cb WalkFunc) error {
 
 	wait := new(sync.WaitGroup)
 
+	// limit the number of callbacks running at once, if requested
+	limits := newSemaphores(GetParallelism(rctx))
+
 	// keep track of what we've scheduled so we don't schedule the same work
 	// twice
 	var worker func(id string)
@@ -459,6 +481,12 @@ func dependencyWalk(rctx context.Context, g *Graph, cb WalkFunc) error {
 			return
 		}
 
+		release, err := limits.acquire(ctx, id)
+		if err != nil {
+			return
+		}
+		defer release()
+
 		logger.WithField("id", id).Debug("executing")
 		val, _ := g.Get(id)
 		if err := cb(val); err != nil {
@@ -477,7 +505,10 @@ func dependencyWalk(rctx context.Context, g *Graph, cb WalkFunc) error {
 			if v == errDepFailed {
 				continue
 			}
-			err = multierror.Append(err, errors.Wrap(v, k))
+			if named, ok := v.(*NodeError); !ok || named.ID != k {
+				v = errors.Wrap(v, k)
+			}
+			err = multierror.Append(err, v)
 		}
 		return err
 	}
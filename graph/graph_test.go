@@ -446,6 +446,39 @@ func TestWalkError(t *testing.T) {
 	}
 }
 
+func TestWalkNodeError(t *testing.T) {
+	g := graph.New()
+
+	g.Add(node.New("a", nil))
+	g.Add(node.New("b", nil))
+
+	g.ConnectParent("a", "b")
+
+	walk := func(err error) error {
+		return g.Walk(
+			context.Background(),
+			func(meta *node.Node) error {
+				if meta.ID == "b" {
+					return err
+				}
+				return nil
+			},
+		)
+	}
+
+	t.Run("same node", func(t *testing.T) {
+		err := walk(&graph.NodeError{ID: "b", Err: errors.New("test")})
+
+		assert.EqualError(t, err, "1 error(s) occurred:\n\n* b: test")
+	})
+
+	t.Run("other node", func(t *testing.T) {
+		err := walk(&graph.NodeError{ID: "other", Err: errors.New("test")})
+
+		assert.EqualError(t, err, "1 error(s) occurred:\n\n* b: other: test")
+	})
+}
+
 func TestValidateNoRoot(t *testing.T) {
 	// Validate should error if there is no root
 	t.Parallel()
@@ -48,3 +48,10 @@ func IsDescendentID(parent, child string) bool {
 func IsRoot(id string) bool {
 	return id == "root"
 }
+
+// MatchesKind checks if the end of the ID is of the given kind. For example,
+// "root/package.apt.x" matches "package" and "package.apt" but not "pack".
+func MatchesKind(id, kind string) bool {
+	base := BaseID(id)
+	return base == kind || strings.HasPrefix(base, kind+".")
+}
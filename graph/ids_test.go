@@ -69,3 +69,25 @@ func TestIsRoot(t *testing.T) {
 		assert.False(t, graph.IsRoot("root/module.test"))
 	})
 }
+
+// TestMatchesKind tests the MatchesKind function
+func TestMatchesKind(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exact", func(t *testing.T) {
+		assert.True(t, graph.MatchesKind("root/package.apt.x", "package.apt.x"))
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		assert.True(t, graph.MatchesKind("root/package.apt.x", "package"))
+		assert.True(t, graph.MatchesKind("root/package.apt.x", "package.apt"))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		assert.False(t, graph.MatchesKind("root/package.apt.x", "pack"))
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		assert.False(t, graph.MatchesKind("root/module.x/file.content.y", "module"))
+	})
+}
@@ -86,9 +86,9 @@ func MergeDuplicates(ctx context.Context, g *Graph, skip SkipMergeFunc) (*Graph,
 // helper functions for various things we need to merge.
 // TODO: find a better home
 
-// SkipModuleAndParams skips trimming modules and params
+// SkipModuleAndParams skips trimming modules, params, outputs, and locals
 func SkipModuleAndParams(meta *node.Node) bool {
 	base := BaseID(meta.ID)
 	_, isConditional := meta.LookupMetadata("conditional-switch-name")
-	return strings.HasPrefix(base, "module") || strings.HasPrefix(base, "param") || isConditional
+	return strings.HasPrefix(base, "module") || strings.HasPrefix(base, "param") || strings.HasPrefix(base, "output") || strings.HasPrefix(base, "local.") || isConditional
 }
@@ -0,0 +1,114 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+)
+
+var parallelismKey = struct{ name string }{"parallelism"}
+
+// Parallelism bounds the number of WalkFuncs that a dependency walk will run
+// at the same time. Limits of zero or less are unbounded.
+type Parallelism struct {
+	// Max is the maximum number of concurrent WalkFunc invocations
+	Max int
+
+	// PerKind limits concurrent invocations for nodes of a given kind. Keys
+	// match against the base ID of a node, so "package" will match
+	// "package.apt.nginx" and "package.rpm.nginx" while "package.apt" will only
+	// match the former.
+	PerKind map[string]int
+}
+
+// WithParallelism sets parallelism limits on a context. Walks started with
+// the returned context will respect the limits.
+func WithParallelism(ctx context.Context, p Parallelism) context.Context {
+	return context.WithValue(ctx, parallelismKey, p)
+}
+
+// GetParallelism retrieves parallelism limits from a context
+func GetParallelism(ctx context.Context) Parallelism {
+	if p, ok := ctx.Value(parallelismKey).(Parallelism); ok {
+		return p
+	}
+	return Parallelism{}
+}
+
+// semaphores implements the limits in a Parallelism for a single walk
+type semaphores struct {
+	global chan struct{}
+	kinds  map[string]chan struct{}
+}
+
+func newSemaphores(p Parallelism) *semaphores {
+	s := &semaphores{kinds: map[string]chan struct{}{}}
+	if p.Max > 0 {
+		s.global = make(chan struct{}, p.Max)
+	}
+	for kind, limit := range p.PerKind {
+		if limit > 0 {
+			s.kinds[kind] = make(chan struct{}, limit)
+		}
+	}
+	return s
+}
+
+// forID returns the semaphores that apply to the given ID, in the order in
+// which they should be acquired. The order is stable so that two workers can't
+// deadlock by acquiring the same set in different orders.
+func (s *semaphores) forID(id string) (out []chan struct{}) {
+	var kinds []string
+	for kind := range s.kinds {
+		if MatchesKind(id, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		out = append(out, s.kinds[kind])
+	}
+	if s.global != nil {
+		out = append(out, s.global)
+	}
+	return out
+}
+
+// acquire blocks until the node with the given ID can run. The returned
+// function releases the acquired slots. If the context is cancelled first, any
+// slots acquired so far are released and the context error is returned.
+func (s *semaphores) acquire(ctx context.Context, id string) (func(), error) {
+	var held []chan struct{}
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+
+	for _, sem := range s.forID(id) {
+		select {
+		case <-ctx.Done():
+			release()
+			return func() {}, ctx.Err()
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		}
+	}
+
+	return release, nil
+}
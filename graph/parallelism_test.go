@@ -0,0 +1,121 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// TestWalkParallelism tests that walks respect the parallelism limits set on
+// the context
+func TestWalkParallelism(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", nil))
+	for i := 0; i < 10; i++ {
+		for _, kind := range []string{"package.apt", "task"} {
+			id := graph.ID("root", fmt.Sprintf("%s.%d", kind, i))
+			g.Add(node.New(id, nil))
+			g.ConnectParent("root", id)
+		}
+	}
+
+	// maxConcurrent walks the graph and returns the maximum number of
+	// concurrently running callbacks for nodes matching the filter
+	maxConcurrent := func(p graph.Parallelism, filter func(string) bool) int {
+		var (
+			lock           = new(sync.Mutex)
+			running, maxed int
+		)
+
+		ctx := graph.WithParallelism(context.Background(), p)
+		require.NoError(t, g.Walk(ctx, func(meta *node.Node) error {
+			if !filter(meta.ID) {
+				return nil
+			}
+
+			lock.Lock()
+			running++
+			if running > maxed {
+				maxed = running
+			}
+			lock.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+
+			return nil
+		}))
+
+		return maxed
+	}
+
+	all := func(string) bool { return true }
+	packages := func(id string) bool { return strings.HasPrefix(graph.BaseID(id), "package.") }
+
+	t.Run("max", func(t *testing.T) {
+		assert.Equal(t, 2, maxConcurrent(graph.Parallelism{Max: 2}, all))
+	})
+
+	t.Run("per kind", func(t *testing.T) {
+		assert.Equal(t, 1, maxConcurrent(graph.Parallelism{PerKind: map[string]int{"package": 1}}, packages))
+	})
+
+	t.Run("per kind and max", func(t *testing.T) {
+		p := graph.Parallelism{Max: 3, PerKind: map[string]int{"package.apt": 1}}
+		assert.Equal(t, 1, maxConcurrent(p, packages))
+		assert.True(t, maxConcurrent(p, all) <= 3)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		ctx := graph.WithParallelism(context.Background(), graph.Parallelism{Max: 1})
+		out := []string{}
+		require.NoError(t, g.Walk(ctx, func(meta *node.Node) error {
+			out = append(out, meta.ID)
+			return nil
+		}))
+
+		assert.Equal(t, "root", out[len(out)-1])
+	})
+}
+
+// TestGetParallelism tests retrieving parallelism from a context
+func TestGetParallelism(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		assert.Equal(t, graph.Parallelism{}, graph.GetParallelism(context.Background()))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		p := graph.Parallelism{Max: 4}
+		assert.Equal(t, p, graph.GetParallelism(graph.WithParallelism(context.Background(), p)))
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"golang.org/x/net/context"
+)
+
+// Target prunes a graph down to the given target IDs. The targets are kept
+// along with their descendents and transitive dependencies. The ancestors of
+// every kept node are also kept so that the graph remains rooted, but their
+// other children are removed. Target IDs may be given with or without the
+// leading "root/".
+func Target(ctx context.Context, g *Graph, targets []string) (*Graph, error) {
+	if len(targets) == 0 {
+		return g, nil
+	}
+
+	logger := logging.GetLogger(ctx).WithField("function", "Target")
+
+	keep := map[string]struct{}{}
+	for _, target := range targets {
+		id := qualifyID(target)
+		if !g.Contains(id) {
+			return nil, fmt.Errorf("target %q does not exist", target)
+		}
+
+		keep[id] = struct{}{}
+		for _, dep := range g.Dependencies(id) {
+			keep[dep] = struct{}{}
+		}
+	}
+
+	// keep the lineage of everything we're keeping
+	for id := range keep {
+		for parent, ok := g.GetParentID(id); ok; parent, ok = g.GetParentID(parent) {
+			keep[parent] = struct{}{}
+		}
+	}
+
+	out := g.Copy()
+	for _, id := range out.Vertices() {
+		if _, ok := keep[id]; !ok {
+			logger.WithField("id", id).Debug("not targeted, removing")
+			out.Remove(id)
+		}
+	}
+
+	return out, out.Validate()
+}
+
+// Exclude removes nodes from a graph, along with their children. Excludes may
+// be node IDs (with or without the leading "root/") or kinds. Kinds match the
+// start of the last segment of an ID, so "package" excludes both
+// "package.apt.x" and "package.rpm.x" while "package.apt" only excludes the
+// former.
+func Exclude(ctx context.Context, g *Graph, excludes []string) (*Graph, error) {
+	if len(excludes) == 0 {
+		return g, nil
+	}
+
+	logger := logging.GetLogger(ctx).WithField("function", "Exclude")
+
+	out := g.Copy()
+	for _, id := range g.Vertices() {
+		if IsRoot(id) || !out.Contains(id) {
+			continue
+		}
+
+		for _, exclude := range excludes {
+			if id != qualifyID(exclude) && !MatchesKind(id, exclude) {
+				continue
+			}
+
+			logger.WithField("id", id).WithField("exclude", exclude).Debug("excluding")
+			for _, child := range lineage(out, id) {
+				out.Remove(child)
+			}
+			out.Remove(id)
+			break
+		}
+	}
+
+	return out, out.Validate()
+}
+
+// qualifyID prepends "root/" to IDs that do not start with it
+func qualifyID(id string) string {
+	if IsRoot(id) || strings.HasPrefix(id, "root/") {
+		return id
+	}
+	return ID("root", id)
+}
+
+// lineage returns all the children of a node, recursively, following parent
+// edges.
+func lineage(g *Graph, id string) (out []string) {
+	for _, child := range g.Children(id) {
+		out = append(out, child)
+		out = append(out, lineage(g, child)...)
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestTarget(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("no targets", func(t *testing.T) {
+		g := basePruneGraph()
+
+		pruned, err := graph.Target(context.Background(), g, nil)
+		require.NoError(t, err)
+		assert.Equal(t, g, pruned)
+	})
+
+	t.Run("keeps dependencies and lineage", func(t *testing.T) {
+		pruned, err := graph.Target(context.Background(), basePruneGraph(), []string{"module.web/file.content.index"})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]string{
+				"root",
+				"root/module.web",
+				"root/module.web/file.content.index",
+				"root/module.web/param.root",
+				"root/package.apt.nginx",
+			},
+			sortedVertices(pruned),
+		)
+	})
+
+	t.Run("keeps descendents", func(t *testing.T) {
+		pruned, err := graph.Target(context.Background(), basePruneGraph(), []string{"root/module.web"})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]string{
+				"root",
+				"root/module.web",
+				"root/module.web/file.content.index",
+				"root/module.web/param.root",
+				"root/module.web/task.restart",
+				"root/package.apt.nginx",
+			},
+			sortedVertices(pruned),
+		)
+	})
+
+	t.Run("does not modify the source graph", func(t *testing.T) {
+		g := basePruneGraph()
+
+		_, err := graph.Target(context.Background(), g, []string{"package.apt.nginx"})
+		require.NoError(t, err)
+		assert.True(t, g.Contains("root/task.unrelated"))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := graph.Target(context.Background(), basePruneGraph(), []string{"module.nope"})
+		assert.EqualError(t, err, `target "module.nope" does not exist`)
+	})
+}
+
+func TestExclude(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("no excludes", func(t *testing.T) {
+		g := basePruneGraph()
+
+		pruned, err := graph.Exclude(context.Background(), g, nil)
+		require.NoError(t, err)
+		assert.Equal(t, g, pruned)
+	})
+
+	t.Run("by id", func(t *testing.T) {
+		pruned, err := graph.Exclude(context.Background(), basePruneGraph(), []string{"root/task.unrelated"})
+		require.NoError(t, err)
+
+		assert.False(t, pruned.Contains("root/task.unrelated"))
+		assert.True(t, pruned.Contains("root/module.web/task.restart"))
+	})
+
+	t.Run("by kind", func(t *testing.T) {
+		pruned, err := graph.Exclude(context.Background(), basePruneGraph(), []string{"package"})
+		require.NoError(t, err)
+
+		assert.False(t, pruned.Contains("root/package.apt.nginx"))
+		assert.Empty(t, pruned.UpEdges("root/package.apt.nginx"))
+	})
+
+	t.Run("removes children", func(t *testing.T) {
+		pruned, err := graph.Exclude(context.Background(), basePruneGraph(), []string{"module.web"})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]string{"root", "root/package.apt.nginx", "root/task.unrelated"},
+			sortedVertices(pruned),
+		)
+	})
+
+	t.Run("never removes root", func(t *testing.T) {
+		pruned, err := graph.Exclude(context.Background(), basePruneGraph(), []string{"root"})
+		require.NoError(t, err)
+
+		assert.True(t, pruned.Contains("root"))
+	})
+}
+
+// basePruneGraph sets up a graph with a module that depends on a sibling of
+// the module and an unrelated task
+func basePruneGraph() *graph.Graph {
+	g := graph.New()
+	g.Add(node.New("root", nil))
+
+	g.Add(node.New("root/module.web", nil))
+	g.ConnectParent("root", "root/module.web")
+
+	g.Add(node.New("root/module.web/param.root", nil))
+	g.ConnectParent("root/module.web", "root/module.web/param.root")
+
+	g.Add(node.New("root/module.web/file.content.index", nil))
+	g.ConnectParent("root/module.web", "root/module.web/file.content.index")
+	g.Connect("root/module.web/file.content.index", "root/module.web/param.root")
+
+	g.Add(node.New("root/module.web/task.restart", nil))
+	g.ConnectParent("root/module.web", "root/module.web/task.restart")
+	g.Connect("root/module.web/task.restart", "root/module.web/file.content.index")
+
+	g.Add(node.New("root/package.apt.nginx", nil))
+	g.ConnectParent("root", "root/package.apt.nginx")
+	g.Connect("root/module.web/file.content.index", "root/package.apt.nginx")
+
+	g.Add(node.New("root/task.unrelated", nil))
+	g.ConnectParent("root", "root/task.unrelated")
+
+	return g
+}
+
+func sortedVertices(g *graph.Graph) []string {
+	vertices := g.Vertices()
+	sort.Strings(vertices)
+	return vertices
+}
@@ -121,3 +121,74 @@ func Swapper() *FakeSwapper {
 		Error:      nil,
 	}
 }
+
+// FakeFlaky is a task that fails to apply a number of times before it
+// succeeds
+type FakeFlaky struct {
+	Failures int
+	Attempts int
+}
+
+// Check reports changes until Apply succeeds
+func (ft *FakeFlaky) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	return &resource.Status{Output: []string{"flaky"}, Level: ft.level()}, nil
+}
+
+// Apply returns an error until it has been called more than Failures times
+func (ft *FakeFlaky) Apply(context.Context) (resource.TaskStatus, error) {
+	ft.Attempts++
+	if ft.Attempts <= ft.Failures {
+		return &resource.Status{Output: []string{"flaky"}, Level: resource.StatusFatal}, errors.New("flaky")
+	}
+	return &resource.Status{Output: []string{"flaky"}, Level: ft.level()}, nil
+}
+
+func (ft *FakeFlaky) level() resource.StatusLevel {
+	if ft.Attempts <= ft.Failures {
+		return resource.StatusWillChange
+	}
+	return resource.StatusNoChange
+}
+
+// Flaky creates a new stub task that fails to apply the given number of times
+func Flaky(failures int) *FakeFlaky {
+	return &FakeFlaky{Failures: failures}
+}
+
+// FakeRollbacker is a task that can be rolled back. It reports changes until
+// it has been applied, and records its name in a shared log when rolled back.
+type FakeRollbacker struct {
+	Name    string
+	Log     *[]string
+	Applied bool
+}
+
+// Check reports changes until Apply has been called
+func (ft *FakeRollbacker) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	return &resource.Status{Level: ft.level()}, nil
+}
+
+// Apply marks the task as applied
+func (ft *FakeRollbacker) Apply(context.Context) (resource.TaskStatus, error) {
+	ft.Applied = true
+	return &resource.Status{Level: ft.level()}, nil
+}
+
+// Rollback marks the task as not applied and records the rollback in the log
+func (ft *FakeRollbacker) Rollback(context.Context) (resource.TaskStatus, error) {
+	ft.Applied = false
+	*ft.Log = append(*ft.Log, ft.Name)
+	return &resource.Status{Output: []string{"restored " + ft.Name}}, nil
+}
+
+func (ft *FakeRollbacker) level() resource.StatusLevel {
+	if ft.Applied {
+		return resource.StatusNoChange
+	}
+	return resource.StatusWillChange
+}
+
+// Rollbacker creates a new stub task that records its rollback in log
+func Rollbacker(name string, log *[]string) *FakeRollbacker {
+	return &FakeRollbacker{Name: name, Log: log}
+}
@@ -0,0 +1,159 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/rpc/pb"
+)
+
+// The possible statuses of a run
+const (
+	// StatusRunning is the status of a run that has not finished yet
+	StatusRunning = "running"
+
+	// StatusSucceeded is the status of a run where every node succeeded
+	StatusSucceeded = "succeeded"
+
+	// StatusFailed is the status of a run where at least one node had an error
+	StatusFailed = "failed"
+
+	// StatusErrored is the status of a run that could not complete, for example
+	// because the module could not be loaded
+	StatusErrored = "errored"
+)
+
+// Run is the record of a single plan, apply, or healthcheck
+type Run struct {
+	ID         string            `json:"id"`
+	Command    string            `json:"command"`
+	Location   string            `json:"location"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end,omitempty"`
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+
+	Edges []graph.Edge     `json:"edges,omitempty"`
+	Nodes map[string]*Node `json:"nodes"`
+
+	lock sync.Mutex
+}
+
+// Node is the record of a single node in a run
+type Node struct {
+	Stage   string                     `json:"stage"`
+	Level   string                     `json:"level,omitempty"`
+	Start   time.Time                  `json:"start"`
+	End     time.Time                  `json:"end"`
+	Details *pb.StatusResponse_Details `json:"details,omitempty"`
+}
+
+// Duration is how long the node took in its last stage
+func (n *Node) Duration() time.Duration {
+	return n.End.Sub(n.Start)
+}
+
+// NewRun starts recording a run
+func NewRun(id, command, location string, params map[string]string) *Run {
+	return &Run{
+		ID:         id,
+		Command:    command,
+		Location:   location,
+		Parameters: params,
+		Start:      time.Now(),
+		Status:     StatusRunning,
+		Nodes:      map[string]*Node{},
+	}
+}
+
+// NodeStarted records the start of a stage for a node
+func (r *Run) NodeStarted(id, stage string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.Nodes[id] = &Node{Stage: stage, Start: time.Now()}
+}
+
+// NodeFinished records the result of a stage for a node. Later stages
+// overwrite earlier ones, so a node that was planned and applied will only
+// have the result of the application.
+func (r *Run) NodeFinished(id, stage, level string, details *pb.StatusResponse_Details) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	node, ok := r.Nodes[id]
+	if !ok || node.Stage != stage {
+		node = &Node{Stage: stage, Start: time.Now()}
+		r.Nodes[id] = node
+	}
+
+	node.Level = level
+	node.End = time.Now()
+	node.Details = details
+}
+
+// Finish records the end of the run. The status is set from the error and the
+// errors recorded on nodes.
+func (r *Run) Finish(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.End = time.Now()
+
+	switch {
+	case err != nil:
+		r.Status = StatusErrored
+		r.Error = err.Error()
+
+	case r.errors() > 0:
+		r.Status = StatusFailed
+
+	default:
+		r.Status = StatusSucceeded
+	}
+}
+
+// Duration is how long the run took
+func (r *Run) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Summary counts the nodes in the run that had changes and errors
+func (r *Run) Summary() (changes, errors int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, node := range r.Nodes {
+		if node.Details != nil && node.Details.HasChanges {
+			changes++
+		}
+	}
+
+	return changes, r.errors()
+}
+
+func (r *Run) errors() (count int) {
+	for _, node := range r.Nodes {
+		if node.Details != nil && node.Details.Error != "" && !node.Details.IgnoreErrors {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,104 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun tests recording a run
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("new", func(t *testing.T) {
+		run := history.NewRun("x", "apply", "test.hcl", map[string]string{"a": "b"})
+
+		assert.Equal(t, history.StatusRunning, run.Status)
+		assert.False(t, run.Start.IsZero())
+		assert.Empty(t, run.Nodes)
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		run := history.NewRun("x", "apply", "test.hcl", nil)
+
+		run.NodeStarted("root/a", "PLAN")
+		run.NodeFinished("root/a", "PLAN", "will change", &pb.StatusResponse_Details{HasChanges: true})
+
+		require.Contains(t, run.Nodes, "root/a")
+		node := run.Nodes["root/a"]
+		assert.Equal(t, "PLAN", node.Stage)
+		assert.Equal(t, "will change", node.Level)
+		assert.True(t, node.Duration() >= 0)
+
+		t.Run("later stage", func(t *testing.T) {
+			run.NodeStarted("root/a", "APPLY")
+			run.NodeFinished("root/a", "APPLY", "no change", &pb.StatusResponse_Details{})
+
+			assert.Equal(t, "APPLY", run.Nodes["root/a"].Stage)
+			assert.Equal(t, "no change", run.Nodes["root/a"].Level)
+		})
+
+		t.Run("finished without start", func(t *testing.T) {
+			run.NodeFinished("root/b", "APPLY", "no change", &pb.StatusResponse_Details{})
+
+			require.Contains(t, run.Nodes, "root/b")
+			assert.False(t, run.Nodes["root/b"].Start.IsZero())
+		})
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		run := history.NewRun("x", "plan", "test.hcl", nil)
+		run.NodeFinished("root/a", "PLAN", "", &pb.StatusResponse_Details{HasChanges: true})
+		run.NodeFinished("root/b", "PLAN", "", &pb.StatusResponse_Details{HasChanges: true, Error: "x"})
+		run.NodeFinished("root/c", "PLAN", "", &pb.StatusResponse_Details{})
+
+		changes, errors := run.Summary()
+		assert.Equal(t, 2, changes)
+		assert.Equal(t, 1, errors)
+	})
+
+	t.Run("finish", func(t *testing.T) {
+		t.Run("succeeded", func(t *testing.T) {
+			run := history.NewRun("x", "plan", "test.hcl", nil)
+			run.NodeFinished("root/a", "PLAN", "", &pb.StatusResponse_Details{})
+			run.Finish(nil)
+
+			assert.Equal(t, history.StatusSucceeded, run.Status)
+			assert.True(t, run.Duration() >= 0)
+		})
+
+		t.Run("failed", func(t *testing.T) {
+			run := history.NewRun("x", "plan", "test.hcl", nil)
+			run.NodeFinished("root/a", "PLAN", "", &pb.StatusResponse_Details{Error: "x"})
+			run.Finish(nil)
+
+			assert.Equal(t, history.StatusFailed, run.Status)
+		})
+
+		t.Run("errored", func(t *testing.T) {
+			run := history.NewRun("x", "plan", "test.hcl", nil)
+			run.Finish(errors.New("could not load"))
+
+			assert.Equal(t, history.StatusErrored, run.Status)
+			assert.Equal(t, "could not load", run.Error)
+		})
+	})
+}
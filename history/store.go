@@ -0,0 +1,155 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultDir is the default location of the history store
+const DefaultDir = "/var/lib/converge/history"
+
+// ErrNotFound is returned when a run does not exist in the store
+var ErrNotFound = errors.New("run not found")
+
+const runExt = ".json"
+
+// Store keeps runs on disk, one JSON file per run
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at the given directory. The directory will
+// be created when the first run is saved.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save writes a run to the store. The file is written atomically so that
+// readers never see a partial run.
+func (s *Store) Save(run *Run) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Wrap(err, "could not create history directory")
+	}
+
+	run.lock.Lock()
+	content, err := json.Marshal(run)
+	run.lock.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "could not serialize run")
+	}
+
+	tmp, err := ioutil.TempFile(s.dir, "."+run.ID)
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not write run")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not write run")
+	}
+
+	return os.Rename(tmp.Name(), s.path(run.ID))
+}
+
+// Get a run by ID. Any unique prefix of an ID will also work.
+func (s *Store) Get(id string) (*Run, error) {
+	if id == "" || strings.ContainsAny(id, `/\*?[`) {
+		return nil, ErrNotFound
+	}
+
+	if run, err := s.read(s.path(id)); err == nil {
+		return run, nil
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(s.dir, id+"*"+runExt))
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return s.read(matches[0])
+	default:
+		return nil, fmt.Errorf("%q matches %d runs", id, len(matches))
+	}
+}
+
+// List runs, newest first. If limit is greater than zero, only that many runs
+// are returned.
+func (s *Store) List(limit int) ([]*Run, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+runExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*Run
+	for _, path := range paths {
+		run, err := s.read(path)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Sort(byStart(runs))
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	return runs, nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+runExt)
+}
+
+func (s *Store) read(path string) (*Run, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read run")
+	}
+
+	run := new(Run)
+	if err := json.Unmarshal(content, run); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", filepath.Base(path))
+	}
+
+	return run, nil
+}
+
+// byStart sorts runs newest first
+type byStart []*Run
+
+func (b byStart) Len() int           { return len(b) }
+func (b byStart) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStart) Less(i, j int) bool { return b[i].Start.After(b[j].Start) }
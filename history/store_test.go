@@ -0,0 +1,115 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStore tests saving and reading runs
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := history.NewStore(filepath.Join(dir, "history"))
+
+	t.Run("empty", func(t *testing.T) {
+		runs, err := store.List(0)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+
+		_, err = store.Get("nope")
+		assert.Equal(t, history.ErrNotFound, err)
+	})
+
+	older := history.NewRun("aaaa-1", "plan", "test.hcl", map[string]string{"a": "b"})
+	older.Start = time.Now().Add(-time.Minute)
+	older.NodeFinished("root/a", "PLAN", "will change", &pb.StatusResponse_Details{HasChanges: true})
+	older.Finish(nil)
+
+	newer := history.NewRun("bbbb-2", "apply", "test.hcl", nil)
+	newer.Finish(nil)
+
+	require.NoError(t, store.Save(older))
+	require.NoError(t, store.Save(newer))
+
+	t.Run("list", func(t *testing.T) {
+		runs, err := store.List(0)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+
+		assert.Equal(t, "bbbb-2", runs[0].ID)
+		assert.Equal(t, "aaaa-1", runs[1].ID)
+	})
+
+	t.Run("list limit", func(t *testing.T) {
+		runs, err := store.List(1)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+
+		assert.Equal(t, "bbbb-2", runs[0].ID)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		run, err := store.Get("aaaa-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, "plan", run.Command)
+		assert.Equal(t, map[string]string{"a": "b"}, run.Parameters)
+		assert.Equal(t, history.StatusSucceeded, run.Status)
+		require.Contains(t, run.Nodes, "root/a")
+		assert.True(t, run.Nodes["root/a"].Details.HasChanges)
+	})
+
+	t.Run("get prefix", func(t *testing.T) {
+		run, err := store.Get("bbbb")
+		require.NoError(t, err)
+		assert.Equal(t, "bbbb-2", run.ID)
+	})
+
+	t.Run("get ambiguous", func(t *testing.T) {
+		extra := history.NewRun("bbbb-3", "apply", "test.hcl", nil)
+		require.NoError(t, store.Save(extra))
+		defer os.Remove(filepath.Join(dir, "history", "bbbb-3.json"))
+
+		_, err := store.Get("bbbb")
+		assert.EqualError(t, err, `"bbbb" matches 2 runs`)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		for _, id := range []string{"", "*", "../history/aaaa-1"} {
+			_, err := store.Get(id)
+			assert.Equal(t, history.ErrNotFound, err, id)
+		}
+	})
+
+	t.Run("no temporary files", func(t *testing.T) {
+		files, err := ioutil.ReadDir(filepath.Join(dir, "history"))
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+}
@@ -23,18 +23,27 @@ import (
 	"os/user"
 	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/openpgp"
 )
 
 // A Keystore represents a repository of trusted public keys which can be
-// used to verify PGP signatures.
+// used to verify PGP signatures, and of secret keys which can be used to
+// decrypt secrets.
 type Keystore struct {
 	LocalPath  string
 	UserPath   string
 	SystemPath string
 	keyring    openpgp.KeyRing
+
+	SecretUserPath   string
+	SecretSystemPath string
+	secretKeyring    openpgp.EntityList
+
+	// secrets are decrypted by concurrent renders, which share the keystore
+	secretMu sync.Mutex
 }
 
 // New returns a new Keystore backed by the provided paths.
@@ -46,24 +55,32 @@ func New(localPath, userPath, systemPath string) *Keystore {
 	}
 }
 
-var defaultKeystore *Keystore
+var (
+	defaultKeystore     *Keystore
+	defaultKeystoreOnce sync.Once
+)
 
 // Default returns a keystore backed by the default local, user, and system paths.
 func Default() *Keystore {
-	if defaultKeystore == nil {
+	defaultKeystoreOnce.Do(func() {
 		userPath := ""
+		secretUserPath := ""
 
 		usr, err := user.Current()
 		if err == nil {
 			userPath = filepath.Join(usr.HomeDir, ".converge/trustedkeys")
+			secretUserPath = filepath.Join(usr.HomeDir, ".converge/secretkeys")
 		}
 
 		defaultKeystore = &Keystore{
 			LocalPath:  "trustedkeys",
 			UserPath:   userPath,
 			SystemPath: "/usr/lib/converge/trustedkeys",
+
+			SecretUserPath:   secretUserPath,
+			SecretSystemPath: "/etc/converge/secretkeys",
 		}
-	}
+	})
 
 	return defaultKeystore
 }
@@ -131,10 +148,17 @@ func (ks *Keystore) CheckSignature(signed, signature io.Reader) error {
 }
 
 func loadKeyring(ks *Keystore) (openpgp.KeyRing, error) {
+	return loadEntities(ks.SystemPath, ks.UserPath, ks.LocalPath)
+}
+
+// loadEntities loads the keys stored in paths, in order. Each key is stored in
+// a file named for its fingerprint, and an empty file masks a key of the same
+// name in an earlier path.
+func loadEntities(paths ...string) (openpgp.EntityList, error) {
 	var keyring openpgp.EntityList
 	trustedKeys := make(map[string]*openpgp.Entity)
 
-	for _, p := range []string{ks.SystemPath, ks.UserPath, ks.LocalPath} {
+	for _, p := range paths {
 		files, err := ioutil.ReadDir(p)
 		if err != nil {
 			if os.IsNotExist(err) {
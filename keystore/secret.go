@@ -0,0 +1,151 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+)
+
+// StoreSecretKey stores the contents of an armored secret key. The key is
+// readable only by the current user.
+func (ks *Keystore) StoreSecretKey(keyBytes []byte) (string, error) {
+	if err := os.MkdirAll(ks.SecretUserPath, 0700); err != nil {
+		return "", err
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyBytes))
+	if err != nil {
+		return "", err
+	}
+
+	if len(keyring) < 1 {
+		return "", errors.New("cannot store secret key: empty keyring")
+	}
+
+	if keyring[0].PrivateKey == nil {
+		return "", errors.New("cannot store secret key: not a secret key")
+	}
+
+	secretKeyPath := filepath.Join(ks.SecretUserPath, fmt.Sprintf("%x", keyring[0].PrimaryKey.Fingerprint))
+	if err := ioutil.WriteFile(secretKeyPath, keyBytes, 0600); err != nil {
+		return "", err
+	}
+	ks.secretMu.Lock()
+	ks.secretKeyring = nil
+	ks.secretMu.Unlock()
+
+	return secretKeyPath, nil
+}
+
+// SecretKeys returns the secret keys in the keystore
+func (ks *Keystore) SecretKeys() (openpgp.EntityList, error) {
+	ks.secretMu.Lock()
+	defer ks.secretMu.Unlock()
+
+	if ks.secretKeyring == nil {
+		keyring, err := loadEntities(ks.SecretSystemPath, ks.SecretUserPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading secret keys")
+		}
+		ks.secretKeyring = keyring
+	}
+
+	return ks.secretKeyring, nil
+}
+
+// Recipients finds the keys to encrypt to. Fingerprints may be given in full
+// or as a long or short key ID, and are looked up in both the trusted and
+// secret keys. If no fingerprints are given, all the secret keys are used.
+func (ks *Keystore) Recipients(fingerprints []string) (openpgp.EntityList, error) {
+	secretKeys, err := ks.SecretKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fingerprints) == 0 {
+		if len(secretKeys) == 0 {
+			return nil, errors.New("there are no secret keys in the keystore to encrypt to")
+		}
+		return secretKeys, nil
+	}
+
+	trustedKeys, err := loadEntities(ks.SystemPath, ks.UserPath, ks.LocalPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading keyring")
+	}
+
+	var recipients openpgp.EntityList
+	for _, wanted := range fingerprints {
+		wanted = strings.ToLower(strings.Replace(wanted, " ", "", -1))
+
+		var found *openpgp.Entity
+		for _, entity := range append(secretKeys, trustedKeys...) {
+			if strings.HasSuffix(fmt.Sprintf("%x", entity.PrimaryKey.Fingerprint), wanted) {
+				found = entity
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, fmt.Errorf("no key in the keystore matches %q", wanted)
+		}
+		recipients = append(recipients, found)
+	}
+
+	return recipients, nil
+}
+
+// Encrypt plaintext to the recipients, writing an unarmored message to w
+func (ks *Keystore) Encrypt(w io.Writer, plaintext []byte, recipients openpgp.EntityList) error {
+	writer, err := openpgp.Encrypt(w, recipients, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(plaintext); err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
+
+// Decrypt an unarmored message with the secret keys in the keystore. Secret
+// keys protected by a passphrase are not supported.
+func (ks *Keystore) Decrypt(message io.Reader) ([]byte, error) {
+	secretKeys, err := ks.SecretKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := openpgp.ReadMessage(message, secretKeys, nil, nil)
+	if err == pgperrors.ErrKeyIncorrect {
+		return nil, errors.New("no secret key in the keystore can decrypt this value")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(md.UnverifiedBody)
+}
@@ -0,0 +1,226 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asteris-llc/converge/fetch"
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// LockfileName is the name of the file next to a module that records the
+// checksums of the modules it calls
+const LockfileName = "converge.lock"
+
+// CacheDir is the directory next to a module where `converge get` stores the
+// modules it calls that aren't local files
+const CacheDir = ".converge/modules"
+
+// Lockfile records the modules called from a module tree, keyed by the ID of
+// the node they are loaded into. Once a module tree has a lockfile, loading it
+// fails if any module in it changes.
+type Lockfile struct {
+	Modules map[string]*LockedModule `json:"modules"`
+}
+
+// LockedModule is the source and checksum of a module in a lockfile
+type LockedModule struct {
+	Source   string `json:"source"`
+	Version  string `json:"version,omitempty"`
+	Checksum string `json:"checksum"`
+}
+
+// NewLockfile returns an empty lockfile
+func NewLockfile() *Lockfile {
+	return &Lockfile{Modules: map[string]*LockedModule{}}
+}
+
+// ReadLockfile reads the lockfile at filename. If there is no lockfile, it
+// returns nil.
+func ReadLockfile(filename string) (*Lockfile, error) {
+	content, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read lockfile")
+	}
+
+	lock := NewLockfile()
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errors.Wrapf(err, "could not parse lockfile %s", filename)
+	}
+	return lock, nil
+}
+
+// Write the lockfile to filename
+func (l *Lockfile) Write(filename string) error {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not serialize lockfile")
+	}
+
+	return ioutil.WriteFile(filename, append(content, '\n'), 0644)
+}
+
+// Verify that the module loaded into a node matches the lockfile
+func (l *Lockfile) Verify(id, source, checksum string) error {
+	locked, ok := l.Modules[id]
+	switch {
+	case !ok:
+		return fmt.Errorf("%s is not in %s, run `converge get` to add it", id, LockfileName)
+	case locked.Source != source:
+		return fmt.Errorf("%s is locked to %q, but is loaded from %q, run `converge get` to update it", id, locked.Source, source)
+	case locked.Checksum != checksum:
+		return fmt.Errorf("%s does not match %s: expected checksum %s, got %s", id, LockfileName, locked.Checksum, checksum)
+	}
+	return nil
+}
+
+// bundle is the lockfile and module cache next to the root of a module tree.
+// Both are only used when the root is a local file.
+type bundle struct {
+	dir  string
+	lock *Lockfile
+
+	// updating is set when `converge get` is filling the cache and lockfile,
+	// rather than checking against them
+	updating bool
+}
+
+func openBundle(root string, updating bool) (*bundle, error) {
+	if !strings.HasPrefix(root, "file://") {
+		if updating {
+			return nil, fmt.Errorf("%s is not a local file", root)
+		}
+		return new(bundle), nil
+	}
+
+	b := &bundle{
+		dir:      filepath.Dir(strings.TrimPrefix(root, "file://")),
+		updating: updating,
+	}
+
+	if updating {
+		b.lock = NewLockfile()
+		return b, nil
+	}
+
+	var err error
+	b.lock, err = ReadLockfile(filepath.Join(b.dir, LockfileName))
+	return b, err
+}
+
+// fetch the content at loc, from the cache if it is there. When updating, the
+// content is always fetched and then cached.
+func (b *bundle) fetch(ctx context.Context, loc string) ([]byte, error) {
+	logger := logging.GetLogger(ctx).WithField("function", "bundle.fetch").WithField("url", loc)
+
+	cached, ok, err := b.cachePath(loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && !b.updating {
+		content, err := ioutil.ReadFile(cached)
+		if err == nil {
+			logger.WithField("cached", cached).Debug("using cached content")
+			return content, nil
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "could not read cached module")
+		}
+	}
+
+	content, err := fetch.Any(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && b.updating {
+		logger.WithField("cached", cached).Debug("caching content")
+		if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+			return nil, errors.Wrap(err, "could not create module cache")
+		}
+		if err := ioutil.WriteFile(cached, content, 0644); err != nil {
+			return nil, errors.Wrap(err, "could not cache module")
+		}
+	}
+
+	return content, nil
+}
+
+// cachePath returns where content from loc is cached. Local files are not
+// cached.
+func (b *bundle) cachePath(loc string) (string, bool, error) {
+	if b.dir == "" || strings.HasPrefix(loc, "file://") {
+		return "", false, nil
+	}
+
+	u, err := url.Parse(loc)
+	if err != nil {
+		return "", false, err
+	}
+
+	base := filepath.Join(b.dir, CacheDir)
+	cached := filepath.Join(base, u.Scheme, u.Host, filepath.FromSlash(u.Path))
+	if !strings.HasPrefix(cached, base+string(filepath.Separator)) {
+		return "", false, fmt.Errorf("%s cannot be cached outside of %s", loc, base)
+	}
+
+	return cached, true, nil
+}
+
+// check records the checksum of a module when updating, or verifies it against
+// the lockfile otherwise
+func (b *bundle) check(id string, source *fetch.Source, loc, checksum string) error {
+	if b.lock == nil || graph.IsRoot(id) {
+		return nil
+	}
+
+	if b.updating {
+		b.lock.Modules[id] = &LockedModule{
+			Source:   loc,
+			Version:  source.Version,
+			Checksum: checksum,
+		}
+		return nil
+	}
+
+	return b.lock.Verify(id, loc, checksum)
+}
+
+// Get loads the module tree at root, storing the modules it calls in the cache
+// next to root and recording their checksums in a new lockfile
+func Get(ctx context.Context, root string, verify bool) (*Lockfile, error) {
+	_, b, err := nodes(ctx, root, verify, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.lock.Write(filepath.Join(b.dir, LockfileName)); err != nil {
+		return nil, errors.Wrap(err, "could not write lockfile")
+	}
+
+	return b.lock, nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGet tests caching and locking the modules of a module tree
+func TestGet(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	registry := map[string]string{
+		"/nginx/1.2/main.hcl": "param \"message\" {\n  default = \"hi\"\n}\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := registry[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "converge-get")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+		return filename
+	}
+
+	source := server.URL + "//nginx?version=1.2"
+	root := write("app.hcl", "module \""+source+"\" \"web\" {}\n\nmodule \"local.hcl\" \"local\" {}\n")
+	write("local.hcl", "param \"a\" {}\n")
+
+	lock, err := load.Get(context.Background(), root, false)
+	require.NoError(t, err)
+
+	require.Len(t, lock.Modules, 2)
+	assert.Equal(t, source, lock.Modules["root/module.web"].Source)
+	assert.Equal(t, "1.2", lock.Modules["root/module.web"].Version)
+	assert.Equal(t, "local.hcl", lock.Modules["root/module.local"].Source)
+	assert.Equal(t, "", lock.Modules["root/module.local"].Version)
+
+	written, err := load.ReadLockfile(filepath.Join(dir, load.LockfileName))
+	require.NoError(t, err)
+	assert.Equal(t, lock, written)
+
+	t.Run("cached", func(t *testing.T) {
+		registry["/nginx/1.2/main.hcl"] = "param \"changed\" {}\n"
+
+		g, err := load.Nodes(context.Background(), root, false)
+		require.NoError(t, err)
+		assert.True(t, g.Contains("root/module.web/param.message"))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		write("local.hcl", "param \"b\" {}\n")
+
+		_, err := load.Nodes(context.Background(), root, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "root/module.local does not match converge.lock")
+	})
+
+	t.Run("not local", func(t *testing.T) {
+		_, err := load.Get(context.Background(), source, false)
+		assert.EqualError(t, err, server.URL+"/nginx/1.2/main.hcl is not a local file")
+	})
+}
+
+// TestLockfileVerify tests checking modules against a lockfile
+func TestLockfileVerify(t *testing.T) {
+	t.Parallel()
+
+	lock := load.NewLockfile()
+	lock.Modules["root/module.x"] = &load.LockedModule{Source: "x.hcl", Checksum: "abc"}
+
+	assert.NoError(t, lock.Verify("root/module.x", "x.hcl", "abc"))
+	assert.EqualError(
+		t,
+		lock.Verify("root/module.x", "x.hcl", "def"),
+		"root/module.x does not match converge.lock: expected checksum abc, got def",
+	)
+	assert.EqualError(
+		t,
+		lock.Verify("root/module.x", "y.hcl", "abc"),
+		`root/module.x is locked to "x.hcl", but is loaded from "y.hcl", run `+"`converge get`"+` to update it`,
+	)
+	assert.EqualError(
+		t,
+		lock.Verify("root/module.y", "y.hcl", "abc"),
+		"root/module.y is not in converge.lock, run `converge get` to add it",
+	)
+}
@@ -52,7 +52,7 @@ func ResolveDependencies(ctx context.Context, g *graph.Graph) (*graph.Graph, err
 			return fmt.Errorf("ResolveDependencies can only be used on Graphs of *parse.Node. I got %T", meta.Value())
 		}
 
-		depGenerators := []dependencyGenerator{getDepends, getParams, getXrefs}
+		depGenerators := []dependencyGenerator{getDepends, getParams, getLocals, getXrefs}
 
 		// we have dependencies from various sources, but they're always IDs, so we
 		// can connect them pretty easily
@@ -84,11 +84,20 @@ func ResolveDependencies(ctx context.Context, g *graph.Graph) (*graph.Graph, err
 			return depG, grpErr
 		}
 	}
-	return g, err
+	if err != nil {
+		return g, err
+	}
+
+	return resolveNotifications(ctx, g)
 }
 
 func getDepends(g *graph.Graph, id string, node *parse.Node) ([]string, error) {
-	deps, err := node.GetStringSlice("depends")
+	return getNodeRefs(g, id, node, "depends")
+}
+
+// getNodeRefs resolves a list of node references in the given field to IDs
+func getNodeRefs(g *graph.Graph, id string, node *parse.Node, field string) ([]string, error) {
+	deps, err := node.GetStringSlice(field)
 	switch err {
 	case parse.ErrNotFound:
 		return []string{}, nil
@@ -106,7 +115,99 @@ func getDepends(g *graph.Graph, id string, node *parse.Node) ([]string, error) {
 	}
 }
 
+// resolveNotifications connects handlers to the nodes that notify them. A node
+// can name its handlers with "notify", or a handler can name the nodes it
+// listens to with "subscribes". Either way the handler depends on the
+// notifying nodes, which are recorded in the MetaNotifiers metadata of the
+// handler.
+func resolveNotifications(ctx context.Context, g *graph.Graph) (*graph.Graph, error) {
+	logger := logging.GetLogger(ctx).WithField("function", "resolveNotifications")
+
+	notifiers := map[string]map[string]struct{}{}
+	notify := func(handler, notifier string) {
+		if _, ok := notifiers[handler]; !ok {
+			notifiers[handler] = map[string]struct{}{}
+		}
+		notifiers[handler][notifier] = struct{}{}
+	}
+
+	for _, meta := range g.Nodes() {
+		node, ok := meta.Value().(*parse.Node)
+		if !ok || graph.IsRoot(meta.ID) {
+			continue
+		}
+
+		handlers, err := getNodeRefs(g, meta.ID, node, "notify")
+		if err != nil {
+			return g, errors.Wrapf(err, "%s: invalid notify", meta.ID)
+		}
+		for _, handler := range handlers {
+			notify(handler, meta.ID)
+		}
+
+		subscriptions, err := getNodeRefs(g, meta.ID, node, "subscribes")
+		if err != nil {
+			return g, errors.Wrapf(err, "%s: invalid subscribes", meta.ID)
+		}
+		for _, notifier := range subscriptions {
+			notify(meta.ID, notifier)
+		}
+	}
+
+	for handler, set := range notifiers {
+		var ids []string
+		for notifier := range set {
+			if notifier == handler {
+				return g, fmt.Errorf("%s: cannot notify itself", handler)
+			}
+
+			if err := g.SafeConnect(handler, notifier); err != nil {
+				return g, err
+			}
+			ids = append(ids, notifier)
+		}
+		sort.Strings(ids)
+
+		logger.WithField("handler", handler).WithField("notifiers", ids).Debug("connected handler")
+
+		meta, _ := g.Get(handler)
+		if err := meta.AddMetadata(MetaNotifiers, ids); err != nil {
+			return g, errors.Wrapf(err, "%s: could not record notifiers", handler)
+		}
+	}
+
+	return g, nil
+}
+
 func getParams(g *graph.Graph, id string, node *parse.Node) (out []string, err error) {
+	return getNamedRefs(g, id, node, "parameter", "param", map[string]interface{}{
+		"param":     "",
+		"paramList": []interface{}(nil),
+		"paramMap":  map[string]interface{}(nil),
+	})
+}
+
+// getLocals finds the locals used by the templates of a node. Like params,
+// locals are looked up in the module the node is in.
+func getLocals(g *graph.Graph, id string, node *parse.Node) (out []string, err error) {
+	out, err = getNamedRefs(g, id, node, "local", "local", map[string]interface{}{
+		"local": "",
+	})
+	if err != nil {
+		return out, err
+	}
+	for _, dep := range out {
+		if dep == id {
+			return out, fmt.Errorf("%s: local cannot refer to itself", id)
+		}
+	}
+	return out, nil
+}
+
+// getNamedRefs finds the calls to the given template functions in the templates
+// of a node and resolves the name each call takes to the nearest node of kind
+// in the module. Calls are given as the value to return while looking.
+func getNamedRefs(g *graph.Graph, id string, node *parse.Node, noun, kind string, calls map[string]interface{}) (out []string, err error) {
 	var nodeStrings []string
 	nodeStrings, err = node.GetStrings()
 	if err != nil {
@@ -118,30 +219,24 @@ func getParams(g *graph.Graph, id string, node *parse.Node) (out []string, err e
 		return nil, errors.New("error: node is not in the provided graph")
 	}
 
-	if metaIface, ok := meta.LookupMetadata("conditional-predicate-raw"); ok {
-		if str, ok := metaIface.(string); ok {
-			nodeStrings = append(nodeStrings, str)
-		}
-	}
+	nodeStrings = append(nodeStrings, metadataStrings(meta)...)
 
-	type stub struct{}
 	language := extensions.MinimalLanguage()
-	language.On("param", extensions.RememberCalls(&out, ""))
-	language.On("paramList", extensions.RememberCalls(&out, []interface{}(nil)))
-	language.On("paramMap", extensions.RememberCalls(&out, map[string]interface{}(nil)))
+	for name, returnValue := range calls {
+		language.On(name, extensions.RememberCalls(&out, returnValue))
+	}
 
 	for _, s := range nodeStrings {
-		useless := stub{}
 		tmpl, tmplErr := template.New("DependencyTemplate").Funcs(language.Funcs).Parse(s)
 		if tmplErr != nil {
 			return out, tmplErr
 		}
-		tmpl.Execute(ioutil.Discard, &useless)
+		tmpl.Execute(ioutil.Discard, templateData(meta))
 	}
 	for idx, val := range out {
-		ancestor, found := getNearestAncestor(g, id, "param."+val)
+		ancestor, found := getNearestAncestor(g, id, kind+"."+val)
 		if !found {
-			return out, fmt.Errorf("unknown parameter: param.%s", val)
+			return out, fmt.Errorf("unknown %s: %s.%s", noun, kind, val)
 		}
 		out[idx] = ancestor
 	}
@@ -162,26 +257,27 @@ func getXrefs(g *graph.Graph, id string, node *parse.Node) (out []string, err er
 		return nil, errors.New("error: node is not in the provided graph")
 	}
 
-	if metaIface, ok := meta.LookupMetadata("conditional-predicate-raw"); ok {
-		if str, ok := metaIface.(string); ok {
-			nodeStrings = append(nodeStrings, str)
-		}
-	}
+	nodeStrings = append(nodeStrings, metadataStrings(meta)...)
 
 	language := extensions.MinimalLanguage()
 	language.On(extensions.RefFuncName, extensions.RememberCalls(&calls, 0))
+	language.On(extensions.OutputFuncName, func(module, name string) (string, error) {
+		calls = append(calls, extensions.OutputRef(module, name))
+		return "", nil
+	})
 	for _, s := range nodeStrings {
 		tmpl, tmplErr := template.New("DependencyTemplate").Funcs(language.Funcs).Parse(s)
 		if tmplErr != nil {
 			return out, tmplErr
 		}
-		tmpl.Execute(ioutil.Discard, &struct{}{})
+		tmpl.Execute(ioutil.Discard, templateData(meta))
 	}
 	for _, call := range calls {
-		vertex, _, found := preprocessor.VertexSplitTraverse(g, call, id, preprocessor.TraverseUntilModule, make(map[string]struct{}))
+		vertex, terms, found := preprocessor.VertexSplitTraverse(g, call, id, preprocessor.TraverseUntilModule, make(map[string]struct{}))
 		if !found {
 			return []string{}, fmt.Errorf("dependency generator: unresolvable call to %s", call)
 		}
+		vertex, _ = preprocessor.OutputSplit(g, vertex, terms)
 		if _, ok := nodeRefs[vertex]; !ok {
 			nodeRefs[vertex] = struct{}{}
 			out = append(out, vertex)
@@ -193,6 +289,28 @@ func getXrefs(g *graph.Graph, id string, node *parse.Node) (out []string, err er
 	return out, err
 }
 
+// metadataStrings returns the templates of a node that are not in its fields:
+// the predicate of the branch it is in, and the content of its template source
+func metadataStrings(meta *node.Node) (out []string) {
+	for _, key := range []string{"conditional-predicate-raw", MetaTemplate} {
+		if metaIface, ok := meta.LookupMetadata(key); ok {
+			if str, ok := metaIface.(string); ok {
+				out = append(out, str)
+			}
+		}
+	}
+	return out
+}
+
+// templateData is the value of "." when looking for dependencies in the
+// templates of a node
+func templateData(meta *node.Node) interface{} {
+	if elem, ok := ForeachElement(meta); ok {
+		return elem
+	}
+	return &struct{}{}
+}
+
 func getPeerVertex(g *graph.Graph, src, dst string) (string, bool) {
 	if dst == "." || graph.IsRoot(dst) {
 		return "", false
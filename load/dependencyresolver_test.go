@@ -100,6 +100,98 @@ func TestDependencyResolverResolvesParam(t *testing.T) {
 	)
 }
 
+// TestDependencyResolverResolvesOutputs ensures that reading the output of a
+// module call depends on the output
+// TestDependencyResolverResolvesTemplateParams tests that params used in the
+// source of a file.template are dependencies of the resource
+func TestDependencyResolverResolvesTemplateParams(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	nodes, err := load.Nodes(context.Background(), "../samples/fileTemplate.hcl", false)
+	require.NoError(t, err)
+
+	resolved, err := load.ResolveDependencies(context.Background(), nodes)
+	assert.NoError(t, err)
+
+	targets := graph.Targets(resolved.DownEdges("root/file.template.motd"))
+	assert.Contains(t, targets, "root/param.filename")
+	assert.Contains(t, targets, "root/param.admins")
+}
+
+func TestDependencyResolverResolvesOutputs(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	nodes, err := load.Nodes(context.Background(), "../samples/outputs.hcl", false)
+	require.NoError(t, err)
+
+	resolved, err := load.ResolveDependencies(context.Background(), nodes)
+	require.NoError(t, err)
+
+	assert.Contains(
+		t,
+		graph.Targets(resolved.DownEdges("root/task.query.lookup")),
+		"root/module.greeter/output.greeting",
+	)
+	assert.Contains(
+		t,
+		graph.Targets(resolved.DownEdges("root/task.query.output")),
+		"root/module.greeter/output.name",
+	)
+	assert.Contains(
+		t,
+		graph.Targets(resolved.DownEdges("root/module.greeter/output.greeting")),
+		"root/module.greeter/task.query.greeting",
+	)
+}
+
+// TestDependencyResolverResolvesLocals ensures that nodes depend on the locals
+// they use, and locals on the params they use
+func TestDependencyResolverResolvesLocals(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("valid", func(t *testing.T) {
+		nodes, err := load.Nodes(context.Background(), "../samples/locals.hcl", false)
+		require.NoError(t, err)
+
+		resolved, err := load.ResolveDependencies(context.Background(), nodes)
+		require.NoError(t, err)
+
+		contentDeps := graph.Targets(resolved.DownEdges("root/file.content.config"))
+		assert.Contains(t, contentDeps, "root/local.image")
+		assert.Contains(t, contentDeps, "root/local.config-dir")
+
+		assert.Contains(
+			t,
+			graph.Targets(resolved.DownEdges("root/local.image")),
+			"root/param.registry",
+		)
+	})
+
+	t.Run("predicate", func(t *testing.T) {
+		nodes, err := hclutils.LoadFromString("ResolverLocalPredicate", "local \"a\" { value = \"a\" }\ntask.query x { query = \"echo x\" }")
+		require.NoError(t, err)
+
+		meta, ok := nodes.Get("root/task.query.x")
+		require.True(t, ok)
+		require.NoError(t, meta.AddMetadata("conditional-predicate-raw", "eq {{local `a`}} `a`"))
+
+		resolved, err := load.ResolveDependencies(context.Background(), nodes)
+		require.NoError(t, err)
+
+		assert.True(t, graphutils.DependsOn(resolved, "root/task.query.x", "root/local.a"))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		nodes, err := hclutils.LoadFromString("ResolverUnknownLocal", "task.query x { query = \"echo {{local `missing`}}\" }")
+		require.NoError(t, err)
+
+		_, err = load.ResolveDependencies(context.Background(), nodes)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task.query.x: unknown local: local.missing")
+		}
+	})
+}
+
 // TestDependencyResolverHandlesConditionalMetadata ensures that we generate
 // dependencies for predicates
 func TestDependencyResolverHandlesConditionalMetadata(t *testing.T) {
@@ -229,3 +321,40 @@ func TestDependencyResolverResolvesGroupDependencies(t *testing.T) {
 		}
 	})
 }
+
+// TestDependencyResolverResolvesNotifications tests that handlers depend on
+// and record the nodes that notify them
+func TestDependencyResolverResolvesNotifications(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("notify-and-subscribes", func(t *testing.T) {
+		nodes, err := load.Nodes(context.Background(), "../samples/notify.hcl", false)
+		require.NoError(t, err)
+
+		resolved, err := load.ResolveDependencies(context.Background(), nodes)
+		require.NoError(t, err)
+
+		for _, handler := range []string{"root/task.reload", "root/task.announce"} {
+			assert.Contains(t, graph.Targets(resolved.DownEdges(handler)), "root/file.content.config")
+
+			meta, ok := resolved.Get(handler)
+			require.True(t, ok)
+			notifiers, ok := meta.LookupMetadata(load.MetaNotifiers)
+			require.True(t, ok, "%s should have notifiers", handler)
+			assert.Equal(t, []string{"root/file.content.config"}, notifiers)
+		}
+
+		meta, ok := resolved.Get("root/file.content.config")
+		require.True(t, ok)
+		_, ok = meta.LookupMetadata(load.MetaNotifiers)
+		assert.False(t, ok)
+	})
+
+	t.Run("bad-notify", func(t *testing.T) {
+		nodes, err := load.Nodes(context.Background(), "../samples/errors/bad_notify.hcl", false)
+		require.NoError(t, err)
+
+		_, err = load.ResolveDependencies(context.Background(), nodes)
+		assert.EqualError(t, err, "root/task.notifier: invalid notify: nonexistent vertices in edges: task.nonexistent")
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/parse"
+	"github.com/asteris-llc/converge/parse/preprocessor/foreach"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// MetaForeachElement is the metadata key for the element a node was generated
+// from by a foreach macro
+const MetaForeachElement = "foreach-element"
+
+var paramsKey = struct{ name string }{"params"}
+
+// WithParams sets the top-level params on a context. Macros that are expanded
+// while loading the root module use them instead of param defaults.
+func WithParams(ctx context.Context, params map[string]string) context.Context {
+	return context.WithValue(ctx, paramsKey, params)
+}
+
+func topParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(paramsKey).(map[string]string)
+	return params
+}
+
+// ForeachElement returns the element a node was generated from, if it was
+// generated by a foreach macro
+func ForeachElement(meta *node.Node) (*foreach.Element, bool) {
+	raw, ok := meta.LookupMetadata(MetaForeachElement)
+	if !ok {
+		return nil, false
+	}
+	elem, ok := raw.(*foreach.Element)
+	return elem, ok
+}
+
+// expandForeachMacro adds a copy of every node inside a foreach macro for each
+// element it iterates over. The copies are siblings of the macro, so they can
+// be referred to by depends and lookup like any other node in the module.
+func expandForeachMacro(ctx context.Context, url string, current *source, siblings []*parse.Node, n *parse.Node, g *graph.Graph) (*graph.Graph, error) {
+	f, err := foreach.NewForeach(n)
+	if err != nil {
+		return g, err
+	}
+
+	items := f.Items
+	if f.Param != "" {
+		items, err = foreachParam(ctx, g, current, siblings, f.Param)
+		if err != nil {
+			return g, errors.Wrapf(err, "%s %q", foreach.Keyword, f.Name)
+		}
+	}
+
+	elements, err := foreach.Elements(items)
+	if err != nil {
+		return g, errors.Wrapf(err, "%s %q", foreach.Keyword, f.Name)
+	}
+
+	for _, elem := range elements {
+		for _, inner := range f.InnerNodes {
+			id := graph.ID(current.Parent, elem.ID(inner))
+			if g.Contains(id) {
+				return g, fmt.Errorf("%s %q: duplicate resource %s", foreach.Keyword, f.Name, graph.BaseID(id))
+			}
+
+			generated := withPosition(node.New(id, inner), url, inner)
+			generated.AddMetadata(MetaForeachElement, elem)
+
+			g.Add(generated)
+			g.ConnectParent(current.Parent, id)
+		}
+	}
+
+	return g, nil
+}
+
+// foreachParam finds the value of a param at load time. Values passed to the
+// module win over the default of the param.
+func foreachParam(ctx context.Context, g *graph.Graph, current *source, siblings []*parse.Node, name string) (interface{}, error) {
+	if graph.IsRoot(current.Parent) {
+		if raw, ok := topParams(ctx)[name]; ok {
+			var val interface{}
+			if err := json.Unmarshal([]byte(raw), &val); err != nil {
+				return nil, fmt.Errorf("param %q must be a JSON list or map to be iterated over, got %q", name, raw)
+			}
+			return val, nil
+		}
+	} else if meta, ok := g.Get(current.Parent); ok {
+		if call, ok := meta.Value().(*parse.Node); ok {
+			params, _ := call.Get("params")
+			if values, ok := foreach.Normalize(params).(map[string]interface{}); ok {
+				if val, ok := values[name]; ok {
+					return loadTimeValue(name, val)
+				}
+			}
+		}
+	}
+
+	for _, sibling := range siblings {
+		if sibling.Kind() != "param" || sibling.Name() != name {
+			continue
+		}
+
+		val, err := sibling.Get("default")
+		if err == parse.ErrNotFound {
+			return nil, fmt.Errorf("param %q has no value", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return loadTimeValue(name, val)
+	}
+
+	return nil, fmt.Errorf("unknown parameter: param.%s", name)
+}
+
+// loadTimeValue refuses values that can only be known after rendering
+func loadTimeValue(name string, val interface{}) (interface{}, error) {
+	if str, ok := val.(string); ok && strings.Contains(str, "{{") {
+		return nil, fmt.Errorf("param %q is a template, which cannot be iterated over at load time", name)
+	}
+	return val, nil
+}
@@ -27,6 +27,10 @@ func Load(ctx context.Context, root string, verify bool) (*graph.Graph, error) {
 		return nil, errors.Wrap(err, "loading failed")
 	}
 
+	if err := ValidateParams(base); err != nil {
+		return nil, errors.Wrap(err, "invalid params")
+	}
+
 	resolved, err := ResolveDependencies(ctx, base)
 
 	if err != nil {
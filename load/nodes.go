@@ -16,7 +16,12 @@ package load
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/asteris-llc/converge/fetch"
 	"github.com/asteris-llc/converge/graph"
@@ -25,11 +30,37 @@ import (
 	"github.com/asteris-llc/converge/helpers/logging"
 	"github.com/asteris-llc/converge/keystore"
 	"github.com/asteris-llc/converge/parse"
+	"github.com/asteris-llc/converge/parse/preprocessor/foreach"
 	"github.com/asteris-llc/converge/parse/preprocessor/switch"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
+// MetaChecksum is the metadata key for the SHA256 checksum of the content of a
+// module. It is set on the root node and on every module call.
+const MetaChecksum = "module-checksum"
+
+// MetaPosition is the metadata key for the position of a node in the module
+// it was loaded from, as "url:line:column"
+const MetaPosition = "position"
+
+// MetaFile is the metadata key for the URL of the file a node was loaded from.
+// This is the module itself or a file it includes.
+const MetaFile = "file"
+
+// MetaNotifiers is the metadata key for the IDs of the nodes that notify a
+// handler node. Handlers are only applied when one of their notifiers changed.
+const MetaNotifiers = "notifiers"
+
+// MetaTemplate is the metadata key for the unrendered content of the source of
+// a file.template resource. Templates are fetched with the module, so they are
+// verified and locked along with it.
+const MetaTemplate = "template"
+
+// templateKind is the kind of the resources whose source is fetched as a
+// template when the module is loaded
+const templateKind = "file.template"
+
 type source struct {
 	Parent       string
 	ParentSource string
@@ -40,9 +71,24 @@ func (s *source) String() string {
 	return fmt.Sprintf("%s (%s)", s.Source, s.Parent)
 }
 
-// Nodes loads and parses all resources referred to by the provided url
+// Nodes loads and parses all resources referred to by the provided url. If the
+// root is a local file with a lockfile next to it, every module called must
+// match its checksum in the lockfile.
 func Nodes(ctx context.Context, root string, verify bool) (*graph.Graph, error) {
-	logger := logging.GetLogger(ctx).WithField("function", "Nodes")
+	out, _, err := nodes(ctx, root, verify, false)
+	return out, err
+}
+
+func nodes(ctx context.Context, root string, verify, updating bool) (*graph.Graph, *bundle, error) {
+	rootSource, err := fetch.ResolveSource(root, root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := openBundle(rootSource.URL, updating)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	toLoad := []*source{{"root", root, root}}
 
@@ -52,69 +98,298 @@ func Nodes(ctx context.Context, root string, verify bool) (*graph.Graph, error)
 	for len(toLoad) > 0 {
 		select {
 		case <-ctx.Done():
-			return nil, errors.New("interrupted")
+			return nil, nil, errors.New("interrupted")
 		default:
 		}
 
 		current := toLoad[0]
 		toLoad = toLoad[1:]
 
-		url, err := fetch.ResolveInContext(current.Source, current.ParentSource)
+		src, err := fetch.ResolveSource(current.Source, current.ParentSource)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		url := src.URL
 
-		logger.WithField("url", url).Debug("fetching")
-		content, err := fetch.Any(ctx, url)
+		files, err := loadFiles(ctx, b, url, verify, map[string]bool{})
 		if err != nil {
-			return nil, errors.Wrap(err, url)
+			return nil, nil, err
+		}
+
+		if err := checkDuplicates(files); err != nil {
+			return nil, nil, err
 		}
 
-		if verify {
-			signatureURL := url + ".asc"
+		// included files share the scope of the module that includes them, so
+		// foreach macros can refer to params from any of them
+		var resources []*parse.Node
+		for _, f := range files {
+			resources = append(resources, f.Resources...)
+		}
 
-			logger.WithField("signatureUrl", signatureURL).Debug("fetching")
-			signature, sigErr := fetch.Any(ctx, signatureURL)
-			if sigErr != nil {
-				return nil, errors.Wrap(sigErr, signatureURL)
+		for _, f := range files {
+			out, toLoad, err = addResources(ctx, f, current, resources, out, toLoad)
+			if err != nil {
+				return out, b, err
 			}
+		}
+
+		templates, err := loadTemplates(ctx, b, out, current.Parent, verify)
+		if err != nil {
+			return out, b, err
+		}
+
+		sum := checksum(files, templates)
+		if parent, ok := out.Get(current.Parent); ok {
+			parent.AddMetadata(MetaChecksum, sum)
+		}
 
-			err = keystore.Default().CheckSignature(bytes.NewBuffer(content), bytes.NewBuffer(signature))
+		if err := b.check(current.Parent, src, current.Source, sum); err != nil {
+			return nil, nil, err
+		}
+	}
+	return out, b, out.Validate()
+}
+
+// addResources adds the resources in a file to the graph under the module
+// being loaded, and returns the module calls it finds so they can be loaded
+func addResources(ctx context.Context, f *moduleFile, current *source, siblings []*parse.Node, out *graph.Graph, toLoad []*source) (*graph.Graph, []*source, error) {
+	var err error
+	url := f.URL
+
+	for _, resource := range f.Resources {
+		if foreach.IsForeachNode(resource) {
+			out, err = expandForeachMacro(ctx, url, current, siblings, resource, out)
+			if err != nil {
+				return out, toLoad, errors.Wrap(err, "unable to load resource")
+			}
+			continue
+		}
+		if control.IsSwitchNode(resource) {
+			out, err = expandSwitchMacro(f.Content, url, current, resource, out)
 			if err != nil {
-				return nil, errors.Wrap(err, signatureURL)
+				return out, toLoad, errors.Wrap(err, "unable to load resource")
 			}
+			continue
 		}
+		newID := graph.ID(current.Parent, resource.ID())
+		out.Add(withPosition(node.New(newID, resource), url, resource))
+		out.ConnectParent(current.Parent, newID)
 
-		resources, err := parse.Parse(content)
+		if resource.IsModule() {
+			toLoad = append(
+				toLoad,
+				&source{
+					Parent:       newID,
+					ParentSource: url,
+					Source:       resource.Source(),
+				},
+			)
+		}
+	}
+	return out, toLoad, nil
+}
+
+// moduleFile is a file that makes up a module: either the module itself or a
+// file it includes
+type moduleFile struct {
+	URL       string
+	Content   []byte
+	Resources []*parse.Node
+}
+
+// loadFiles fetches and parses the module at url, followed by the files it
+// includes, in order
+func loadFiles(ctx context.Context, b *bundle, url string, verify bool, seen map[string]bool) ([]*moduleFile, error) {
+	if seen[url] {
+		return nil, fmt.Errorf("%s is included more than once", url)
+	}
+	seen[url] = true
+
+	content, err := fetchModule(ctx, b, url, verify)
+	if err != nil {
+		return nil, err
+	}
+
+	stripped, includes := parse.ExtractIncludes(content)
+
+	resources, err := parse.Parse(stripped)
+	if err != nil {
+		return nil, errors.Wrap(err, url)
+	}
+
+	files := []*moduleFile{{URL: url, Content: content, Resources: resources}}
+
+	for _, include := range includes {
+		targets, err := resolveInclude(include.Pattern, url)
 		if err != nil {
-			return nil, errors.Wrap(err, url)
+			return nil, errors.Wrapf(err, "%s:%d", url, include.Line)
 		}
 
-		for _, resource := range resources {
-			if control.IsSwitchNode(resource) {
-				out, err = expandSwitchMacro(content, current, resource, out)
-				if err != nil {
-					return out, errors.Wrap(err, "unable to load resource")
-				}
-				continue
+		for _, target := range targets {
+			included, err := loadFiles(ctx, b, target, verify, seen)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s:%d", url, include.Line)
 			}
-			newID := graph.ID(current.Parent, resource.ID())
-			out.Add(node.New(newID, resource))
-			out.ConnectParent(current.Parent, newID)
-
-			if resource.IsModule() {
-				toLoad = append(
-					toLoad,
-					&source{
-						Parent:       newID,
-						ParentSource: url,
-						Source:       resource.Source(),
-					},
-				)
+			files = append(files, included...)
+		}
+	}
+
+	return files, nil
+}
+
+// fetchModule fetches the content at url, checking its signature if verify is
+// set
+func fetchModule(ctx context.Context, b *bundle, url string, verify bool) ([]byte, error) {
+	logger := logging.GetLogger(ctx).WithField("function", "fetchModule")
+
+	logger.WithField("url", url).Debug("fetching")
+	content, err := b.fetch(ctx, url)
+	if err != nil {
+		return nil, errors.Wrap(err, url)
+	}
+
+	if verify {
+		signatureURL := url + ".asc"
+
+		logger.WithField("signatureUrl", signatureURL).Debug("fetching")
+		signature, sigErr := b.fetch(ctx, signatureURL)
+		if sigErr != nil {
+			return nil, errors.Wrap(sigErr, signatureURL)
+		}
+
+		err = keystore.Default().CheckSignature(bytes.NewBuffer(content), bytes.NewBuffer(signature))
+		if err != nil {
+			return nil, errors.Wrap(err, signatureURL)
+		}
+	}
+
+	return content, nil
+}
+
+// resolveInclude resolves the pattern of an include relative to the file that
+// includes it. Patterns with wildcards are expanded, in sorted order, and must
+// match at least one file.
+func resolveInclude(pattern, url string) ([]string, error) {
+	target, err := fetch.ResolveInContext(pattern, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{target}, nil
+	}
+
+	if !strings.HasPrefix(target, "file://") {
+		return nil, fmt.Errorf("cannot include %q: wildcards are only supported for local files", pattern)
+	}
+
+	matches, err := filepath.Glob(strings.TrimPrefix(target, "file://"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot include %q", pattern)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cannot include %q: no files match", pattern)
+	}
+
+	sort.Strings(matches)
+	for i, match := range matches {
+		matches[i] = "file://" + match
+	}
+	return matches, nil
+}
+
+// loadTemplates fetches the source of every file.template resource in the
+// module loaded into parent, relative to the file the resource is in, and
+// records it on the node. Sources are fetched like modules, so they're cached
+// and their signatures are checked. The content of each source is returned once,
+// in the order of their URLs, to be checksummed with the module.
+func loadTemplates(ctx context.Context, b *bundle, g *graph.Graph, parent string, verify bool) ([][]byte, error) {
+	fetched := map[string][]byte{}
+
+	// nodes from modules called by this one are not in the graph yet, so every
+	// descendent of parent belongs to this module
+	for _, id := range g.Descendents(parent) {
+		meta, _ := g.Get(id)
+		n, ok := meta.Value().(*parse.Node)
+		if !ok || n.Kind() != templateKind {
+			continue
+		}
+
+		pos, _ := Position(meta)
+		url, _ := fileURL(meta)
+
+		if _, err := n.Get("template"); err == nil {
+			return nil, fmt.Errorf("%s: \"template\" cannot be set, it is loaded from \"source\"", pos)
+		}
+
+		src, err := n.GetString("source")
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: \"source\" is required", pos)
+		}
+		if strings.Contains(src, "{{") {
+			return nil, fmt.Errorf("%s: \"source\" cannot be templated, it is loaded with the module", pos)
+		}
+
+		target, err := fetch.ResolveInContext(src, url)
+		if err != nil {
+			return nil, errors.Wrap(err, pos)
+		}
+
+		content, ok := fetched[target]
+		if !ok {
+			content, err = fetchModule(ctx, b, target, verify)
+			if err != nil {
+				return nil, errors.Wrap(err, pos)
+			}
+			fetched[target] = content
+		}
+
+		meta.AddMetadata(MetaTemplate, string(content))
+	}
+
+	var urls []string
+	for url := range fetched {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	var out [][]byte
+	for _, url := range urls {
+		out = append(out, fetched[url])
+	}
+	return out, nil
+}
+
+// checksum returns the SHA256 checksum of the content of all the files in a
+// module, followed by the templates it uses. For a module without includes or
+// templates, this is the checksum of the module itself.
+func checksum(files []*moduleFile, templates [][]byte) string {
+	hash := sha256.New()
+	for _, f := range files {
+		hash.Write(f.Content)
+	}
+	for _, template := range templates {
+		hash.Write(template)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// checkDuplicates makes sure that no two files in a module define the same
+// resource. Duplicates within a single file are caught when it is parsed.
+func checkDuplicates(files []*moduleFile) error {
+	positions := map[string]string{}
+	for _, f := range files {
+		for _, resource := range f.Resources {
+			pos := fmt.Sprintf("%s:%s", f.URL, resource.Pos())
+			if first, ok := positions[resource.ID()]; ok {
+				return fmt.Errorf("%s: duplicate resource %s, first defined at %s", pos, resource.ID(), first)
 			}
+			positions[resource.ID()] = pos
 		}
 	}
-	return out, out.Validate()
+	return nil
 }
 
 // expandSwitchMacro is responsible for adding the generated switch nodes into
@@ -122,7 +397,7 @@ func Nodes(ctx context.Context, root string, verify bool) (*graph.Graph, error)
 // case statements, who are parents of the outer switch statement.  Actual node
 // generation happens in parse/preprocessor/switch and we add the nodes into the
 // graph here.
-func expandSwitchMacro(data []byte, current *source, n *parse.Node, g *graph.Graph) (*graph.Graph, error) {
+func expandSwitchMacro(data []byte, url string, current *source, n *parse.Node, g *graph.Graph) (*graph.Graph, error) {
 	if !control.IsSwitchNode(n) {
 		return g, nil
 	}
@@ -170,7 +445,7 @@ func expandSwitchMacro(data []byte, current *source, n *parse.Node, g *graph.Gra
 			}
 			innerID := graph.ID(branchID, innerNode.ID())
 
-			condNode := node.New(innerID, innerNode)
+			condNode := withPosition(node.New(innerID, innerNode), url, innerNode)
 			condNode.AddMetadata(conditional.MetaSwitchName, switchObj.Name)
 			condNode.AddMetadata(conditional.MetaUnrenderedPredicate, branch.Predicate)
 			condNode.AddMetadata(conditional.MetaBranchName, branch.Name)
@@ -195,10 +470,55 @@ func validateInnerNode(node *parse.Node) error {
 	switch node.Kind() {
 	case "module":
 		return errors.New("modules not supported in conditionals")
+	case "output":
+		return errors.New("outputs not supported in conditionals")
 	case "switch":
 		return errors.New("nested conditionals are not supported")
 	case "case":
 		return errors.New("nested branches are not supported")
+	case foreach.Keyword:
+		return errors.New("foreach is not supported in conditionals")
 	}
 	return nil
 }
+
+// withPosition records where a node was loaded from
+func withPosition(meta *node.Node, url string, n *parse.Node) *node.Node {
+	meta.AddMetadata(MetaFile, url)
+	meta.AddMetadata(MetaPosition, fmt.Sprintf("%s:%s", url, n.Pos()))
+	return meta
+}
+
+// fileURL returns the URL of the file a node was loaded from, if known
+func fileURL(meta *node.Node) (string, bool) {
+	raw, ok := meta.LookupMetadata(MetaFile)
+	if !ok {
+		return "", false
+	}
+	url, ok := raw.(string)
+	return url, ok
+}
+
+// Position returns the position a node was loaded from, if known
+func Position(meta *node.Node) (string, bool) {
+	raw, ok := meta.LookupMetadata(MetaPosition)
+	if !ok {
+		return "", false
+	}
+	pos, ok := raw.(string)
+	return pos, ok
+}
+
+// Checksums returns the module checksums recorded in the graph during loading,
+// keyed by the ID of the node the module was loaded into.
+func Checksums(g *graph.Graph) map[string]string {
+	out := map[string]string{}
+	for _, meta := range g.Nodes() {
+		if raw, ok := meta.LookupMetadata(MetaChecksum); ok {
+			if sum, ok := raw.(string); ok {
+				out[meta.ID] = sum
+			}
+		}
+	}
+	return out
+}
@@ -17,6 +17,9 @@ package load_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -71,6 +74,21 @@ func TestNodesSourceFile(t *testing.T) {
 	)
 }
 
+// TestNodesChecksums tests that module checksums are recorded
+func TestNodesChecksums(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	g, err := load.Nodes(context.Background(), "../samples/sourceFile.hcl", false)
+	require.NoError(t, err)
+
+	checksums := load.Checksums(g)
+	assert.Len(t, checksums, 2)
+	assert.Len(t, checksums["root"], 64)
+	assert.Len(t, checksums["root/module.basic"], 64)
+	assert.NotEqual(t, checksums["root"], checksums["root/module.basic"])
+}
+
 // TestNodeWithConditionals tests loading when switch statements are present
 func TestNodeWithConditionals(t *testing.T) {
 	t.Parallel()
@@ -156,3 +174,220 @@ func assertMetadataMatches(t *testing.T, node *node.Node, key string, expected i
 	assert.True(t, ok)
 	assert.Equal(t, expected, actual)
 }
+
+// TestNodesForeach tests expanding foreach macros
+func TestNodesForeach(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	t.Run("defaults", func(t *testing.T) {
+		g, err := load.Nodes(context.Background(), "../samples/foreach.hcl", false)
+		require.NoError(t, err)
+
+		for _, id := range []string{
+			"root/file.directory.dir.logs",
+			"root/file.directory.dir.cache",
+			"root/file.content.greeting.english",
+			"root/file.content.greeting.spanish",
+		} {
+			assert.True(t, g.Contains(id), "%q was missing from the graph", id)
+			assert.Equal(t, "root", graph.ParentID(id))
+		}
+		assert.False(t, g.Contains("root/macro.foreach.directories"))
+
+		meta, _ := g.Get("root/file.content.greeting.spanish")
+		elem, ok := load.ForeachElement(meta)
+		require.True(t, ok)
+		assert.Equal(t, "spanish", elem.Key)
+		assert.Equal(t, "hola", elem.Value)
+	})
+
+	t.Run("params", func(t *testing.T) {
+		ctx := load.WithParams(context.Background(), map[string]string{"directories": `["a", "b"]`})
+		g, err := load.Nodes(ctx, "../samples/foreach.hcl", false)
+		require.NoError(t, err)
+
+		assert.True(t, g.Contains("root/file.directory.dir.a"))
+		assert.True(t, g.Contains("root/file.directory.dir.b"))
+		assert.False(t, g.Contains("root/file.directory.dir.logs"))
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		ctx := load.WithParams(context.Background(), map[string]string{"directories": "a"})
+		_, err := load.Nodes(ctx, "../samples/foreach.hcl", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `param "directories" must be a JSON list or map`)
+	})
+}
+
+// TestNodesInclude tests splicing included files into a module
+func TestNodesInclude(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	t.Run("sample", func(t *testing.T) {
+		g, err := load.Nodes(context.Background(), "../samples/include.hcl", false)
+		require.NoError(t, err)
+
+		for _, id := range []string{
+			"root/param.root",
+			"root/file.directory.root",
+			"root/file.directory.config",
+			"root/file.content.config",
+			"root/file.directory.logs",
+		} {
+			assert.True(t, g.Contains(id), "%q was missing from the graph", id)
+			assert.Equal(t, "root", graph.ParentID(id))
+		}
+
+		meta, _ := g.Get("root/file.directory.logs")
+		pos, ok := load.Position(meta)
+		require.True(t, ok)
+		assert.Contains(t, pos, "samples/include/logs.hcl:1:1")
+
+		file, ok := meta.LookupMetadata(load.MetaFile)
+		require.True(t, ok)
+		assert.Contains(t, file, "samples/include/logs.hcl")
+	})
+
+	dir, err := ioutil.TempDir("", "converge-include")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+		return filename
+	}
+
+	t.Run("checksum", func(t *testing.T) {
+		main := write("checksum/main.hcl", "include \"other.hcl\"\n")
+		write("checksum/other.hcl", "task \"a\" {\n  check = \"true\"\n  apply = \"true\"\n}\n")
+
+		before, err := load.Nodes(context.Background(), main, false)
+		require.NoError(t, err)
+
+		write("checksum/other.hcl", "task \"a\" {\n  check = \"false\"\n  apply = \"true\"\n}\n")
+
+		after, err := load.Nodes(context.Background(), main, false)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, load.Checksums(before)["root"], load.Checksums(after)["root"])
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		main := write("duplicate/main.hcl", "include \"other.hcl\"\n\nparam \"a\" {}\n")
+		write("duplicate/other.hcl", "\nparam \"a\" {}\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.EqualError(
+			t,
+			err,
+			"file://"+dir+"/duplicate/other.hcl:2:1: duplicate resource param.a, first defined at file://"+dir+"/duplicate/main.hcl:3:1",
+		)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		main := write("nomatch/main.hcl", "include \"roles/*.hcl\"\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.EqualError(t, err, "file://"+main+":1: cannot include \"roles/*.hcl\": no files match")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		main := write("cycle/main.hcl", "include \"other.hcl\"\n")
+		write("cycle/other.hcl", "include \"main.hcl\"\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "file://"+main+" is included more than once")
+	})
+}
+
+// TestNodesTemplate tests loading the sources of file.template resources with
+// the module
+func TestNodesTemplate(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	t.Run("sample", func(t *testing.T) {
+		g, err := load.Nodes(context.Background(), "../samples/fileTemplate.hcl", false)
+		require.NoError(t, err)
+
+		expected, err := ioutil.ReadFile("../samples/templates/motd.tmpl")
+		require.NoError(t, err)
+
+		meta, ok := g.Get("root/file.template.motd")
+		require.True(t, ok)
+
+		template, ok := meta.LookupMetadata(load.MetaTemplate)
+		require.True(t, ok)
+		assert.Equal(t, string(expected), template)
+	})
+
+	dir, err := ioutil.TempDir("", "converge-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+		return filename
+	}
+
+	t.Run("relative to include", func(t *testing.T) {
+		main := write("include/main.hcl", "include \"roles/web.hcl\"\n")
+		write("include/roles/web.hcl", "file.template \"a\" {\n  source      = \"a.tmpl\"\n  destination = \"a\"\n}\n")
+		write("include/roles/a.tmpl", "web")
+
+		g, err := load.Nodes(context.Background(), main, false)
+		require.NoError(t, err)
+
+		meta, _ := g.Get("root/file.template.a")
+		template, _ := meta.LookupMetadata(load.MetaTemplate)
+		assert.Equal(t, "web", template)
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		main := write("checksum/main.hcl", "file.template \"a\" {\n  source      = \"a.tmpl\"\n  destination = \"a\"\n}\n")
+		write("checksum/a.tmpl", "before")
+
+		before, err := load.Nodes(context.Background(), main, false)
+		require.NoError(t, err)
+
+		write("checksum/a.tmpl", "after")
+
+		after, err := load.Nodes(context.Background(), main, false)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, load.Checksums(before)["root"], load.Checksums(after)["root"])
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		main := write("missing/main.hcl", "file.template \"a\" {\n  source      = \"a.tmpl\"\n  destination = \"a\"\n}\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "file://"+main+":1:1: file://"+dir+"/missing/a.tmpl")
+	})
+
+	t.Run("templated source", func(t *testing.T) {
+		main := write("templated/main.hcl", "file.template \"a\" {\n  source      = \"{{param `x`}}\"\n  destination = \"a\"\n}\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.EqualError(t, err, "file://"+main+":1:1: \"source\" cannot be templated, it is loaded with the module")
+	})
+
+	t.Run("template set", func(t *testing.T) {
+		main := write("set/main.hcl", "file.template \"a\" {\n  source      = \"a.tmpl\"\n  template    = \"x\"\n  destination = \"a\"\n}\n")
+
+		_, err := load.Nodes(context.Background(), main, false)
+		require.Error(t, err)
+		assert.EqualError(t, err, "file://"+main+":1:1: \"template\" cannot be set, it is loaded from \"source\"")
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/parse"
+	"github.com/asteris-llc/converge/resource/param"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl"
+)
+
+// ValidateParams checks the declarations of the params in a graph of
+// *parse.Node, as loaded by Nodes. Values are checked against the declarations
+// when the graph is rendered.
+func ValidateParams(g *graph.Graph) error {
+	var err error
+
+	ids := g.Vertices()
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		meta, _ := g.Get(id)
+		raw, ok := meta.Value().(*parse.Node)
+		if !ok || raw.Kind() != "param" {
+			continue
+		}
+
+		pos, ok := Position(meta)
+		if !ok {
+			pos = id
+		}
+
+		var p param.Preparer
+		if decodeErr := hcl.DecodeObject(&p, raw.ObjectItem.Val); decodeErr != nil {
+			err = multierror.Append(err, fmt.Errorf("%s: %s: %s", pos, raw.ID(), decodeErr))
+			continue
+		}
+
+		if validErr := p.Validate(); validErr != nil {
+			err = multierror.Append(err, fmt.Errorf("%s: %s: %s", pos, raw.ID(), validErr))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateParams tests checking param declarations
+func TestValidateParams(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	t.Run("valid", func(t *testing.T) {
+		g, err := load.Nodes(context.Background(), "../samples/paramTypes.hcl", false)
+		require.NoError(t, err)
+
+		assert.NoError(t, load.ValidateParams(g))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		g, err := load.Nodes(context.Background(), "../samples/errors/bad_param_type.hcl", false)
+		require.NoError(t, err)
+
+		err = load.ValidateParams(g)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `bad_param_type.hcl:2:1: param.port: default must be an int, got "eighty"`)
+		}
+	})
+}
+
+// TestPosition tests that nodes record where they were loaded from
+func TestPosition(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	g, err := load.Nodes(context.Background(), "../samples/paramTypes.hcl", false)
+	require.NoError(t, err)
+
+	meta, ok := g.Get("root/param.environment")
+	require.True(t, ok)
+
+	pos, ok := load.Position(meta)
+	require.True(t, ok)
+	assert.Contains(t, pos, "samples/paramTypes.hcl:9:1")
+}
@@ -0,0 +1,159 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ReadParamsFiles reads params from YAML, JSON, or HCL files. The files are
+// merged in order, so a param in a later file replaces the same param in an
+// earlier one. Values are encoded with EncodeParam.
+func ReadParamsFiles(filenames []string) (map[string]string, error) {
+	out := map[string]string{}
+
+	for _, filename := range filenames {
+		params, err := ReadParamsFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, val := range params {
+			encoded, err := EncodeParam(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s: could not encode param %s", filename, key)
+			}
+			out[key] = encoded
+		}
+	}
+
+	return out, nil
+}
+
+// ReadParamsFile reads params from a single file. The format is chosen by the
+// extension: ".yaml" or ".yml" for YAML, ".json" for JSON, and ".hcl" for HCL.
+// Values may be nested lists and maps.
+func ReadParamsFile(filename string) (map[string]interface{}, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read params file")
+	}
+
+	var raw interface{}
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".yaml", ".yml":
+		var out interface{}
+		err = yaml.Unmarshal(content, &out)
+		raw = out
+
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.UseNumber()
+
+		var out interface{}
+		err = dec.Decode(&out)
+		raw = out
+
+	case ".hcl":
+		var out map[string]interface{}
+		err = hcl.Decode(&out, string(content))
+		raw = out
+
+	default:
+		return nil, fmt.Errorf("%s: unknown params file format %q, expected .yaml, .yml, .json, or .hcl", filename, ext)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: could not parse params", filename)
+	}
+
+	if raw == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	params, ok := normalizeParam(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: params must be a map of names to values, not %T", filename, raw)
+	}
+
+	return params, nil
+}
+
+// EncodeParam encodes a param value as a string, as params are sent over RPC.
+// Lists and maps are encoded as JSON so that paramList, paramMap, and macros
+// can use them.
+func EncodeParam(val interface{}) (string, error) {
+	switch val.(type) {
+	case []interface{}, map[string]interface{}:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+
+	default:
+		return fmt.Sprintf("%v", val), nil
+	}
+}
+
+// normalizeParam converts the values produced by the YAML and HCL decoders to
+// the lists and string-keyed maps produced by the JSON decoder
+func normalizeParam(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, inner := range v {
+			out[fmt.Sprintf("%v", key)] = normalizeParam(inner)
+		}
+		return out
+
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, inner := range v {
+			out[key] = normalizeParam(inner)
+		}
+		return out
+
+	case []map[string]interface{}:
+		// HCL decodes a map as a list of maps, one for every time it was
+		// declared
+		out := map[string]interface{}{}
+		for _, m := range v {
+			for key, inner := range m {
+				out[key] = normalizeParam(inner)
+			}
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, inner := range v {
+			out[i] = normalizeParam(inner)
+		}
+		return out
+
+	default:
+		return val
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/load"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadParamsFile tests reading params in every supported format
+func TestReadParamsFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-params-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	expected := map[string]interface{}{
+		"name":  "web",
+		"ports": []interface{}{"80", "443"},
+		"tags":  map[string]interface{}{"env": "prod"},
+	}
+
+	for name, content := range map[string]string{
+		"params.yaml": "name: web\nports: [\"80\", \"443\"]\ntags:\n  env: prod\n",
+		"params.json": `{"name": "web", "ports": ["80", "443"], "tags": {"env": "prod"}}`,
+		"params.hcl":  "name = \"web\"\nports = [\"80\", \"443\"]\ntags {\n  env = \"prod\"\n}\n",
+	} {
+		filename := writeParamsFile(t, dir, name, content)
+
+		params, err := load.ReadParamsFile(filename)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, params, name)
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		filename := writeParamsFile(t, dir, "params.toml", "name = \"web\"")
+
+		_, err := load.ReadParamsFile(filename)
+		assert.EqualError(t, err, filename+`: unknown params file format ".toml", expected .yaml, .yml, .json, or .hcl`)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		filename := writeParamsFile(t, dir, "list.yaml", "- a\n- b\n")
+
+		_, err := load.ReadParamsFile(filename)
+		assert.EqualError(t, err, filename+": params must be a map of names to values, not []interface {}")
+	})
+}
+
+// TestReadParamsFiles tests merging params files
+func TestReadParamsFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-params-files")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	params, err := load.ReadParamsFiles([]string{
+		writeParamsFile(t, dir, "base.yaml", "name: web\ncount: 1\nports: [80]\n"),
+		writeParamsFile(t, dir, "prod.json", `{"count": 3, "tags": {"env": "prod"}}`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]string{
+			"name":  "web",
+			"count": "3",
+			"ports": "[80]",
+			"tags":  `{"env":"prod"}`,
+		},
+		params,
+	)
+}
+
+func writeParamsFile(t *testing.T, dir, name, content string) string {
+	filename := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0600))
+	return filename
+}
@@ -16,6 +16,8 @@ package load
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
@@ -30,16 +32,23 @@ import (
 	_ "github.com/asteris-llc/converge/resource/docker/image"
 	_ "github.com/asteris-llc/converge/resource/docker/network"
 	_ "github.com/asteris-llc/converge/resource/docker/volume"
+	_ "github.com/asteris-llc/converge/resource/file/block"
 	_ "github.com/asteris-llc/converge/resource/file/content"
 	_ "github.com/asteris-llc/converge/resource/file/directory"
 	_ "github.com/asteris-llc/converge/resource/file/fetch"
+	_ "github.com/asteris-llc/converge/resource/file/line"
+	_ "github.com/asteris-llc/converge/resource/file/link"
 	_ "github.com/asteris-llc/converge/resource/file/mode"
 	_ "github.com/asteris-llc/converge/resource/file/owner"
+	_ "github.com/asteris-llc/converge/resource/file/structured"
+	_ "github.com/asteris-llc/converge/resource/file/template"
 	_ "github.com/asteris-llc/converge/resource/group"
+	_ "github.com/asteris-llc/converge/resource/local"
 	_ "github.com/asteris-llc/converge/resource/lvm/fs"
 	_ "github.com/asteris-llc/converge/resource/lvm/lv"
 	_ "github.com/asteris-llc/converge/resource/lvm/vg"
 	_ "github.com/asteris-llc/converge/resource/module"
+	_ "github.com/asteris-llc/converge/resource/output"
 	_ "github.com/asteris-llc/converge/resource/package/apt"
 	_ "github.com/asteris-llc/converge/resource/package/rpm"
 	_ "github.com/asteris-llc/converge/resource/param"
@@ -53,6 +62,18 @@ import (
 	"golang.org/x/net/context"
 )
 
+// MetaRetryPolicy is the metadata key for the *resource.RetryPolicy of a node,
+// if it sets any retries
+const MetaRetryPolicy = "retry-policy"
+
+// MetaTimeout is the metadata key for the time.Duration a node may take in
+// each stage, if it sets a timeout
+const MetaTimeout = "timeout"
+
+// MetaIgnoreErrors is the metadata key that is set to true when the
+// dependents of a node should run even if it fails
+const MetaIgnoreErrors = "ignore-errors"
+
 // SetResources loads the resources for each graph node
 func SetResources(ctx context.Context, g *graph.Graph) (*graph.Graph, error) {
 	logger := logging.GetLogger(ctx).WithField("function", "SetResources")
@@ -82,10 +103,91 @@ func SetResources(ctx context.Context, g *graph.Graph) (*graph.Graph, error) {
 
 		err := hcl.DecodeObject(&preparer.Source, raw.ObjectItem.Val)
 		if err != nil {
-			return err
+			return &graph.NodeError{ID: meta.ID, Err: err}
+		}
+
+		// the source of a template was fetched with the module, and is rendered
+		// like any other field
+		if template, ok := meta.LookupMetadata(MetaTemplate); ok {
+			preparer.Source["template"] = template
+		}
+
+		if err := checkNotTemplated(preparer.Source, resource.RetriesField, resource.RetryIntervalField, resource.RetryBackoffField, resource.IgnoreErrorsField); err != nil {
+			return &graph.NodeError{ID: meta.ID, Err: err}
+		}
+
+		policy, err := resource.NewRetryPolicy(preparer.Source)
+		if err != nil {
+			return &graph.NodeError{ID: meta.ID, Err: err}
 		}
 
-		out.Add(meta.WithValue(preparer))
+		withResource := meta.WithValue(preparer)
+		if policy != nil {
+			if err := withResource.AddMetadata(MetaRetryPolicy, policy); err != nil {
+				return err
+			}
+		}
+
+		// resources with their own timeout field, like task, handle the timeout
+		// themselves
+		if !preparer.HasField(resource.TimeoutField) {
+			if err := checkNotTemplated(preparer.Source, resource.TimeoutField); err != nil {
+				return &graph.NodeError{ID: meta.ID, Err: err}
+			}
+
+			timeout, err := resource.ParseTimeout(preparer.Source)
+			if err != nil {
+				return &graph.NodeError{ID: meta.ID, Err: err}
+			}
+			if timeout > 0 {
+				if err := withResource.AddMetadata(MetaTimeout, timeout); err != nil {
+					return err
+				}
+			}
+		}
+
+		ignoreErrors, err := resource.ParseIgnoreErrors(preparer.Source)
+		if err != nil {
+			return &graph.NodeError{ID: meta.ID, Err: err}
+		}
+		if ignoreErrors {
+			if err := withResource.AddMetadata(MetaIgnoreErrors, true); err != nil {
+				return err
+			}
+		}
+
+		out.Add(withResource)
 		return nil
 	})
 }
+
+// checkNotTemplated refuses templates in fields that are read when the module
+// is loaded, before anything is rendered
+func checkNotTemplated(source map[string]interface{}, fields ...string) error {
+	for _, field := range fields {
+		if str, ok := source[field].(string); ok && strings.Contains(str, "{{") {
+			return fmt.Errorf("%s cannot be templated, since it is read when the module is loaded", field)
+		}
+	}
+	return nil
+}
+
+// Timeout returns the timeout of a node, or 0 if it does not have one
+func Timeout(meta *node.Node) time.Duration {
+	if raw, ok := meta.LookupMetadata(MetaTimeout); ok {
+		if timeout, ok := raw.(time.Duration); ok {
+			return timeout
+		}
+	}
+	return 0
+}
+
+// IgnoreErrors returns true if the errors of a node should not stop its
+// dependents or fail the run
+func IgnoreErrors(meta *node.Node) bool {
+	if raw, ok := meta.LookupMetadata(MetaIgnoreErrors); ok {
+		ignore, _ := raw.(bool)
+		return ignore
+	}
+	return false
+}
@@ -17,6 +17,7 @@ package load_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
@@ -61,6 +62,78 @@ func TestSetResourcesBad(t *testing.T) {
 	}
 }
 
+// TestSetResourcesOptions tests that the options every resource accepts are
+// recorded in metadata
+func TestSetResourcesOptions(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("retries and timeout", func(t *testing.T) {
+		resourced, err := getResourcesGraph(
+			t,
+			[]byte(`
+file.content x {
+  destination    = "x"
+  retries        = 2
+  retry_interval = "1s"
+  timeout        = "30s"
+}`),
+		)
+		require.NoError(t, err)
+
+		meta, ok := resourced.Get("root/file.content.x")
+		require.True(t, ok)
+
+		policy, ok := meta.LookupMetadata(load.MetaRetryPolicy)
+		require.True(t, ok)
+		assert.Equal(t, &resource.RetryPolicy{Retries: 2, Interval: time.Second, Backoff: 1}, policy)
+
+		assert.Equal(t, 30*time.Second, load.Timeout(meta))
+	})
+
+	t.Run("own timeout", func(t *testing.T) {
+		resourced, err := getResourcesGraph(
+			t,
+			[]byte(`
+task x {
+  check   = "check"
+  apply   = "apply"
+  timeout = "30s"
+}`),
+		)
+		require.NoError(t, err)
+
+		meta, ok := resourced.Get("root/task.x")
+		require.True(t, ok)
+		assert.Equal(t, time.Duration(0), load.Timeout(meta))
+	})
+
+	t.Run("ignore errors", func(t *testing.T) {
+		resourced, err := getResourcesGraph(t, []byte(`file.content x { ignore_errors = true }`))
+		require.NoError(t, err)
+
+		meta, ok := resourced.Get("root/file.content.x")
+		require.True(t, ok)
+		assert.True(t, load.IgnoreErrors(meta))
+	})
+
+	t.Run("templated options", func(t *testing.T) {
+		for _, field := range []string{"retries", "retry_interval", "retry_backoff", "timeout", "ignore_errors"} {
+			_, err := getResourcesGraph(t, []byte(`file.content x { `+field+` = "{{param `+"`x`"+`}}" }`))
+			assert.EqualError(t, err, "1 error(s) occurred:\n\n* root/file.content.x: "+field+" cannot be templated, since it is read when the module is loaded")
+		}
+	})
+
+	t.Run("templated own timeout", func(t *testing.T) {
+		_, err := getResourcesGraph(t, []byte(`task x { timeout = "{{param `+"`x`"+`}}" }`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("bad timeout", func(t *testing.T) {
+		_, err := getResourcesGraph(t, []byte(`file.content x { timeout = "soon" }`))
+		assert.EqualError(t, err, "1 error(s) occurred:\n\n* root/file.content.x: timeout must be a duration, got \"soon\"")
+	})
+}
+
 func getResourcesGraph(t *testing.T, content []byte) (*graph.Graph, error) {
 	resources, err := parse.Parse(content)
 	require.NoError(t, err)
@@ -0,0 +1,235 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// DefaultPath is the default location of the apply lock
+const DefaultPath = "/var/lib/converge/apply.lock"
+
+// PollInterval is how often a held lock is checked while waiting for it
+var PollInterval = 100 * time.Millisecond
+
+// Holder describes the process holding the lock
+type Holder struct {
+	PID    int       `json:"pid"`
+	Start  time.Time `json:"start"`
+	Module string    `json:"module"`
+}
+
+// NewHolder describes the current process applying the given module
+func NewHolder(module string) *Holder {
+	return &Holder{
+		PID:    os.Getpid(),
+		Start:  time.Now(),
+		Module: module,
+	}
+}
+
+// Alive checks whether the holder's process is still running on this host
+func (h *Holder) Alive() bool {
+	if h.PID <= 0 {
+		return false
+	}
+
+	err := syscall.Kill(h.PID, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+func (h *Holder) String() string {
+	return fmt.Sprintf("pid %d applying %q since %s", h.PID, h.Module, h.Start.Format(time.RFC3339))
+}
+
+// HeldError is returned when the lock is held by another process
+type HeldError struct {
+	Path   string
+	Holder *Holder
+}
+
+func (e *HeldError) Error() string {
+	if e.Holder == nil {
+		return fmt.Sprintf("%s is held by another process", e.Path)
+	}
+
+	msg := fmt.Sprintf("%s is held by %s", e.Path, e.Holder)
+	if !e.Holder.Alive() {
+		msg += " (process is not running, the lock may be stale)"
+	}
+	return msg
+}
+
+// UnavailableError is returned when the lock file cannot be created or opened,
+// for example because the user running converge cannot write to its directory
+type UnavailableError struct {
+	Path string
+	Err  error
+}
+
+func (e *UnavailableError) Error() string {
+	return e.Err.Error()
+}
+
+// Lock is a host-wide advisory lock. The lock itself is an flock on the lock
+// file, so the kernel releases it if the holder dies. The file records the
+// holder so that other processes can say who they are waiting for.
+type Lock struct {
+	file   *os.File
+	holder *Holder
+}
+
+// Acquire takes the lock at path. If the lock is held, Acquire will wait up to
+// timeout for it to be released before returning a HeldError. A timeout of
+// zero fails immediately.
+func Acquire(ctx context.Context, path string, holder *Holder, timeout time.Duration) (*Lock, error) {
+	logger := logging.GetLogger(ctx).WithField("function", "lock.Acquire").WithField("path", path)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		lock, err := tryAcquire(ctx, path, holder)
+		if err == nil {
+			return lock, nil
+		}
+
+		held, ok := err.(*HeldError)
+		if !ok || !time.Now().Before(deadline) {
+			return nil, err
+		}
+
+		logger.WithField("holder", held.Holder).Debug("waiting for lock")
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "waiting for lock: %s", held)
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+func tryAcquire(ctx context.Context, path string, holder *Holder) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, &UnavailableError{Path: path, Err: errors.Wrap(err, "could not create lock directory")}
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, &UnavailableError{Path: path, Err: errors.Wrap(err, "could not open lock file")}
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+
+		if err == syscall.EWOULDBLOCK {
+			current, _ := Read(path)
+			return nil, &HeldError{Path: path, Holder: current}
+		}
+		return nil, errors.Wrap(err, "could not lock")
+	}
+
+	// the file is emptied when the lock is released, so anything left over is
+	// from a process that died while holding the lock
+	if stale, err := readHolder(file); err == nil && stale != nil {
+		logging.GetLogger(ctx).WithField("function", "lock.Acquire").WithField("holder", stale).Warn("taking over stale lock")
+	}
+
+	content, err := json.Marshal(holder)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "could not serialize lock holder")
+	}
+
+	if err := replaceContent(file, content); err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "could not write lock holder")
+	}
+
+	return &Lock{file: file, holder: holder}, nil
+}
+
+// Holder returns the holder recorded when the lock was acquired
+func (l *Lock) Holder() *Holder {
+	return l.holder
+}
+
+// Release the lock. The lock file is emptied but not removed, since removing
+// it would let two processes lock different files at the same path.
+func (l *Lock) Release() error {
+	defer l.file.Close()
+
+	if err := replaceContent(l.file, nil); err != nil {
+		return errors.Wrap(err, "could not clear lock holder")
+	}
+
+	return syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+}
+
+// Read returns the holder recorded in the lock file at path, or nil if there
+// is none
+func Read(path string) (*Holder, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not open lock file")
+	}
+	defer file.Close()
+
+	return readHolder(file)
+}
+
+func readHolder(file *os.File) (*Holder, error) {
+	if _, err := file.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read lock file")
+	}
+
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	holder := new(Holder)
+	if err := json.Unmarshal(content, holder); err != nil {
+		return nil, errors.Wrap(err, "could not parse lock file")
+	}
+
+	return holder, nil
+}
+
+func replaceContent(file *os.File, content []byte) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := file.WriteAt(content, 0); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,143 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// TestAcquire tests taking and releasing the lock
+func TestAcquire(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	dir, err := ioutil.TempDir("", "converge-lock")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state", "apply.lock")
+
+	t.Run("records holder", func(t *testing.T) {
+		holder := lock.NewHolder("test.hcl")
+		l, err := lock.Acquire(context.Background(), path, holder, 0)
+		require.NoError(t, err)
+
+		current, err := lock.Read(path)
+		require.NoError(t, err)
+		require.NotNil(t, current)
+		assert.Equal(t, os.Getpid(), current.PID)
+		assert.Equal(t, "test.hcl", current.Module)
+		assert.True(t, holder.Start.Equal(current.Start))
+
+		require.NoError(t, l.Release())
+
+		current, err = lock.Read(path)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+	})
+
+	t.Run("held", func(t *testing.T) {
+		l, err := lock.Acquire(context.Background(), path, lock.NewHolder("first.hcl"), 0)
+		require.NoError(t, err)
+		defer l.Release()
+
+		_, err = lock.Acquire(context.Background(), path, lock.NewHolder("second.hcl"), 0)
+		require.Error(t, err)
+
+		held, ok := err.(*lock.HeldError)
+		require.True(t, ok, "expected a HeldError, got %T", err)
+		assert.Equal(t, "first.hcl", held.Holder.Module)
+		assert.Contains(t, err.Error(), `applying "first.hcl"`)
+		assert.NotContains(t, err.Error(), "stale")
+	})
+
+	t.Run("waits", func(t *testing.T) {
+		l, err := lock.Acquire(context.Background(), path, lock.NewHolder("first.hcl"), 0)
+		require.NoError(t, err)
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			l.Release()
+		}()
+
+		second, err := lock.Acquire(context.Background(), path, lock.NewHolder("second.hcl"), 5*time.Second)
+		require.NoError(t, err)
+		assert.NoError(t, second.Release())
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		l, err := lock.Acquire(context.Background(), path, lock.NewHolder("first.hcl"), 0)
+		require.NoError(t, err)
+		defer l.Release()
+
+		start := time.Now()
+		_, err = lock.Acquire(context.Background(), path, lock.NewHolder("second.hcl"), 200*time.Millisecond)
+		assert.IsType(t, &lock.HeldError{}, err)
+		assert.True(t, time.Since(start) >= 200*time.Millisecond)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		// a holder that died without releasing the lock leaves its record
+		// behind, but the kernel has released the flock
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"pid":-1,"module":"dead.hcl"}`), 0644))
+
+		l, err := lock.Acquire(context.Background(), path, lock.NewHolder("test.hcl"), 0)
+		require.NoError(t, err)
+		defer l.Release()
+
+		current, err := lock.Read(path)
+		require.NoError(t, err)
+		assert.Equal(t, "test.hcl", current.Module)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		// the lock directory cannot be created under a regular file
+		blocked := filepath.Join(dir, "file")
+		require.NoError(t, ioutil.WriteFile(blocked, nil, 0644))
+
+		_, err := lock.Acquire(context.Background(), filepath.Join(blocked, "apply.lock"), lock.NewHolder("test.hcl"), time.Second)
+		assert.IsType(t, &lock.UnavailableError{}, err)
+	})
+}
+
+// TestHeldError tests the message for a held lock
+func TestHeldError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alive", func(t *testing.T) {
+		err := &lock.HeldError{Path: "apply.lock", Holder: lock.NewHolder("test.hcl")}
+		assert.Contains(t, err.Error(), "apply.lock is held by pid")
+		assert.NotContains(t, err.Error(), "stale")
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		err := &lock.HeldError{Path: "apply.lock", Holder: &lock.Holder{PID: -1, Module: "test.hcl"}}
+		assert.Contains(t, err.Error(), "the lock may be stale")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		err := &lock.HeldError{Path: "apply.lock"}
+		assert.Equal(t, "apply.lock is held by another process", err.Error())
+	})
+}
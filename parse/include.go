@@ -0,0 +1,56 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// IncludeKeyword starts a line that includes other files in a module
+const IncludeKeyword = "include"
+
+var includeRe = regexp.MustCompile(`^` + IncludeKeyword + `[ \t]+"([^"]+)"[ \t]*$`)
+
+// Include is an include directive in a module
+type Include struct {
+	Pattern string
+	Line    int
+}
+
+// ExtractIncludes finds the include directives in a module and removes them
+// from the content, so that the rest can be parsed as usual. The directives
+// must start at the beginning of a line, as in `include "roles/*.hcl"`. Lines
+// are blanked rather than removed, so positions in the content don't change.
+func ExtractIncludes(content []byte) ([]byte, []*Include) {
+	var includes []*Include
+
+	lines := bytes.Split(content, []byte("\n"))
+	for i, line := range lines {
+		match := includeRe.FindSubmatch(bytes.TrimRight(line, "\r"))
+		if match == nil {
+			continue
+		}
+
+		includes = append(includes, &Include{Pattern: string(match[1]), Line: i + 1})
+		lines[i] = nil
+	}
+
+	if len(includes) == 0 {
+		return content, nil
+	}
+
+	return bytes.Join(lines, []byte("\n")), includes
+}
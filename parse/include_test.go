@@ -0,0 +1,51 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractIncludes(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("include \"roles/*.hcl\"\n\ntask \"x\" {\n  check = \"include \\\"no\\\"\"\n}\ninclude \"base.hcl\"  \n  include \"indented.hcl\"\n")
+
+	remaining, includes := parse.ExtractIncludes(content)
+
+	assert.Equal(
+		t,
+		[]*parse.Include{
+			{Pattern: "roles/*.hcl", Line: 1},
+			{Pattern: "base.hcl", Line: 6},
+		},
+		includes,
+	)
+	assert.Equal(t, "\n\ntask \"x\" {\n  check = \"include \\\"no\\\"\"\n}\n\n  include \"indented.hcl\"\n", string(remaining))
+}
+
+func TestExtractIncludesNone(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("task \"x\" {}\n")
+
+	remaining, includes := parse.ExtractIncludes(content)
+
+	assert.Nil(t, includes)
+	assert.Equal(t, content, remaining)
+}
@@ -0,0 +1,253 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package foreach
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/asteris-llc/converge/parse"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/pkg/errors"
+)
+
+// Keyword starts a foreach macro
+const Keyword = "macro.foreach"
+
+// Foreach represents a foreach macro. The inner nodes are stamped out once for
+// every element of either a param or a literal list of items.
+type Foreach struct {
+	Name       string
+	Param      string
+	Items      interface{}
+	InnerNodes []*parse.Node
+	Node       *parse.Node
+}
+
+// Element is a single element of the list or map being iterated over. It is
+// available in the templates of the generated nodes as {{.Key}} and
+// {{.Value}}.
+type Element struct {
+	Key   string
+	Value interface{}
+}
+
+// IsForeachNode returns true if the parse node represents a foreach macro
+func IsForeachNode(n *parse.Node) bool {
+	return n.Kind() == Keyword
+}
+
+// NewForeach constructs a *Foreach from a foreach node
+func NewForeach(n *parse.Node) (*Foreach, error) {
+	if !IsForeachNode(n) {
+		return nil, fmt.Errorf("expected %s node but got %s", Keyword, n.Kind())
+	}
+
+	obj, ok := n.Val.(*ast.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s %q must be a block", n.Pos(), Keyword, n.Name())
+	}
+
+	f := &Foreach{Name: n.Name(), Node: n}
+	for _, item := range obj.List.Items {
+		if len(item.Keys) > 1 {
+			inner := parse.NewNode(item)
+			if err := inner.Validate(); err != nil {
+				return nil, err
+			}
+			if err := validateInnerNode(inner); err != nil {
+				return nil, fmt.Errorf("%s: %s", inner.Pos(), err)
+			}
+			f.InnerNodes = append(f.InnerNodes, inner)
+			continue
+		}
+
+		if err := f.setAttribute(item); err != nil {
+			return nil, fmt.Errorf("%s: %s", item.Pos(), err)
+		}
+	}
+
+	if f.Param == "" && f.Items == nil {
+		return nil, fmt.Errorf("%s: %s %q needs either a param or items", n.Pos(), Keyword, f.Name)
+	}
+	if f.Param != "" && f.Items != nil {
+		return nil, fmt.Errorf("%s: %s %q cannot have both a param and items", n.Pos(), Keyword, f.Name)
+	}
+
+	return f, nil
+}
+
+func (f *Foreach) setAttribute(item *ast.ObjectItem) error {
+	name, _ := item.Keys[0].Token.Value().(string)
+
+	var val interface{}
+	if err := hcl.DecodeObject(&val, item.Val); err != nil {
+		return errors.Wrap(err, name)
+	}
+
+	switch name {
+	case "param":
+		param, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("param must be the name of a param, got %T", val)
+		}
+		f.Param = param
+
+	case "items":
+		f.Items = val
+
+	default:
+		return fmt.Errorf("unknown field %q in %s (expected param, items, or resources)", name, Keyword)
+	}
+
+	return nil
+}
+
+// validateInnerNode makes sure we only generate plain resources. Nested macros,
+// modules, and params would need IDs that can't be known at load time.
+func validateInnerNode(n *parse.Node) error {
+	switch n.Kind() {
+	case "module":
+		return errors.New("modules are not supported in " + Keyword)
+	case "param":
+		return errors.New("params are not supported in " + Keyword)
+	case Keyword:
+		return errors.New("nested " + Keyword + " is not supported")
+	case "switch", "case", "default":
+		return errors.New("conditionals are not supported in " + Keyword)
+	}
+	return nil
+}
+
+// Elements splits a list or map into the elements to iterate over. Map
+// elements are keyed by their map key and sorted. List elements that are
+// strings, numbers, or booleans are keyed by themselves so that reordering the
+// list does not change the IDs of the generated nodes. Other list elements are
+// keyed by their index.
+func Elements(items interface{}) ([]*Element, error) {
+	items = Normalize(items)
+
+	var out []*Element
+	val := reflect.ValueOf(items)
+	switch val.Kind() {
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			out = append(out, &Element{
+				Key:   fmt.Sprintf("%v", key.Interface()),
+				Value: val.MapIndex(key).Interface(),
+			})
+		}
+		sort.Sort(byKey(out))
+
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i).Interface()
+			key := strconv.Itoa(i)
+			if isScalar(elem) {
+				key = fmt.Sprintf("%v", elem)
+			}
+			out = append(out, &Element{Key: key, Value: elem})
+		}
+
+	default:
+		return nil, fmt.Errorf("can only iterate over a list or a map, got %T", items)
+	}
+
+	seen := map[string]struct{}{}
+	for _, elem := range out {
+		if err := validateKey(elem.Key); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[elem.Key]; ok {
+			return nil, fmt.Errorf("duplicate key %q", elem.Key)
+		}
+		seen[elem.Key] = struct{}{}
+	}
+
+	return out, nil
+}
+
+// ID returns the ID of the node generated from inner for this element
+func (e *Element) ID(inner *parse.Node) string {
+	return inner.ID() + "." + e.Key
+}
+
+// Normalize converts the single-element lists of maps that HCL decodes objects
+// into back into maps
+func Normalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case []map[string]interface{}:
+		if len(v) == 1 {
+			return Normalize(v[0])
+		}
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = Normalize(item)
+		}
+		return out
+
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = Normalize(item)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = Normalize(item)
+		}
+		return out
+
+	default:
+		return val
+	}
+}
+
+func isScalar(val interface{}) bool {
+	switch val.(type) {
+	case string, bool, int, int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateKey makes sure a key can be used in a node ID
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("keys cannot be empty")
+	}
+
+	for _, letter := range key {
+		if !(unicode.IsLetter(letter) || unicode.IsNumber(letter) || letter == '-' || letter == '_' || letter == '.') {
+			return fmt.Errorf("key %q cannot be used in a node ID; valid characters are unicode letters and numbers, dashes '-', underscores '_', and dots '.' (use a map to choose keys)", key)
+		}
+	}
+
+	return nil
+}
+
+// byKey sorts elements by key
+type byKey []*Element
+
+func (b byKey) Len() int           { return len(b) }
+func (b byKey) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byKey) Less(i, j int) bool { return b[i].Key < b[j].Key }
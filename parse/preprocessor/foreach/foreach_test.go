@@ -0,0 +1,183 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package foreach_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/parse"
+	"github.com/asteris-llc/converge/parse/preprocessor/foreach"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseOne(t *testing.T, content string) *parse.Node {
+	nodes, err := parse.Parse([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	return nodes[0]
+}
+
+// TestNewForeach tests parsing foreach macros
+func TestNewForeach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("param", func(t *testing.T) {
+		f, err := foreach.NewForeach(parseOne(t, `
+macro.foreach "dirs" {
+  param = "dirs"
+
+  file.directory "dir" {
+    destination = "{{.Value}}"
+  }
+
+  task "x" {
+    check = "true"
+    apply = "true"
+  }
+}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "dirs", f.Name)
+		assert.Equal(t, "dirs", f.Param)
+		assert.Nil(t, f.Items)
+		require.Len(t, f.InnerNodes, 2)
+		assert.Equal(t, "file.directory.dir", f.InnerNodes[0].ID())
+		assert.Equal(t, "task.x", f.InnerNodes[1].ID())
+	})
+
+	t.Run("items", func(t *testing.T) {
+		f, err := foreach.NewForeach(parseOne(t, `
+macro.foreach "dirs" {
+  items = ["a", "b"]
+
+  file.directory "dir" {
+    destination = "{{.Value}}"
+  }
+}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{"a", "b"}, f.Items)
+	})
+
+	errorCases := map[string]string{
+		"neither": `macro.foreach "x" {
+  task "x" { check = "true" }
+}`,
+		"both": `macro.foreach "x" {
+  param = "x"
+  items = ["a"]
+}`,
+		"unknown field": `macro.foreach "x" {
+  param = "x"
+  count = 3
+}`,
+		"module": `macro.foreach "x" {
+  param = "x"
+  module "basic.hcl" "basic" {}
+}`,
+		"nested": `macro.foreach "x" {
+  param = "x"
+  macro.foreach "y" {
+    param = "y"
+  }
+}`,
+	}
+
+	for name, content := range errorCases {
+		content := content
+		t.Run(name, func(t *testing.T) {
+			_, err := foreach.NewForeach(parseOne(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestElements tests splitting items into elements
+func TestElements(t *testing.T) {
+	t.Parallel()
+
+	t.Run("list", func(t *testing.T) {
+		elems, err := foreach.Elements([]interface{}{"b", "a", 3})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]*foreach.Element{
+				{Key: "b", Value: "b"},
+				{Key: "a", Value: "a"},
+				{Key: "3", Value: 3},
+			},
+			elems,
+		)
+	})
+
+	t.Run("list of maps", func(t *testing.T) {
+		elems, err := foreach.Elements([]interface{}{
+			[]map[string]interface{}{{"name": "a"}},
+			[]map[string]interface{}{{"name": "b"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]*foreach.Element{
+				{Key: "0", Value: map[string]interface{}{"name": "a"}},
+				{Key: "1", Value: map[string]interface{}{"name": "b"}},
+			},
+			elems,
+		)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		elems, err := foreach.Elements([]map[string]interface{}{{"b": 2, "a": 1}})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]*foreach.Element{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 2},
+			},
+			elems,
+		)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := foreach.Elements([]interface{}{"a", "a"})
+		assert.EqualError(t, err, `duplicate key "a"`)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := foreach.Elements([]interface{}{"/var/log"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `key "/var/log" cannot be used in a node ID`)
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		_, err := foreach.Elements("a")
+		assert.EqualError(t, err, "can only iterate over a list or a map, got string")
+	})
+}
+
+// TestElementID tests the IDs of generated nodes
+func TestElementID(t *testing.T) {
+	t.Parallel()
+
+	inner := parseOne(t, `file.directory "dir" {}`)
+	elem := &foreach.Element{Key: "logs", Value: "logs"}
+
+	assert.Equal(t, "file.directory.dir.logs", elem.ID(inner))
+}
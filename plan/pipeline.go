@@ -17,12 +17,14 @@ package plan
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node/conditional"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/parse/preprocessor/switch"
 	"github.com/asteris-llc/converge/render"
 	"github.com/asteris-llc/converge/resource"
@@ -113,12 +115,21 @@ func parseTruth(predicate string) bool {
 // encountered it returns `Left error`, if failing dependencies are encountered
 // it returns `Right (Left Status)` and otherwise returns `Right (Right
 // Task)`. The return values are structured to short-circuit `PlanNode` if we
-// have failures.
+// have failures. Dependencies that ignore errors are only considered failing
+// when they were not run because of a failure further down, and when failing
+// fast every node after the first failure is short-circuited.
 func (g *pipelineGen) DependencyCheck(ctx context.Context, taskI interface{}) (interface{}, error) {
 	task, ok := taskI.(taskWrapper)
 	if !ok {
 		return nil, errors.New("input node is not a task wrapper")
 	}
+	if failed, ok := executor.GetFailFast(ctx).Failed(); ok {
+		return &Result{
+			Status: &resource.Status{Level: resource.StatusWillChange},
+			Task:   task.Task,
+			Err:    &executor.FailFastError{Failed: failed},
+		}, nil
+	}
 	for _, depID := range graph.Targets(g.Graph.DownEdges(g.ID)) {
 		meta, ok := g.Graph.Get(depID)
 		if !ok {
@@ -129,11 +140,11 @@ func (g *pipelineGen) DependencyCheck(ctx context.Context, taskI interface{}) (i
 		if !ok {
 			return nil, fmt.Errorf("expected executor.Status but got %T", meta.Value())
 		}
-		if err := dep.Error(); err != nil {
+		if executor.BlocksDependents(dep.Error(), load.IgnoreErrors(meta)) {
 			errResult := &Result{
 				Status: &resource.Status{Level: resource.StatusWillChange},
 				Task:   task.Task,
-				Err:    fmt.Errorf("error in dependency %q", depID),
+				Err:    &executor.DependencyError{Dependency: depID},
 			}
 			return errResult, nil
 		}
@@ -159,7 +170,15 @@ func (g *pipelineGen) PlanNode(ctx context.Context, taski interface{}) (interfac
 	if err != nil {
 		return nil, fmt.Errorf("unable to get renderer for %s", g.ID)
 	}
-	status, err := twrapper.Task.Check(ctx, renderer)
+	var timeout time.Duration
+	if meta, ok := g.Graph.Get(g.ID); ok {
+		timeout = load.Timeout(meta)
+	}
+
+	checked, err := resource.RunWithTimeout(ctx, "check", timeout, func(ctx context.Context) (interface{}, error) {
+		return twrapper.Task.Check(ctx, renderer)
+	})
+	status, _ := checked.(resource.TaskStatus)
 
 	// create empty Status structure, if it not created in .Check()
 	if status == nil {
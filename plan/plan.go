@@ -17,9 +17,12 @@ package plan
 import (
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/render"
 	"golang.org/x/net/context"
 )
@@ -34,7 +37,10 @@ func Plan(ctx context.Context, in *graph.Graph) (*graph.Graph, error) {
 
 // WithNotify is plan, but with a notification feature
 func WithNotify(ctx context.Context, in *graph.Graph, notify *graph.Notifier) (*graph.Graph, error) {
-	var hasErrors error
+	var (
+		hasErrors  error
+		errorsLock sync.Mutex
+	)
 
 	out, err := in.Transform(ctx,
 		notify.Transform(func(meta *node.Node, out *graph.Graph) error {
@@ -56,8 +62,11 @@ func WithNotify(ctx context.Context, in *graph.Graph, notify *graph.Notifier) (*
 				return fmt.Errorf("expected asResult but got %T", val)
 			}
 
-			if nil != asResult.Error() {
+			if nil != asResult.Error() && !load.IgnoreErrors(meta) {
+				errorsLock.Lock()
 				hasErrors = ErrTreeContainsErrors
+				errorsLock.Unlock()
+				executor.GetFailFast(ctx).Fail(meta.ID)
 			}
 
 			out.Add(meta.WithValue(asResult))
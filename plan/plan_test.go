@@ -17,10 +17,12 @@ package plan_test
 import (
 	"testing"
 
+	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	"github.com/asteris-llc/converge/helpers/faketask"
 	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,6 +94,78 @@ func TestPlanErrorsBelow(t *testing.T) {
 	assert.EqualError(t, rootNode.Error(), `error in dependency "root/err"`)
 }
 
+// TestPlanIgnoreErrors tests that dependents of a node that ignores errors are
+// still planned, and that its error does not fail the plan
+func TestPlanIgnoreErrors(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", faketask.NoOp()))
+	errNode := node.New("root/err", faketask.Error())
+	require.NoError(t, errNode.AddMetadata(load.MetaIgnoreErrors, true))
+	g.Add(errNode)
+
+	g.Connect("root", "root/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := plan.Plan(context.Background(), g)
+	assert.NoError(t, err)
+
+	assert.Error(t, getResult(t, out, "root/err").Error())
+	assert.NoError(t, getResult(t, out, "root").Error())
+}
+
+// TestPlanIgnoreErrorsInherited tests that a node that ignores errors but was
+// not planned because its own dependency failed still blocks its dependents
+func TestPlanIgnoreErrorsInherited(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", faketask.NoOp()))
+	ignoring := node.New("root/ignoring", faketask.NoOp())
+	require.NoError(t, ignoring.AddMetadata(load.MetaIgnoreErrors, true))
+	g.Add(ignoring)
+	g.Add(node.New("root/ignoring/err", faketask.Error()))
+
+	g.Connect("root", "root/ignoring")
+	g.Connect("root/ignoring", "root/ignoring/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := plan.Plan(context.Background(), g)
+	assert.Equal(t, plan.ErrTreeContainsErrors, err)
+
+	assert.Error(t, getResult(t, out, "root/ignoring/err").Error())
+	assert.EqualError(t, getResult(t, out, "root/ignoring").Error(), `error in dependency "root/ignoring/err"`)
+	assert.EqualError(t, getResult(t, out, "root").Error(), `error in dependency "root/ignoring"`)
+}
+
+// TestPlanFailFast tests that no nodes are planned after the first failure
+// when failing fast
+func TestPlanFailFast(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	g := graph.New()
+	g.Add(node.New("root", faketask.NoOp()))
+	g.Add(node.New("root/a", faketask.NoOp()))
+	g.Add(node.New("root/a/err", faketask.Error()))
+
+	g.Connect("root", "root/a")
+	g.Connect("root/a", "root/a/err")
+
+	require.NoError(t, g.Validate())
+
+	out, err := plan.Plan(executor.WithFailFast(context.Background()), g)
+	assert.Equal(t, plan.ErrTreeContainsErrors, err)
+
+	assert.EqualError(t, getResult(t, out, "root/a/err").Error(), "error")
+	assert.False(t, getResult(t, out, "root/a/err").Skipped())
+	assert.EqualError(t, getResult(t, out, "root/a").Error(), `not run: failing fast after error in "root/a/err"`)
+	assert.True(t, getResult(t, out, "root/a").Skipped())
+	assert.EqualError(t, getResult(t, out, "root").Error(), `not run: failing fast after error in "root/a/err"`)
+}
+
 func getResult(t *testing.T, src *graph.Graph, key string) *plan.Result {
 	meta, ok := src.Get(key)
 	require.True(t, ok, "%q was not present in the graph", key)
@@ -14,7 +14,10 @@
 
 package plan
 
-import "github.com/asteris-llc/converge/resource"
+import (
+	"github.com/asteris-llc/converge/executor"
+	"github.com/asteris-llc/converge/resource"
+)
 
 // Result is the result of planning execution
 type Result struct {
@@ -35,6 +38,13 @@ func (r *Result) HasChanges() bool { return r.Status.HasChanges() }
 // Error returns the error assigned to this Result, if any
 func (r *Result) Error() error { return r.Err }
 
+// Skipped indicates if this result was not planned because another node failed
+// while failing fast
+func (r *Result) Skipped() bool {
+	_, ok := r.Err.(*executor.FailFastError)
+	return ok
+}
+
 // Warning returns the warning assigned to this Result, if any
 func (r *Result) Warning() string { return r.Status.Warning() }
 
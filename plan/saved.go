@@ -0,0 +1,309 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/load"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/secret"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// SavedFormat identifies serialized plans
+const SavedFormat = "converge-plan"
+
+// SavedVersion is the version of the serialized plan format
+const SavedVersion = 1
+
+// ErrNotSaved is returned by ReadSaved when the content is not a saved plan
+var ErrNotSaved = errors.New("not a saved plan")
+
+// Saved is a plan serialized so that it can be reviewed and applied later
+type Saved struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+
+	// the request that generated the plan. Parameters include the values read
+	// from params files.
+	Location      string            `json:"location"`
+	Parameters    map[string]string `json:"parameters,omitempty"`
+	VerifyModules bool              `json:"verifyModules,omitempty"`
+	Targets       []string          `json:"targets,omitempty"`
+	Excludes      []string          `json:"excludes,omitempty"`
+
+	// Checksums of the module content, keyed by the node they were loaded into
+	Checksums map[string]string `json:"checksums"`
+
+	Edges []*graph.Edge         `json:"edges"`
+	Nodes map[string]*SavedNode `json:"nodes"`
+}
+
+// SavedNode is the planned state of a single node
+type SavedNode struct {
+	HasChanges bool                  `json:"hasChanges,omitempty"`
+	Fields     map[string]string     `json:"fields,omitempty"`
+	Diffs      map[string]*SavedDiff `json:"diffs,omitempty"`
+}
+
+// SavedDiff is a serialized resource.Diff
+type SavedDiff struct {
+	Original string `json:"original"`
+	Current  string `json:"current"`
+	Changes  bool   `json:"changes"`
+}
+
+// NewSaved creates an empty saved plan for the given location
+func NewSaved(location string, params map[string]string, verify bool) *Saved {
+	return &Saved{
+		Format:        SavedFormat,
+		Version:       SavedVersion,
+		Location:      location,
+		Parameters:    params,
+		VerifyModules: verify,
+		Checksums:     map[string]string{},
+		Nodes:         map[string]*SavedNode{},
+	}
+}
+
+// ReadSaved reads a saved plan. It returns ErrNotSaved if the content is not a
+// saved plan.
+func ReadSaved(r io.Reader) (*Saved, error) {
+	saved := new(Saved)
+	if err := json.NewDecoder(r).Decode(saved); err != nil || saved.Format != SavedFormat {
+		return nil, ErrNotSaved
+	}
+
+	if saved.Version != SavedVersion {
+		return nil, fmt.Errorf("unsupported plan version %d, expected %d", saved.Version, SavedVersion)
+	}
+
+	return saved, nil
+}
+
+// Write the saved plan
+func (s *Saved) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// AddNode records the planned state of a node
+func (s *Saved) AddNode(id string, hasChanges bool, diffs map[string]resource.Diff, fields map[string]string) {
+	saved := &SavedNode{
+		HasChanges: hasChanges,
+		Fields:     fields,
+		Diffs:      map[string]*SavedDiff{},
+	}
+
+	for name, diff := range diffs {
+		saved.Diffs[name] = &SavedDiff{
+			Original: diff.Original(),
+			Current:  diff.Current(),
+			Changes:  diff.Changes(),
+		}
+	}
+
+	s.Nodes[id] = saved
+}
+
+// VerifyChecksums makes sure that the modules that were loaded have the same
+// content as the modules that were planned
+func (s *Saved) VerifyChecksums(checksums map[string]string) error {
+	var err error
+	for _, id := range sortedKeys(s.Checksums) {
+		current, ok := checksums[id]
+		if !ok {
+			err = multierror.Append(err, fmt.Errorf("%s: module is no longer loaded", id))
+			continue
+		}
+
+		if current != s.Checksums[id] {
+			err = multierror.Append(err, fmt.Errorf("%s: module has changed since it was planned", id))
+		}
+	}
+
+	for _, id := range sortedKeys(checksums) {
+		if _, ok := s.Checksums[id]; !ok {
+			err = multierror.Append(err, fmt.Errorf("%s: module was not planned", id))
+		}
+	}
+
+	return err
+}
+
+// Verify makes sure that a freshly planned graph has the same differences as
+// the saved plan. An error is returned for every node that differs.
+func (s *Saved) Verify(g *graph.Graph) error {
+	var err error
+
+	ids := g.Vertices()
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		meta, _ := g.Get(id)
+		result, ok := meta.Value().(*Result)
+		if !ok {
+			continue
+		}
+
+		saved, ok := s.Nodes[meta.ID]
+		if !ok {
+			err = multierror.Append(err, fmt.Errorf("%s: node was not planned", meta.ID))
+			continue
+		}
+
+		if result.Error() != nil && !load.IgnoreErrors(meta) {
+			err = multierror.Append(err, errors.Wrap(result.Error(), meta.ID))
+			continue
+		}
+
+		if result.HasChanges() != saved.HasChanges {
+			err = multierror.Append(err, fmt.Errorf("%s: planned to have changes: %t, now: %t", meta.ID, saved.HasChanges, result.HasChanges()))
+			continue
+		}
+
+		if mismatched := saved.mismatchedDiffs(result.Changes()); len(mismatched) > 0 {
+			err = multierror.Append(err, fmt.Errorf("%s: differences have changed since planning: %s", meta.ID, strings.Join(mismatched, ", ")))
+			continue
+		}
+
+		if result.GetStatus() == nil {
+			continue
+		}
+
+		if mismatched := saved.mismatchedFields(result.GetStatus().ExportedFields()); len(mismatched) > 0 {
+			err = multierror.Append(err, fmt.Errorf("%s: fields have changed since planning: %s", meta.ID, strings.Join(mismatched, ", ")))
+		}
+	}
+
+	var planned []string
+	for id := range s.Nodes {
+		planned = append(planned, id)
+	}
+	sort.Strings(planned)
+
+	for _, id := range planned {
+		if !g.Contains(id) {
+			err = multierror.Append(err, fmt.Errorf("%s: planned node is no longer present", id))
+		}
+	}
+
+	return err
+}
+
+// mismatchedDiffs returns the names of the diffs that have changes in either
+// the saved or current diffs and are not identical in both
+func (n *SavedNode) mismatchedDiffs(current map[string]resource.Diff) (out []string) {
+	names := map[string]struct{}{}
+	for name, diff := range n.Diffs {
+		if diff.Changes {
+			names[name] = struct{}{}
+		}
+	}
+	for name, diff := range current {
+		if diff.Changes() {
+			names[name] = struct{}{}
+		}
+	}
+
+	for name := range names {
+		saved, savedOK := n.Diffs[name]
+		diff, currentOK := current[name]
+		if !savedOK || !currentOK {
+			out = append(out, name)
+			continue
+		}
+
+		if saved.Original != diff.Original() || saved.Current != diff.Current() || saved.Changes != diff.Changes() {
+			out = append(out, name)
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+// mismatchedFields returns the names of the recorded fields whose values are
+// not the same as the current ones. Fields are recorded as they are sent to the
+// client: formatted and with secrets masked. Fields holding structs or pointers
+// are state read from the system (unit properties, command results) rather
+// than rendered values, so they are not compared.
+func (n *SavedNode) mismatchedFields(current resource.FieldMap) (out []string) {
+	for name, saved := range n.Fields {
+		value, ok := current[name]
+		if !ok {
+			out = append(out, name)
+			continue
+		}
+
+		if !comparableField(reflect.ValueOf(value)) {
+			continue
+		}
+
+		if saved != secret.Redact(fmt.Sprintf("%v", value)) {
+			out = append(out, name)
+		}
+	}
+
+	for name := range current {
+		if _, ok := n.Fields[name]; !ok {
+			out = append(out, name)
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+// comparableField returns whether a field value formats the same way every time
+// it is rendered with the same inputs
+func comparableField(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Array:
+		return comparableKind(value.Type().Elem().Kind())
+	case reflect.Map:
+		return comparableKind(value.Type().Key().Kind()) && comparableKind(value.Type().Elem().Kind())
+	default:
+		return comparableKind(value.Kind())
+	}
+}
+
+func comparableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Struct, reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
+
+func sortedKeys(in map[string]string) (out []string) {
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,182 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/helpers/faketask"
+	"github.com/asteris-llc/converge/plan"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSavedRoundTrip tests writing and reading a saved plan
+func TestSavedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	saved := plan.NewSaved("test.hcl", map[string]string{"a": "b"}, true)
+	saved.Checksums["root"] = "abc"
+	saved.AddNode(
+		"root/task",
+		true,
+		map[string]resource.Diff{"x": resource.TextDiff{Values: [2]string{"1", "2"}}},
+		map[string]string{"field": "value"},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, saved.Write(&buf))
+
+	read, err := plan.ReadSaved(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, saved, read)
+}
+
+// TestReadSavedNotSaved tests that other content is rejected
+func TestReadSavedNotSaved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hcl", func(t *testing.T) {
+		_, err := plan.ReadSaved(strings.NewReader(`task "x" { check = "true" }`))
+		assert.Equal(t, plan.ErrNotSaved, err)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		_, err := plan.ReadSaved(strings.NewReader(`{"a": 1}`))
+		assert.Equal(t, plan.ErrNotSaved, err)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		_, err := plan.ReadSaved(strings.NewReader(`{"format": "converge-plan", "version": 1000}`))
+		assert.EqualError(t, err, "unsupported plan version 1000, expected 1")
+	})
+}
+
+// TestSavedVerifyChecksums tests comparing module checksums
+func TestSavedVerifyChecksums(t *testing.T) {
+	t.Parallel()
+
+	saved := plan.NewSaved("test.hcl", nil, false)
+	saved.Checksums = map[string]string{"root": "a", "root/module.x": "b"}
+
+	t.Run("same", func(t *testing.T) {
+		assert.NoError(t, saved.VerifyChecksums(map[string]string{"root": "a", "root/module.x": "b"}))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		err := saved.VerifyChecksums(map[string]string{"root": "a", "root/module.x": "c"})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/module.x: module has changed since it was planned")
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		err := saved.VerifyChecksums(map[string]string{"root": "a"})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/module.x: module is no longer loaded")
+		}
+	})
+
+	t.Run("extra", func(t *testing.T) {
+		err := saved.VerifyChecksums(map[string]string{"root": "a", "root/module.x": "b", "root/module.y": "c"})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/module.y: module was not planned")
+		}
+	})
+}
+
+// TestSavedVerify tests comparing a planned graph to a saved plan
+func TestSavedVerify(t *testing.T) {
+	t.Parallel()
+
+	diffs := map[string]resource.Diff{"x": resource.TextDiff{Values: [2]string{"1", "2"}}}
+
+	plannedWithFields := func(diffs map[string]resource.Diff, field string) *graph.Graph {
+		status := &resource.Status{Differences: diffs}
+		require.NoError(t, status.UpdateExportedFields(&faketask.FakeTask{Status: field}))
+
+		g := graph.New()
+		g.Add(node.New("root", &plan.Result{Status: &resource.Status{}}))
+		g.Add(node.New("root/task", &plan.Result{Status: status}))
+		g.ConnectParent("root", "root/task")
+		return g
+	}
+
+	planned := func(diffs map[string]resource.Diff) *graph.Graph {
+		return plannedWithFields(diffs, "ok")
+	}
+
+	saved := plan.NewSaved("test.hcl", nil, false)
+	saved.AddNode("root", false, nil, nil)
+	saved.AddNode("root/task", true, diffs, map[string]string{"status": "ok"})
+
+	t.Run("same", func(t *testing.T) {
+		assert.NoError(t, saved.Verify(planned(diffs)))
+	})
+
+	t.Run("different fields", func(t *testing.T) {
+		err := saved.Verify(plannedWithFields(diffs, "changed"))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task: fields have changed since planning: status")
+		}
+	})
+
+	t.Run("different values", func(t *testing.T) {
+		err := saved.Verify(planned(map[string]resource.Diff{"x": resource.TextDiff{Values: [2]string{"1", "3"}}}))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task: differences have changed since planning: x")
+		}
+	})
+
+	t.Run("different names", func(t *testing.T) {
+		err := saved.Verify(planned(map[string]resource.Diff{"y": resource.TextDiff{Values: [2]string{"1", "2"}}}))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task: differences have changed since planning: x, y")
+		}
+	})
+
+	t.Run("no longer changes", func(t *testing.T) {
+		err := saved.Verify(planned(nil))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task: planned to have changes: true, now: false")
+		}
+	})
+
+	t.Run("unplanned node", func(t *testing.T) {
+		g := planned(diffs)
+		g.Add(node.New("root/other", &plan.Result{Status: &resource.Status{}}))
+		g.ConnectParent("root", "root/other")
+
+		err := saved.Verify(g)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/other: node was not planned")
+		}
+	})
+
+	t.Run("missing node", func(t *testing.T) {
+		g := graph.New()
+		g.Add(node.New("root", &plan.Result{Status: &resource.Status{}}))
+
+		err := saved.Verify(g)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "root/task: planned node is no longer present")
+		}
+	})
+}
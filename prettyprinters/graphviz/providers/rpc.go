@@ -56,7 +56,8 @@ func (p RPCProvider) VertexGetID(e graphviz.GraphEntity) (pp.VisibleRenderable,
 // specific generated labels sare:
 //    Templates: Return 'Template' and the file destination
 //    Modules: Return 'Module' and the module name
-//    Params: Return 'name -> "value"'
+//    Params: Return 'name -> "value"' and the description, if any
+//    Outputs: Return 'Output' and the output name
 //    otherwise: Return 'name'
 func (p RPCProvider) VertexGetLabel(e graphviz.GraphEntity) (pp.VisibleRenderable, error) {
 	var name string
@@ -73,7 +74,7 @@ func (p RPCProvider) VertexGetLabel(e graphviz.GraphEntity) (pp.VisibleRenderabl
 		return pp.VisibleString(name), nil
 	}
 
-	switch val.Kind {
+	switch vertexKind(e.Name, val) {
 	case "file.content":
 		var dest = new(content.Content)
 		if err := json.Unmarshal(val.Details, dest); err != nil {
@@ -99,10 +100,15 @@ func (p RPCProvider) VertexGetLabel(e graphviz.GraphEntity) (pp.VisibleRenderabl
 			return nil, errors.Wrap(err, "could not unmarshal param")
 		}
 
-		return pp.RenderableString(
-			fmt.Sprintf("%s: %s", name, dest.Val),
-			p.ShowParams,
-		), nil
+		label := fmt.Sprintf("%s: %v", name, dest.Val)
+		if dest.Description != "" {
+			label += "\n" + dest.Description
+		}
+
+		return pp.RenderableString(label, p.ShowParams), nil
+
+	case "output":
+		return pp.VisibleString(fmt.Sprintf("Output: %s", strings.TrimPrefix(name, "output."))), nil
 
 	case "docker.image":
 		return dockerImageLabel(val)
@@ -124,8 +130,9 @@ func (p RPCProvider) VertexGetLabel(e graphviz.GraphEntity) (pp.VisibleRenderabl
 }
 
 // VertexGetProperties sets graphviz attributes based on the type of the
-// resource. Specifically, we set the shape to 'component' for Shell preparers
-// and 'tab' for templates, and we set the entire root node to be invisible.
+// resource. Specifically, we set the shape to 'component' for Shell preparers,
+// 'tab' for templates, and 'cds' for module outputs, and we set the entire root
+// node to be invisible.
 func (p RPCProvider) VertexGetProperties(e graphviz.GraphEntity) graphviz.PropertySet {
 	properties := make(map[string]string)
 
@@ -134,7 +141,7 @@ func (p RPCProvider) VertexGetProperties(e graphviz.GraphEntity) graphviz.Proper
 		return properties
 	}
 
-	switch val.Kind {
+	switch vertexKind(e.Name, val) {
 	case "task":
 		properties["shape"] = "component"
 
@@ -144,6 +151,9 @@ func (p RPCProvider) VertexGetProperties(e graphviz.GraphEntity) graphviz.Proper
 	case "module":
 		properties["shape"] = "folder"
 
+	case "output":
+		properties["shape"] = "cds"
+
 	case "docker.image", "docker.container":
 		properties["shape"] = "box3d"
 	}
@@ -151,6 +161,17 @@ func (p RPCProvider) VertexGetProperties(e graphviz.GraphEntity) graphviz.Proper
 	return properties
 }
 
+// vertexKind returns the kind of a vertex. Outputs usually refer to other
+// nodes, so they are often not rendered yet when graphing and their kind is
+// unknown. They are identified by their ID instead.
+func vertexKind(id string, val *pb.GraphComponent_Vertex) string {
+	parts := strings.Split(id, "/")
+	if strings.HasPrefix(parts[len(parts)-1], "output.") {
+		return "output"
+	}
+	return val.Kind
+}
+
 // EdgeGetProperties sets attributes for graph edges, specifically making edges
 // originating from the Root node invisible.
 func (p RPCProvider) EdgeGetProperties(src graphviz.GraphEntity, dst graphviz.GraphEntity) graphviz.PropertySet {
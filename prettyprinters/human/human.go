@@ -24,7 +24,9 @@ import (
 
 	"github.com/asteris-llc/converge/graph"
 	pp "github.com/asteris-llc/converge/prettyprinters"
+	"github.com/asteris-llc/converge/secret"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 // Printer for human-readable output
@@ -78,11 +80,19 @@ func (p *Printer) FinishPP(g *graph.Graph) (pp.Renderable, error) {
 {{range .DependencyErrors}} * {{.}}
 {{end}}
 {{end}}
+{{- if .IgnoredErrors}}Ignored errors:
+{{range .IgnoredErrors}} * {{.}}
+{{end}}
+{{end}}
 {{- if gt (len .Errors) 0}}{{red "Summary"}}
 {{- else}}{{green "Summary"}}
 {{- end}}: {{len .Errors}} errors, {{.ChangesCount}} changes
 {{- if .DependencyErrors}}, {{len .DependencyErrors}} dependency errors
 {{- end}}
+{{- if .IgnoredErrors}}, {{len .IgnoredErrors}} ignored errors
+{{- end}}
+{{- if .SkippedCount}}, {{.SkippedCount}} not run due to --fail-fast
+{{- end}}
 `)
 	if err != nil {
 		return pp.HiddenString(), err
@@ -90,8 +100,10 @@ func (p *Printer) FinishPP(g *graph.Graph) (pp.Renderable, error) {
 
 	counts := struct {
 		ChangesCount     int
+		SkippedCount     int
 		Errors           []error
 		DependencyErrors []error
+		IgnoredErrors    []error
 	}{}
 
 	for _, id := range g.Vertices() {
@@ -107,7 +119,14 @@ func (p *Printer) FinishPP(g *graph.Graph) (pp.Renderable, error) {
 
 		if err = printable.Error(); err != nil {
 			if id != "root" {
-				if strings.Contains(err.Error(), "error in dependency") {
+				if ignoresErrors(printable) {
+					counts.IgnoredErrors = append(
+						counts.IgnoredErrors,
+						errors.Wrap(err, id),
+					)
+				} else if skipped(printable) {
+					counts.SkippedCount++
+				} else if strings.Contains(err.Error(), "error in dependency") {
 					counts.DependencyErrors = append(
 						counts.DependencyErrors,
 						errors.Wrap(err, id),
@@ -127,7 +146,7 @@ func (p *Printer) FinishPP(g *graph.Graph) (pp.Renderable, error) {
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, counts)
 
-	return &buf, err
+	return bytes.NewBufferString(secret.Redact(buf.String())), err
 }
 
 // DrawNode containing a result
@@ -148,7 +167,7 @@ func (p *Printer) DrawNode(g *graph.Graph, id string) (pp.Renderable, error) {
 
 	tmpl, err := p.template(`{{if .Error}}{{red .ID}}{{else if .HasChanges}}{{yellow .ID}}{{else}}{{.ID}}{{end}}:
 	{{- if .Error}}
-	{{red "Error"}}: {{.Error}}
+	{{red "Error"}}: {{.Error}}{{if .ErrorIgnored}} (ignored){{end}}
 	{{- end}}
 	{{- if .Warning}}
 	{{yellow "Warning"}}: {{.Warning}}
@@ -158,6 +177,9 @@ func (p *Printer) DrawNode(g *graph.Graph, id string) (pp.Renderable, error) {
 	{{indent $msg}}
 	{{- end}}
 	Has Changes: {{if .HasChanges}}{{yellow "yes"}}{{else}}no{{end}}
+	{{- if .Handler}}
+	Triggered: {{if .Triggers}}{{yellow "yes"}} (by {{.Triggers}}){{else}}no{{end}}
+	{{- end}}
 	Changes:
 		{{- range $key, $values := .Changes}}
 		{{cyan $key}}:	{{diff ($values.Original) ($values.Current)}}
@@ -175,7 +197,7 @@ func (p *Printer) DrawNode(g *graph.Graph, id string) (pp.Renderable, error) {
 	}
 
 	tabWriter := tabwriter.NewWriter(&out, 1, 1, 1, ' ', 0)
-	_, err = tabWriter.Write(intermediate.Bytes())
+	_, err = tabWriter.Write([]byte(secret.Redact(intermediate.String())))
 
 	return &out, err
 }
@@ -211,26 +233,54 @@ func (p *Printer) styled(style func(string) string) func(string) string {
 }
 
 func (p *Printer) diff(before, after string) (string, error) {
+	// mask sensitive values before quoting changes how they look
+	before, after = secret.Redact(before), secret.Redact(after)
+
 	// remember when modifying these that diff is responsible for leading
-	// whitespace
+	// whitespace. Single lines are shown side by side, and anything longer as a
+	// unified diff.
 	if !strings.Contains(strings.TrimSpace(before), "\n") && !strings.Contains(strings.TrimSpace(after), "\n") {
 		return p.getFunc("bold")(
 			fmt.Sprintf("%q\t=>\t%q", strings.TrimSpace(before), strings.TrimSpace(after)),
 		), nil
 	}
 
-	tmpl, err := p.template(`before:
-{{.Before}}
-after:
-{{.After}}`)
+	unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
 	if err != nil {
 		return "", err
 	}
 
-	buf := new(bytes.Buffer)
-	err = tmpl.Execute(buf, struct{ Before, After string }{before, after})
+	lines := strings.Split(strings.TrimSuffix(unified, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case i < 2: // the "---" and "+++" headers
+			lines[i] = p.getFunc("bold")(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = p.getFunc("red")(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = p.getFunc("green")(line)
+		}
+	}
+
+	return "\n" + p.indent(p.indent(strings.Join(lines, "\n"))), nil
+}
 
-	return "\n" + p.indent(p.indent(buf.String())), err
+// splitLines splits a value into lines for a unified diff, each ending in a
+// newline. Unlike difflib.SplitLines, a trailing newline does not add an empty
+// line.
+func splitLines(in string) []string {
+	lines := strings.SplitAfter(in, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
 }
 
 func (p *Printer) indent(in string) string {
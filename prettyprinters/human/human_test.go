@@ -118,9 +118,34 @@ func TestFinishPPError(t *testing.T) {
 			)
 		})
 	})
+
+	t.Run("not run due to fail-fast", func(t *testing.T) {
+		g := graph.New()
+		g.Add(node.New("root/test", Printable{"error": "test"}))
+		g.Add(node.New("root/subtest", skippedPrintable{Printable{"error": "not run: failing fast after error in root/test"}}))
+
+		printer := human.New()
+		printer.InitColors()
+		str, err := printer.FinishPP(g)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Errors:\n * root/test: test\n\nSummary: 1 errors, 0 changes, 1 not run due to --fail-fast\n", str.String())
+	})
+
+	t.Run("ignored errors", func(t *testing.T) {
+		g := graph.New()
+		g.Add(node.New("root/task", ignoredPrintable{Printable{"error": "test"}}))
+
+		printer := human.New()
+		printer.InitColors()
+		str, err := printer.FinishPP(g)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Ignored errors:\n * root/task: test\n\nSummary: 0 errors, 0 changes, 1 ignored errors\n", str.String())
+	})
 }
 
-func testDrawNodes(t *testing.T, in Printable, out string) {
+func testDrawNodes(t *testing.T, in human.Printable, out string) {
 	printer := human.New()
 	printer.InitColors()
 	testDrawNodesCustomPrinter(
@@ -132,7 +157,7 @@ func testDrawNodes(t *testing.T, in Printable, out string) {
 	)
 }
 
-func testDrawNodesCustomPrinter(t *testing.T, h *human.Printer, id string, in Printable, out string) {
+func testDrawNodesCustomPrinter(t *testing.T, h *human.Printer, id string, in human.Printable, out string) {
 	g := graph.New()
 	g.Add(node.New(id, in))
 
@@ -231,6 +256,18 @@ func TestDrawNodeChanges(t *testing.T) {
 	)
 }
 
+// TestDrawNodeMultilineChanges tests that changes over several lines are shown
+// as a unified diff
+func TestDrawNodeMultilineChanges(t *testing.T) {
+	t.Parallel()
+
+	testDrawNodes(
+		t,
+		Printable{"a": "b\nc\n"},
+		"root:\n Messages:\n Has Changes: yes\n Changes:\n  a: \n  --- before\n  +++ after\n  @@ -0,0 +1,2 @@\n  +b\n  +c\n\n",
+	)
+}
+
 func BenchmarkDrawNodeChanges(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		benchmarkDrawNodes(
@@ -260,6 +297,38 @@ func TestDrawNodeWarning(t *testing.T) {
 	)
 }
 
+// TestDrawNodeHandler tests that handlers show whether they were triggered
+func TestDrawNodeHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("triggered", func(t *testing.T) {
+		testDrawNodes(
+			t,
+			handlerPrintable{Printable{"a": "b"}, []string{"root/x", "root/y"}},
+			"root:\n Messages:\n Has Changes: yes\n Triggered: yes (by root/x, root/y)\n Changes:\n  a: \"\" => \"b\"\n\n",
+		)
+	})
+
+	t.Run("not triggered", func(t *testing.T) {
+		testDrawNodes(
+			t,
+			handlerPrintable{Printable{}, []string{}},
+			"root:\n Messages:\n Has Changes: no\n Triggered: no\n Changes: No changes\n\n",
+		)
+	})
+}
+
+// TestDrawNodeIgnoredError tests that ignored errors are marked as such
+func TestDrawNodeIgnoredError(t *testing.T) {
+	t.Parallel()
+
+	testDrawNodes(
+		t,
+		ignoredPrintable{Printable{"error": "x"}},
+		"root:\n Error: x (ignored)\n Messages:\n Has Changes: yes\n Changes:\n  error: \"\" => \"x\"\n\n",
+	)
+}
+
 func BenchmarkDrawNodeError(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		benchmarkDrawNodes(
@@ -306,3 +375,28 @@ func (p Printable) Error() error {
 func (p Printable) Warning() string {
 	return p["warning"]
 }
+
+// handler printable stub
+
+type handlerPrintable struct {
+	Printable
+	triggers []string
+}
+
+func (h handlerPrintable) IsHandler() bool { return true }
+
+func (h handlerPrintable) TriggeredBy() []string { return h.triggers }
+
+type ignoredPrintable struct {
+	Printable
+}
+
+func (i ignoredPrintable) IgnoresErrors() bool { return true }
+
+// skipped printable stub
+
+type skippedPrintable struct {
+	Printable
+}
+
+func (s skippedPrintable) Skipped() bool { return true }
@@ -14,7 +14,11 @@
 
 package human
 
-import "github.com/asteris-llc/converge/resource"
+import (
+	"strings"
+
+	"github.com/asteris-llc/converge/resource"
+)
 
 type printerNode struct {
 	ID string
@@ -22,6 +26,28 @@ type printerNode struct {
 	Printable
 }
 
+// Handler returns true if the node is a handler
+func (p *printerNode) Handler() bool {
+	if handler, ok := p.Printable.(Handler); ok {
+		return handler.IsHandler()
+	}
+	return false
+}
+
+// Triggers returns the nodes that triggered the handler, separated by commas
+func (p *printerNode) Triggers() string {
+	if handler, ok := p.Printable.(Handler); ok {
+		return strings.Join(handler.TriggeredBy(), ", ")
+	}
+	return ""
+}
+
+// ErrorIgnored returns true if the node has an error that does not stop its
+// dependents
+func (p *printerNode) ErrorIgnored() bool {
+	return p.Error() != nil && ignoresErrors(p.Printable)
+}
+
 // Printable defines the methods needed to print with this printer
 type Printable interface {
 	Changes() map[string]resource.Diff
@@ -30,3 +56,36 @@ type Printable interface {
 	Error() error
 	Warning() string
 }
+
+// Handler is optionally implemented by printables for nodes that are only
+// applied when notified by another node
+type Handler interface {
+	IsHandler() bool
+	TriggeredBy() []string
+}
+
+// ErrorIgnorer is optionally implemented by printables for nodes whose errors
+// do not stop their dependents or fail the run
+type ErrorIgnorer interface {
+	IgnoresErrors() bool
+}
+
+// Skipper is implemented by printables that know whether they were not run
+// because an earlier node failed while failing fast
+type Skipper interface {
+	Skipped() bool
+}
+
+func skipped(p Printable) bool {
+	if skipper, ok := p.(Skipper); ok {
+		return skipper.Skipped()
+	}
+	return false
+}
+
+func ignoresErrors(p Printable) bool {
+	if ignorer, ok := p.(ErrorIgnorer); ok {
+		return ignorer.IgnoresErrors()
+	}
+	return false
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	pp "github.com/asteris-llc/converge/prettyprinters"
+	"github.com/asteris-llc/converge/secret"
 )
 
 // Node is the serializable type for graph nodes
@@ -40,7 +41,7 @@ type Edge struct {
 // Printer prints a graph in JSONL format
 type Printer struct{}
 
-// DrawNode prints a node in JSONL format
+// DrawNode prints a node in JSONL format. Sensitive values are masked.
 func (j *Printer) DrawNode(graph *graph.Graph, nodeID string) (pp.Renderable, error) {
 	meta, ok := graph.Get(nodeID)
 	if !ok {
@@ -53,7 +54,7 @@ func (j *Printer) DrawNode(graph *graph.Graph, nodeID string) (pp.Renderable, er
 		Meta:  meta,
 		Value: meta.Value(),
 	})
-	return pp.VisibleString(string(out) + "\n"), err
+	return pp.VisibleString(secret.Redact(string(out)) + "\n"), err
 }
 
 // DrawEdge returns an edge in JSONL format
@@ -19,7 +19,12 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/fgrid/uuid"
 )
 
 // DefaultEnv provides a default implementation for the env function in text
@@ -81,3 +86,146 @@ func DefaultJsonify(val interface{}) (string, error) {
 
 	return string(out), nil
 }
+
+// DefaultReplace replaces all instances of old with new in a string. The string
+// is the last argument so that it can be piped, as in
+// `{{param "x" | replace "-" "_"}}`.
+func DefaultReplace(old, new, str string) string {
+	return strings.Replace(str, old, new, -1)
+}
+
+// DefaultRegexMatch returns true if the string (second argument) matches the
+// regular expression (first argument)
+func DefaultRegexMatch(pattern, str string) (bool, error) {
+	return regexp.MatchString(pattern, str)
+}
+
+// DefaultRegexReplace replaces all matches of the regular expression (first
+// argument) in the string (last argument) with the replacement (second
+// argument). The replacement can refer to submatches with `$1`.
+func DefaultRegexReplace(pattern, replacement, str string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return re.ReplaceAllString(str, replacement), nil
+}
+
+// DefaultDefault returns the value (second argument) unless it is empty, in
+// which case it returns the default (first argument). It is meant to be used
+// in a pipeline, as in `{{param "x" | default "y"}}`.
+func DefaultDefault(def, val interface{}) interface{} {
+	if isEmpty(val) {
+		return def
+	}
+	return val
+}
+
+// DefaultCoalesce returns the first of its arguments that is not empty, or an
+// empty string if they all are
+func DefaultCoalesce(vals ...interface{}) interface{} {
+	for _, val := range vals {
+		if !isEmpty(val) {
+			return val
+		}
+	}
+	return ""
+}
+
+// DefaultIndent indents every line of a string (second argument) by a number of
+// spaces (first argument). This is mostly useful for embedding values in YAML.
+func DefaultIndent(spaces interface{}, str string) (string, error) {
+	n, err := toInt(spaces)
+	if err != nil {
+		return "", err
+	}
+
+	pad := strings.Repeat(" ", n)
+	return pad + strings.Replace(str, "\n", "\n"+pad, -1), nil
+}
+
+// DefaultKeys returns the keys of a map, sorted
+func DefaultKeys(m interface{}) ([]string, error) {
+	val := reflect.ValueOf(m)
+	if val.Kind() != reflect.Map {
+		return nil, fmt.Errorf("keys requires a map, got %T", m)
+	}
+
+	out := make([]string, 0, val.Len())
+	for _, key := range val.MapKeys() {
+		out = append(out, fmt.Sprintf("%v", key.Interface()))
+	}
+	sort.Strings(out)
+
+	return out, nil
+}
+
+// DefaultHasKey returns true if the map (second argument) has the key (first
+// argument)
+func DefaultHasKey(key string, m interface{}) (bool, error) {
+	keys, err := DefaultKeys(m)
+	if err != nil {
+		return false, err
+	}
+
+	for _, k := range keys {
+		if k == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// uuidNamespace is the namespace of the UUIDs that uuid derives from names
+var uuidNamespace = uuid.NewNamespaceUUID("converge")
+
+// DefaultUUID returns a new random UUID. A random UUID is different every time
+// the module is rendered, so a resource that uses one always has changes and a
+// saved plan containing one can't be applied. Given a name, it returns a UUID
+// derived from the name instead, which is the same on every render.
+func DefaultUUID(name ...string) (string, error) {
+	switch len(name) {
+	case 0:
+		return uuid.NewV4().String(), nil
+	case 1:
+		return uuid.NewV5(uuidNamespace, []byte(name[0])).String(), nil
+	default:
+		return "", fmt.Errorf("uuid takes at most one name, got %d", len(name))
+	}
+}
+
+// isEmpty tests whether a value is nil or the zero value of its type. Empty
+// lists and maps are also empty.
+func isEmpty(val interface{}) bool {
+	if val == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return reflect.DeepEqual(val, reflect.Zero(v.Type()).Interface())
+}
+
+// toInt converts template arguments to ints. Numbers in templates are ints, but
+// values from params are usually strings.
+func toInt(val interface{}) (int, error) {
+	switch v := val.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	case string:
+		return strconv.Atoi(strings.TrimSpace(v))
+	}
+	return 0, fmt.Errorf("%v is not an integer", val)
+}
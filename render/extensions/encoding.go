@@ -0,0 +1,98 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultBase64Encode encodes a string as standard base64
+func DefaultBase64Encode(str string) string {
+	return base64.StdEncoding.EncodeToString([]byte(str))
+}
+
+// DefaultBase64Decode decodes a standard base64 string
+func DefaultBase64Decode(str string) (string, error) {
+	out, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// DefaultSHA256 returns the hex-encoded SHA256 sum of a string
+func DefaultSHA256(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+// DefaultMD5 returns the hex-encoded MD5 sum of a string
+func DefaultMD5(str string) string {
+	sum := md5.Sum([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+// DefaultFromJSON parses a JSON string into a value that can be used in the
+// rest of the template, for example with `index` or `range`
+func DefaultFromJSON(str string) (interface{}, error) {
+	var out interface{}
+	if err := json.Unmarshal([]byte(str), &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// DefaultFromYAML parses a YAML string into a value that can be used in the
+// rest of the template. Maps have string keys, as they do with fromJSON.
+func DefaultFromYAML(str string) (interface{}, error) {
+	var out interface{}
+	if err := yaml.Unmarshal([]byte(str), &out); err != nil {
+		return nil, err
+	}
+
+	return stringKeys(out), nil
+}
+
+// stringKeys converts the map[interface{}]interface{} values that the YAML
+// parser produces to map[string]interface{}, recursively
+func stringKeys(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[fmt.Sprintf("%v", key)] = stringKeys(value)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = stringKeys(value)
+		}
+		return out
+
+	default:
+		return val
+	}
+}
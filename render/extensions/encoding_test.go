@@ -0,0 +1,60 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/render/extensions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultBase64_RoundTrips(t *testing.T) {
+	encoded := extensions.DefaultBase64Encode("hello")
+	assert.Equal(t, "aGVsbG8=", encoded)
+
+	decoded, err := extensions.DefaultBase64Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", decoded)
+
+	_, err = extensions.DefaultBase64Decode("not base64!")
+	assert.Error(t, err)
+}
+
+func Test_DefaultHashes(t *testing.T) {
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", extensions.DefaultSHA256("hello"))
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592", extensions.DefaultMD5("hello"))
+}
+
+func Test_DefaultFromYAML_StringKeys(t *testing.T) {
+	val, err := extensions.DefaultFromYAML("a:\n  1: [x, {k: v}]\n")
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			"a": map[string]interface{}{
+				"1": []interface{}{"x", map[string]interface{}{"k": "v"}},
+			},
+		},
+		val,
+	)
+}
+
+func Test_DefaultFromJSON_Invalid(t *testing.T) {
+	_, err := extensions.DefaultFromJSON("{")
+	assert.Error(t, err)
+}
@@ -17,6 +17,7 @@ package extensions
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"sync"
 	"text/template"
 
@@ -30,6 +31,11 @@ import (
 // avoid bikeshedding
 const RefFuncName string = "lookup"
 
+// OutputFuncName is the name of the function to reference the outputs of a
+// module call. `{{output "name" "value"}}` is the same as
+// `{{lookup "module.name.output.value"}}`.
+const OutputFuncName string = "output"
+
 // languageKeywords defines the known keywords that have been added to the
 // templating language.  This is stored as a map for quick lookup and is used
 // for DSL validation.
@@ -39,8 +45,45 @@ var languageKeywords = map[string]struct{}{
 	"join":      {},
 	RefFuncName: {},
 	"platform":  {},
+	"fact":      {},
 	"jsonify":   {},
 
+	// functions for working with strings
+	"upper":        {},
+	"lower":        {},
+	"trim":         {},
+	"replace":      {},
+	"regexMatch":   {},
+	"regexReplace": {},
+	"indent":       {},
+
+	// functions for working with values
+	"default":  {},
+	"coalesce": {},
+	"keys":     {},
+	"hasKey":   {},
+	"uuid":     {},
+
+	// functions for encoding and hashing
+	"base64encode": {},
+	"base64decode": {},
+	"sha256":       {},
+	"md5":          {},
+	"toJSON":       {},
+	"fromJSON":     {},
+	"fromYAML":     {},
+
+	// functions for working with networks
+	"cidrhost":   {},
+	"cidrsubnet": {},
+
+	// functions for working with secrets
+	"secret": {},
+
+	// functions for working with modules
+	OutputFuncName: {},
+	"local":        {},
+
 	// functions for working with parameters
 	"param":     {},
 	"paramList": {},
@@ -72,22 +115,62 @@ func MakeLanguage() *LanguageExtension {
 
 // MinimalLanguage provides a language extension where all known extensions are
 // associated with NOP functions- as with MakeLanguage()- except that arity,
-// input, and output types are respected and pure transformations are
-// implemented.  It is less featureful than DefaultLanguage but will not
-// introduce template errors that may be present when using an unmodified
-// MakeLanguage.
+// input, and output types are respected, so that calls can be chained.  It is
+// less featureful than DefaultLanguage but will not introduce template errors
+// that may be present when using an unmodified MakeLanguage.
 func MinimalLanguage() *LanguageExtension {
 	language := MakeLanguage()
 	language.On("platform", newStub(&platform.Platform{}))
+	language.On("fact", newStub(""))
 	language.On(RefFuncName, newStub(""))
+	language.On(OutputFuncName, newStub(""))
+	language.On("local", newStub(""))
 
 	// params
 	language.On("param", newStub(""))
 	language.On("paramList", newStub([]interface{}{}))
 	language.On("paramMap", newStub(map[string]interface{}{}))
+
+	// library functions
+	for keyword, returnVal := range libraryStubs() {
+		language.On(keyword, newLibraryStub(returnVal))
+	}
 	return language
 }
 
+// libraryStubs are the values returned by the library functions in
+// MinimalLanguage. The real functions may fail on the empty values that other
+// stubs return, so they are stubbed too.
+func libraryStubs() map[string]interface{} {
+	return map[string]interface{}{
+		"split":        []string{},
+		"join":         "",
+		"jsonify":      "",
+		"upper":        "",
+		"lower":        "",
+		"trim":         "",
+		"replace":      "",
+		"regexMatch":   false,
+		"regexReplace": "",
+		"indent":       "",
+		"default":      "",
+		"coalesce":     "",
+		"keys":         []string{},
+		"hasKey":       false,
+		"uuid":         "",
+		"base64encode": "",
+		"base64decode": "",
+		"sha256":       "",
+		"md5":          "",
+		"toJSON":       "",
+		"fromJSON":     map[string]interface{}{},
+		"fromYAML":     map[string]interface{}{},
+		"cidrhost":     "",
+		"cidrsubnet":   "",
+		"secret":       "",
+	}
+}
+
 // DefaultLanguage provides a default language extension.  It creates default
 // implementations of context-free and non-dependency-generating functions
 // (e.g. split) and provides a unimplemented function for functions that must be
@@ -98,8 +181,44 @@ func DefaultLanguage() *LanguageExtension {
 	language.On("split", DefaultSplit)
 	language.On("join", DefaultJoin)
 	language.On("jsonify", DefaultJsonify)
+
+	// strings
+	language.On("upper", strings.ToUpper)
+	language.On("lower", strings.ToLower)
+	language.On("trim", strings.TrimSpace)
+	language.On("replace", DefaultReplace)
+	language.On("regexMatch", DefaultRegexMatch)
+	language.On("regexReplace", DefaultRegexReplace)
+	language.On("indent", DefaultIndent)
+
+	// values
+	language.On("default", DefaultDefault)
+	language.On("coalesce", DefaultCoalesce)
+	language.On("keys", DefaultKeys)
+	language.On("hasKey", DefaultHasKey)
+	language.On("uuid", DefaultUUID)
+
+	// encoding and hashing
+	language.On("base64encode", DefaultBase64Encode)
+	language.On("base64decode", DefaultBase64Decode)
+	language.On("sha256", DefaultSHA256)
+	language.On("md5", DefaultMD5)
+	language.On("toJSON", DefaultJsonify)
+	language.On("fromJSON", DefaultFromJSON)
+	language.On("fromYAML", DefaultFromYAML)
+
+	// networks
+	language.On("cidrhost", DefaultCIDRHost)
+	language.On("cidrsubnet", DefaultCIDRSubnet)
+
+	// secrets
+	language.On("secret", DefaultSecret)
+
 	language.On("platform", platform.DefaultPlatform)
+	language.On("fact", Unimplemented("fact"))
 	language.On(RefFuncName, Unimplemented(RefFuncName))
+	language.On(OutputFuncName, Unimplemented(OutputFuncName))
+	language.On("local", Unimplemented("local"))
 
 	// params
 	language.On("param", Unimplemented("param"))
@@ -113,7 +232,8 @@ func DefaultLanguage() *LanguageExtension {
 // encountering a keyword.  It inserts the key and value pair into the language
 // and returns a reference to the language.  The language is mutated and the
 // returned version is simply to allow method chaning, e.g.:
-//   language = MakeLanguage().On("foo", foo).On("bar", bar).On("baz", baz)
+//
+//	language = MakeLanguage().On("foo", foo).On("bar", bar).On("baz", baz)
 func (l *LanguageExtension) On(keyword string, action interface{}) *LanguageExtension {
 	l.innerLock.Lock()
 	defer l.innerLock.Unlock()
@@ -199,6 +319,15 @@ func newStub(returnVal interface{}) func(...string) (interface{}, error) {
 	}
 }
 
+// newLibraryStub generates a stub for a library function. Unlike newStub, it
+// accepts arguments of any type, since library functions are often given the
+// values returned by other functions.
+func newLibraryStub(returnVal interface{}) func(...interface{}) (interface{}, error) {
+	return func(...interface{}) (interface{}, error) {
+		return returnVal, nil
+	}
+}
+
 // RememberCalls is a utility function to instert calls into a list.
 // RememberCalls takes a pointer to a list of strings, and a default. It returns
 // a variadic function that when called from gotemplate will take the indexed
@@ -211,6 +340,11 @@ func RememberCalls(list *[]string, returnvalue interface{}) interface{} {
 	}
 }
 
+// OutputRef returns the lookup reference for an output of a module call
+func OutputRef(module, name string) string {
+	return "module." + module + ".output." + name
+}
+
 // Unimplemented returns a function that will raise an error with the fact that
 // the keyword is unimplemented.
 func Unimplemented(name string) interface{} {
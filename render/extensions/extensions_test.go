@@ -30,11 +30,48 @@ import (
 var keywords = map[string]struct{}{
 	"env":      {},
 	"platform": {},
+	"fact":     {},
 	"split":    {},
 	"join":     {},
 	"jsonify":  {},
 	"lookup":   {},
 
+	// strings
+	"upper":        {},
+	"lower":        {},
+	"trim":         {},
+	"replace":      {},
+	"regexMatch":   {},
+	"regexReplace": {},
+	"indent":       {},
+
+	// values
+	"default":  {},
+	"coalesce": {},
+	"keys":     {},
+	"hasKey":   {},
+	"uuid":     {},
+
+	// encoding and hashing
+	"base64encode": {},
+	"base64decode": {},
+	"sha256":       {},
+	"md5":          {},
+	"toJSON":       {},
+	"fromJSON":     {},
+	"fromYAML":     {},
+
+	// networks
+	"cidrhost":   {},
+	"cidrsubnet": {},
+
+	// secrets
+	"secret": {},
+
+	// modules
+	"output": {},
+	"local":  {},
+
 	// parameters
 	"param":     {},
 	"paramList": {},
@@ -122,6 +159,71 @@ func Test_DefaultSplit_SplitsBasedOnFirstArg(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(expected, actual))
 }
 
+func Test_DefaultLanguage_Library(t *testing.T) {
+	l := extensions.DefaultLanguage()
+
+	examples := map[string]string{
+		"{{upper `abc`}}":                                         "ABC",
+		"{{lower `ABC`}}":                                         "abc",
+		"{{trim `  abc \n`}}":                                     "abc",
+		"{{`a-b-c` | replace `-` `_`}}":                           "a_b_c",
+		"{{`v1.2.3` | regexMatch `^v[0-9.]+$`}}":                  "true",
+		"{{`v1.2.3` | regexReplace `^v([0-9]+).*$` `$1`}}":        "1",
+		"{{`` | default `x`}}":                                    "x",
+		"{{`y` | default `x`}}":                                   "y",
+		"{{coalesce `` `` `z`}}":                                  "z",
+		"{{`a\nb` | indent 2}}":                                   "  a\n  b",
+		"{{`{\"b\": 1, \"a\": 2}` | fromJSON | keys | join `,`}}": "a,b",
+		"{{`{\"a\": 1}` | fromJSON | hasKey `a`}}":                "true",
+		"{{(`a: {b: c}` | fromYAML).a.b}}":                        "c",
+		"{{`a: [1, 2]` | fromYAML | toJSON}}":                     `{"a":[1,2]}`,
+	}
+
+	for tmpl, expected := range examples {
+		actual, err := renderTemplate(l, tmpl)
+		assert.NoError(t, err, tmpl)
+		assert.Equal(t, expected, actual, tmpl)
+	}
+
+	id, err := renderTemplate(l, "{{uuid}}")
+	assert.NoError(t, err)
+	assert.Len(t, id, 36)
+
+	named, err := renderTemplate(l, "{{uuid `app`}}")
+	assert.NoError(t, err)
+	assert.Len(t, named, 36)
+
+	again, err := renderTemplate(l, "{{uuid `app`}}")
+	assert.NoError(t, err)
+	assert.Equal(t, named, again)
+
+	other, err := renderTemplate(l, "{{uuid `other`}}")
+	assert.NoError(t, err)
+	assert.NotEqual(t, named, other)
+
+	_, err = renderTemplate(l, "{{uuid `a` `b`}}")
+	assert.Error(t, err)
+}
+
+func Test_MinimalLanguage_StubsLibrary(t *testing.T) {
+	l := extensions.MinimalLanguage()
+
+	examples := []string{
+		"{{param `x` | fromJSON | keys | join `,`}}",
+		"{{paramMap `x` | hasKey `a`}}",
+		"{{param `x` | base64decode | indent 2}}",
+		"{{cidrhost 1 (param `x`)}}",
+		"{{(param `x` | fromYAML).a}}",
+		"{{coalesce (param `x`) 1 (paramList `y`)}}",
+		"{{param `x` | secret}}",
+	}
+
+	for _, tmpl := range examples {
+		_, err := renderTemplate(l, tmpl)
+		assert.NoError(t, err, tmpl)
+	}
+}
+
 // strip the values out of a map so we can use reflect.DeepEqual for comparison
 func takeKeys(m template.FuncMap) map[string]struct{} {
 	out := make(map[string]struct{})
@@ -0,0 +1,178 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package facts gathers information about the system that modules can use in
+// templates
+package facts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Facts describes the system converge is running on
+type Facts struct {
+	Hostname       string                  `json:"hostname"`
+	FQDN           string                  `json:"fqdn"`
+	CPU            CPU                     `json:"cpu"`
+	Memory         Memory                  `json:"memory"`
+	Net            map[string]*Interface   `json:"net"`
+	Block          map[string]*BlockDevice `json:"block"`
+	Mounts         map[string]*Mount       `json:"mounts"`
+	Init           string                  `json:"init"`
+	Virtualization Virtualization          `json:"virtualization"`
+	User           User                    `json:"user"`
+}
+
+// CPU describes the processors of the system
+type CPU struct {
+	Count int    `json:"count"`
+	Model string `json:"model"`
+}
+
+// Memory describes the memory of the system, in bytes
+type Memory struct {
+	Total     uint64 `json:"total"`
+	Available uint64 `json:"available"`
+	Swap      uint64 `json:"swap"`
+}
+
+// Interface describes a network interface. IPv4 and IPv6 are the first
+// address of each family, and Addresses has every address in CIDR notation.
+type Interface struct {
+	MAC       string   `json:"mac"`
+	MTU       int      `json:"mtu"`
+	Up        bool     `json:"up"`
+	IPv4      string   `json:"ipv4"`
+	IPv6      string   `json:"ipv6"`
+	Addresses []string `json:"addresses"`
+}
+
+// BlockDevice describes a block device. Size is in bytes.
+type BlockDevice struct {
+	Size       uint64 `json:"size"`
+	Model      string `json:"model"`
+	Removable  bool   `json:"removable"`
+	Rotational bool   `json:"rotational"`
+}
+
+// Mount describes a mounted filesystem
+type Mount struct {
+	Device  string   `json:"device"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+// Virtualization describes the virtualization or containerization the system
+// is running under. Type is "none" on physical hardware.
+type Virtualization struct {
+	Type string `json:"type"`
+	Role string `json:"role"`
+}
+
+// User describes the user converge is running as
+type User struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	UID      string `json:"uid"`
+	GID      string `json:"gid"`
+	Home     string `json:"home"`
+}
+
+// Get returns the fact at a dotted path, like "net.eth0.ipv4". Keys that
+// contain dots themselves, like the names of VLAN interfaces or mount points,
+// are matched as well.
+func (f *Facts) Get(path string) (interface{}, error) {
+	tree, err := f.tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var current interface{} = tree
+	terms := strings.Split(path, ".")
+	for len(terms) > 0 {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unknown fact %q", path)
+		}
+
+		found := false
+		for i := len(terms); i > 0; i-- {
+			if val, ok := m[strings.Join(terms[:i], ".")]; ok {
+				current, terms, found = val, terms[i:], true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown fact %q, expected one of %s", path, strings.Join(sortedKeys(m), ", "))
+		}
+	}
+
+	return current, nil
+}
+
+// tree returns the facts as nested maps, as they are shown in JSON
+func (f *Facts) tree() (map[string]interface{}, error) {
+	content, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	var out map[string]interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Cache gathers facts the first time they are needed and keeps them. A cache
+// is meant to last for a single run, so that facts are fresh for every run but
+// gathered only once.
+type Cache struct {
+	gatherer *Gatherer
+
+	once  sync.Once
+	facts *Facts
+	err   error
+}
+
+// NewCache returns a cache that gathers facts from the running system
+func NewCache() *Cache {
+	return &Cache{gatherer: NewGatherer()}
+}
+
+// Get the facts, gathering them if this is the first call
+func (c *Cache) Get() (*Facts, error) {
+	c.once.Do(func() {
+		c.facts, c.err = c.gatherer.Gather()
+	})
+	return c.facts, c.err
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
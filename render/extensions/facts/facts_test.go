@@ -0,0 +1,76 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/asteris-llc/converge/render/extensions/facts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactsGet(t *testing.T) {
+	t.Parallel()
+
+	f := &facts.Facts{
+		Hostname: "web01",
+		CPU:      facts.CPU{Count: 4},
+		Net: map[string]*facts.Interface{
+			"eth0":     {IPv4: "10.0.0.5", Addresses: []string{"10.0.0.5/24"}},
+			"eth0.100": {IPv4: "10.0.100.5"},
+		},
+	}
+
+	t.Run("value", func(t *testing.T) {
+		val, err := f.Get("hostname")
+		assert.NoError(t, err)
+		assert.Equal(t, "web01", val)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		val, err := f.Get("cpu.count")
+		assert.NoError(t, err)
+		assert.Equal(t, json.Number("4"), val)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		val, err := f.Get("net.eth0.ipv4")
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.5", val)
+	})
+
+	t.Run("dotted key", func(t *testing.T) {
+		val, err := f.Get("net.eth0.100.ipv4")
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.100.5", val)
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		val, err := f.Get("net.eth0.addresses")
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"10.0.0.5/24"}, val)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := f.Get("net.eth1.ipv4")
+		assert.EqualError(t, err, `unknown fact "net.eth1.ipv4", expected one of eth0, eth0.100`)
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		_, err := f.Get("hostname.short")
+		assert.EqualError(t, err, `unknown fact "hostname.short"`)
+	})
+}
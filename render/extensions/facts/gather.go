@@ -0,0 +1,355 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facts
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Gatherer collects facts from /proc, /sys, and /etc under Root. Files that
+// don't exist (for example on systems other than Linux) leave the facts they
+// would provide empty.
+type Gatherer struct {
+	Root string
+}
+
+// NewGatherer returns a Gatherer for the running system
+func NewGatherer() *Gatherer {
+	return &Gatherer{Root: "/"}
+}
+
+// Gather all the facts
+func (g *Gatherer) Gather() (*Facts, error) {
+	f := new(Facts)
+
+	f.Hostname = g.hostname()
+	f.FQDN = g.fqdn(f.Hostname)
+	f.CPU = g.cpu()
+	f.Memory = g.memory()
+
+	var err error
+	if f.Net, err = g.net(); err != nil {
+		return nil, err
+	}
+
+	f.Block = g.block()
+	f.Mounts = g.mounts()
+	f.Init = g.init()
+	f.Virtualization = g.virtualization()
+	f.User = g.user()
+
+	return f, nil
+}
+
+func (g *Gatherer) path(parts ...string) string {
+	return filepath.Join(append([]string{g.Root}, parts...)...)
+}
+
+func (g *Gatherer) read(parts ...string) string {
+	content, err := ioutil.ReadFile(g.path(parts...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func (g *Gatherer) exists(parts ...string) bool {
+	_, err := os.Stat(g.path(parts...))
+	return err == nil
+}
+
+// lines calls fn with the fields of every line of a file
+func (g *Gatherer) lines(fn func(fields []string), parts ...string) {
+	file, err := os.Open(g.path(parts...))
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			fn(fields)
+		}
+	}
+}
+
+func (g *Gatherer) hostname() string {
+	if name := g.read("proc", "sys", "kernel", "hostname"); name != "" {
+		return name
+	}
+
+	name, _ := os.Hostname()
+	return name
+}
+
+// fqdn finds the fully qualified name of the host in /etc/hosts, the way
+// `hostname -f` does without asking DNS. If there is none, the hostname is
+// returned as-is.
+func (g *Gatherer) fqdn(hostname string) string {
+	if strings.Contains(hostname, ".") {
+		return hostname
+	}
+
+	fqdn := hostname
+	found := false
+	g.lines(func(fields []string) {
+		if found || strings.HasPrefix(fields[0], "#") || len(fields) < 2 {
+			return
+		}
+
+		names := fields[1:]
+		for _, name := range names {
+			if name == hostname || strings.HasPrefix(name, hostname+".") {
+				for _, candidate := range names {
+					if strings.HasPrefix(candidate, hostname+".") {
+						fqdn, found = candidate, true
+						return
+					}
+				}
+			}
+		}
+	}, "etc", "hosts")
+
+	return fqdn
+}
+
+func (g *Gatherer) cpu() CPU {
+	var out CPU
+	g.lines(func(fields []string) {
+		line := strings.Join(fields, " ")
+		switch {
+		case strings.HasPrefix(line, "processor :"):
+			out.Count++
+		case out.Model == "" && strings.HasPrefix(line, "model name :"):
+			out.Model = strings.TrimPrefix(line, "model name : ")
+		}
+	}, "proc", "cpuinfo")
+
+	if out.Count == 0 {
+		out.Count = runtime.NumCPU()
+	}
+	return out
+}
+
+func (g *Gatherer) memory() Memory {
+	var out Memory
+	g.lines(func(fields []string) {
+		if len(fields) < 2 {
+			return
+		}
+
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return
+		}
+
+		switch fields[0] {
+		case "MemTotal:":
+			out.Total = kb * 1024
+		case "MemAvailable:":
+			out.Available = kb * 1024
+		case "SwapTotal:":
+			out.Swap = kb * 1024
+		}
+	}, "proc", "meminfo")
+	return out
+}
+
+func (g *Gatherer) net() (map[string]*Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]*Interface{}
+	for _, iface := range ifaces {
+		info := &Interface{
+			MAC:       iface.HardwareAddr.String(),
+			MTU:       iface.MTU,
+			Up:        iface.Flags&net.FlagUp != 0,
+			Addresses: []string{},
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+
+			info.Addresses = append(info.Addresses, ipnet.String())
+			if ipnet.IP.To4() != nil {
+				if info.IPv4 == "" {
+					info.IPv4 = ipnet.IP.String()
+				}
+			} else if info.IPv6 == "" {
+				info.IPv6 = ipnet.IP.String()
+			}
+		}
+
+		out[iface.Name] = info
+	}
+
+	return out, nil
+}
+
+func (g *Gatherer) block() map[string]*BlockDevice {
+	out := map[string]*BlockDevice{}
+
+	devices, _ := ioutil.ReadDir(g.path("sys", "block"))
+	for _, device := range devices {
+		name := device.Name()
+
+		// sizes in /sys are always in 512-byte sectors
+		sectors, _ := strconv.ParseUint(g.read("sys", "block", name, "size"), 10, 64)
+
+		out[name] = &BlockDevice{
+			Size:       sectors * 512,
+			Model:      g.read("sys", "block", name, "device", "model"),
+			Removable:  g.read("sys", "block", name, "removable") == "1",
+			Rotational: g.read("sys", "block", name, "queue", "rotational") == "1",
+		}
+	}
+
+	return out
+}
+
+func (g *Gatherer) mounts() map[string]*Mount {
+	out := map[string]*Mount{}
+	g.lines(func(fields []string) {
+		if len(fields) < 4 {
+			return
+		}
+
+		out[unescapeMount(fields[1])] = &Mount{
+			Device:  unescapeMount(fields[0]),
+			Type:    fields[2],
+			Options: strings.Split(fields[3], ","),
+		}
+	}, "proc", "mounts")
+	return out
+}
+
+// unescapeMount replaces the octal escapes the kernel uses for spaces and other
+// special characters in /proc/mounts
+func unescapeMount(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var out []byte
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if code, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				out = append(out, byte(code))
+				i += 3
+				continue
+			}
+		}
+		out = append(out, field[i])
+	}
+	return string(out)
+}
+
+func (g *Gatherer) init() string {
+	if g.exists("run", "systemd", "system") {
+		return "systemd"
+	}
+
+	if g.exists("sbin", "initctl") && g.exists("etc", "init") {
+		return "upstart"
+	}
+
+	if comm := g.read("proc", "1", "comm"); comm != "" {
+		return comm
+	}
+	return "unknown"
+}
+
+func (g *Gatherer) virtualization() Virtualization {
+	guest := func(typ string) Virtualization {
+		return Virtualization{Type: typ, Role: "guest"}
+	}
+
+	// containers
+	switch {
+	case g.exists(".dockerenv"):
+		return guest("docker")
+	case g.exists("run", ".containerenv"):
+		return guest("podman")
+	}
+
+	cgroup := g.read("proc", "1", "cgroup")
+	switch {
+	case strings.Contains(cgroup, "kubepods"):
+		return guest("kubernetes")
+	case strings.Contains(cgroup, "/docker"):
+		return guest("docker")
+	case strings.Contains(cgroup, "/lxc"):
+		return guest("lxc")
+	}
+
+	// virtual machines
+	dmi := g.read("sys", "class", "dmi", "id", "sys_vendor") + " " + g.read("sys", "class", "dmi", "id", "product_name")
+	for _, vm := range []struct{ marker, typ string }{
+		{"QEMU", "qemu"},
+		{"KVM", "kvm"},
+		{"VMware", "vmware"},
+		{"VirtualBox", "virtualbox"},
+		{"innotek", "virtualbox"},
+		{"Xen", "xen"},
+		{"Amazon EC2", "kvm"},
+		{"Google Compute Engine", "kvm"},
+		{"Microsoft Corporation Virtual Machine", "hyperv"},
+	} {
+		if strings.Contains(dmi, vm.marker) {
+			return guest(vm.typ)
+		}
+	}
+
+	if strings.Contains(g.read("proc", "cpuinfo"), " hypervisor") {
+		return guest("unknown")
+	}
+
+	return Virtualization{Type: "none", Role: "host"}
+}
+
+func (g *Gatherer) user() User {
+	current, err := user.Current()
+	if err != nil {
+		return User{}
+	}
+
+	return User{
+		Username: current.Username,
+		Name:     current.Name,
+		UID:      current.Uid,
+		GID:      current.Gid,
+		Home:     current.HomeDir,
+	}
+}
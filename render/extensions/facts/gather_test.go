@@ -0,0 +1,89 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facts_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/render/extensions/facts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatherer(t *testing.T) {
+	t.Parallel()
+
+	g := &facts.Gatherer{Root: "testdata"}
+	f, err := g.Gather()
+	require.NoError(t, err)
+
+	t.Run("host", func(t *testing.T) {
+		assert.Equal(t, "web01", f.Hostname)
+		assert.Equal(t, "web01.example.com", f.FQDN)
+		assert.Equal(t, "systemd", f.Init)
+	})
+
+	t.Run("cpu", func(t *testing.T) {
+		assert.Equal(t, facts.CPU{Count: 2, Model: "Test CPU @ 2.00GHz"}, f.CPU)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		assert.Equal(t, facts.Memory{Total: 2048 * 1024, Available: 1024 * 1024, Swap: 256 * 1024}, f.Memory)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		require.Contains(t, f.Block, "sda")
+		assert.Equal(
+			t,
+			&facts.BlockDevice{Size: 209715200 * 512, Model: "QEMU HARDDISK", Rotational: true},
+			f.Block["sda"],
+		)
+	})
+
+	t.Run("mounts", func(t *testing.T) {
+		assert.Equal(
+			t,
+			map[string]*facts.Mount{
+				"/":            {Device: "/dev/sda1", Type: "ext4", Options: []string{"rw", "relatime"}},
+				"/mnt/my data": {Device: "/dev/sda2", Type: "xfs", Options: []string{"ro"}},
+			},
+			f.Mounts,
+		)
+	})
+
+	t.Run("virtualization", func(t *testing.T) {
+		assert.Equal(t, facts.Virtualization{Type: "qemu", Role: "guest"}, f.Virtualization)
+	})
+
+	t.Run("net", func(t *testing.T) {
+		for name, iface := range f.Net {
+			assert.NotNil(t, iface.Addresses, name)
+		}
+	})
+}
+
+func TestGathererMissing(t *testing.T) {
+	t.Parallel()
+
+	g := &facts.Gatherer{Root: "testdata/nonexistent"}
+	f, err := g.Gather()
+	require.NoError(t, err)
+
+	assert.Equal(t, "unknown", f.Init)
+	assert.Equal(t, facts.Virtualization{Type: "none", Role: "host"}, f.Virtualization)
+	assert.Empty(t, f.Block)
+	assert.Empty(t, f.Mounts)
+	assert.True(t, f.CPU.Count > 0)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// DefaultCIDRHost returns the address of the given host number (first
+// argument) in a CIDR prefix (second argument). Negative host numbers count
+// back from the end of the prefix, so -1 is the broadcast address of an IPv4
+// network.
+func DefaultCIDRHost(hostnum interface{}, prefix string) (string, error) {
+	num, err := toInt(hostnum)
+	if err != nil {
+		return "", err
+	}
+
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+
+	offset := big.NewInt(int64(num))
+	if num < 0 {
+		offset.Add(offset, size)
+	}
+	if offset.Sign() < 0 || offset.Cmp(size) >= 0 {
+		return "", fmt.Errorf("prefix %s has no host number %d", prefix, num)
+	}
+
+	return addToIP(network.IP, offset).String(), nil
+}
+
+// DefaultCIDRSubnet returns a subnet of a CIDR prefix (last argument). The
+// subnet's prefix is extended by newbits (first argument), and netnum (second
+// argument) selects which of the resulting subnets to return.
+func DefaultCIDRSubnet(newbits, netnum interface{}, prefix string) (string, error) {
+	extend, err := toInt(newbits)
+	if err != nil {
+		return "", err
+	}
+
+	num, err := toInt(netnum)
+	if err != nil {
+		return "", err
+	}
+
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	ones, bits := network.Mask.Size()
+	if extend < 0 || ones+extend > bits {
+		return "", fmt.Errorf("cannot extend prefix %s by %d bits", prefix, extend)
+	}
+
+	if num < 0 || big.NewInt(int64(num)).Cmp(new(big.Int).Lsh(big.NewInt(1), uint(extend))) >= 0 {
+		return "", fmt.Errorf("prefix %s extended by %d bits has no network number %d", prefix, extend, num)
+	}
+
+	offset := new(big.Int).Lsh(big.NewInt(int64(num)), uint(bits-ones-extend))
+	subnet := &net.IPNet{
+		IP:   addToIP(network.IP, offset),
+		Mask: net.CIDRMask(ones+extend, bits),
+	}
+
+	return subnet.String(), nil
+}
+
+// addToIP adds an offset to an IP address
+func addToIP(ip net.IP, offset *big.Int) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	sum := new(big.Int).Add(new(big.Int).SetBytes(ip), offset)
+
+	out := make(net.IP, len(ip))
+	raw := sum.Bytes()
+	copy(out[len(out)-len(raw):], raw)
+	return out
+}
@@ -0,0 +1,69 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/render/extensions"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DefaultCIDRHost(t *testing.T) {
+	cases := []struct {
+		hostnum  interface{}
+		prefix   string
+		expected string
+	}{
+		{1, "10.0.0.0/24", "10.0.0.1"},
+		{"5", "10.0.1.0/24", "10.0.1.5"},
+		{-1, "10.0.0.0/24", "10.0.0.255"},
+		{300, "10.0.0.0/16", "10.0.1.44"},
+		{16, "fd00::/64", "fd00::10"},
+	}
+
+	for _, c := range cases {
+		actual, err := extensions.DefaultCIDRHost(c.hostnum, c.prefix)
+		assert.NoError(t, err, c.prefix)
+		assert.Equal(t, c.expected, actual, c.prefix)
+	}
+
+	_, err := extensions.DefaultCIDRHost(256, "10.0.0.0/24")
+	assert.EqualError(t, err, "prefix 10.0.0.0/24 has no host number 256")
+}
+
+func Test_DefaultCIDRSubnet(t *testing.T) {
+	cases := []struct {
+		newbits, netnum interface{}
+		prefix          string
+		expected        string
+	}{
+		{8, 2, "10.0.0.0/16", "10.0.2.0/24"},
+		{4, 15, "10.0.0.0/8", "10.240.0.0/12"},
+		{16, 1, "fd00::/48", "fd00:0:0:1::/64"},
+	}
+
+	for _, c := range cases {
+		actual, err := extensions.DefaultCIDRSubnet(c.newbits, c.netnum, c.prefix)
+		assert.NoError(t, err, c.prefix)
+		assert.Equal(t, c.expected, actual, c.prefix)
+	}
+
+	_, err := extensions.DefaultCIDRSubnet(2, 4, "10.0.0.0/16")
+	assert.EqualError(t, err, "prefix 10.0.0.0/16 extended by 2 bits has no network number 4")
+
+	_, err = extensions.DefaultCIDRSubnet(20, 0, "10.0.0.0/16")
+	assert.EqualError(t, err, "cannot extend prefix 10.0.0.0/16 by 20 bits")
+}
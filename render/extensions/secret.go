@@ -0,0 +1,27 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"github.com/asteris-llc/converge/keystore"
+	"github.com/asteris-llc/converge/secret"
+)
+
+// DefaultSecret decrypts a value that was encrypted with `converge secret
+// encrypt` using the secret keys in the default keystore. The decrypted value
+// is masked as <sensitive> wherever it is shown.
+func DefaultSecret(ciphertext string) (string, error) {
+	return secret.Decrypt(keystore.Default(), ciphertext)
+}
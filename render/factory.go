@@ -20,7 +20,9 @@ import (
 	"strings"
 
 	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/render/extensions"
+	"github.com/asteris-llc/converge/render/extensions/facts"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/resource/module"
 	"golang.org/x/net/context"
@@ -31,6 +33,7 @@ type Factory struct {
 	Graph     *graph.Graph
 	DotValues map[string]*LazyValue
 	Language  *extensions.LanguageExtension
+	Facts     *facts.Cache
 }
 
 // ValueThunk lazily evaluates a param
@@ -50,7 +53,7 @@ func (v *LazyValue) Value() (resource.Value, bool, error) {
 		if result[2] != nil {
 			resultErr = result[2].(error)
 		}
-		return result[0].(string), result[1].(bool), resultErr
+		return result[0], result[1].(bool), resultErr
 	case ValueThunk:
 		val, found, err := result()
 		v.val = [3]interface{}{val, found, err}
@@ -62,7 +65,7 @@ func (v *LazyValue) Value() (resource.Value, bool, error) {
 
 // GetRenderer returns a Factory for the specific graph node
 func (f *Factory) GetRenderer(id string) (*Renderer, error) {
-	r := &Renderer{Language: f.Language, Graph: func() *graph.Graph { return f.Graph }, ID: id}
+	r := &Renderer{Language: f.Language, Facts: f.Facts, Graph: func() *graph.Graph { return f.Graph }, ID: id}
 	if dotVal, found := f.DotValues[id]; found {
 		if valResult, valFound, err := dotVal.Value(); err != nil {
 			return nil, err
@@ -80,9 +83,14 @@ func NewFactory(ctx context.Context, g *graph.Graph) (*Factory, error) {
 		Graph:     g,
 		Language:  extensions.DefaultLanguage(),
 		DotValues: make(map[string]*LazyValue),
+		Facts:     facts.NewCache(),
 	}
 
 	for _, vertex := range g.Vertices() {
+		if dotVal, found := getForeachElement(g, vertex); found {
+			f.DotValues[vertex] = &LazyValue{dotVal}
+			continue
+		}
 		if dotVal, found := getParamOverrides(func() *graph.Graph { return f.Graph }, vertex); found {
 			f.DotValues[vertex] = &LazyValue{dotVal}
 		}
@@ -90,6 +98,22 @@ func NewFactory(ctx context.Context, g *graph.Graph) (*Factory, error) {
 	return f, nil
 }
 
+// getForeachElement makes the element a node was generated from available as
+// "." in its templates
+func getForeachElement(g *graph.Graph, id string) (ValueThunk, bool) {
+	meta, ok := g.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	elem, ok := load.ForeachElement(meta)
+	if !ok {
+		return nil, false
+	}
+
+	return func() (resource.Value, bool, error) { return elem, true, nil }, true
+}
+
 func getParamOverrides(gFunc func() *graph.Graph, id string) (ValueThunk, bool) {
 	name := graph.BaseID(id)
 	f := func() (resource.Value, bool, error) { return resource.Value(""), false, nil }
@@ -69,6 +69,37 @@ func VertexSplitTraverse(g *graph.Graph, toFind string, startingNode string, sto
 	return VertexSplitTraverse(g, toFind, parentID, stop, history)
 }
 
+// OutputSplit redirects a lookup on a module call to one of its outputs. A
+// lookup of "module.x.output.name" first resolves to the module call with the
+// terms "output.name"; OutputSplit turns that into the output node inside the
+// module, and the terms into the exported value of the output. Lookups of an
+// output in the same module also resolve to its value. If the lookup is not for
+// an output, the vertex and terms are returned unchanged.
+func OutputSplit(g *graph.Graph, vertex, terms string) (string, string) {
+	if IsOutput(vertex) && terms == "" {
+		return vertex, "value"
+	}
+
+	if !strings.HasPrefix(terms, "output.") {
+		return vertex, terms
+	}
+
+	output, rest, found := VertexSplit(g, graph.ID(vertex, terms))
+	if !found || graph.ParentID(output) != vertex || !IsOutput(output) {
+		return vertex, terms
+	}
+
+	if rest == "" {
+		rest = "value"
+	}
+	return output, rest
+}
+
+// IsOutput returns true if the ID refers to an output node
+func IsOutput(id string) bool {
+	return strings.HasPrefix(graph.BaseID(id), "output.")
+}
+
 // TraverseUntilModule is a function intended to be used with
 // VertexSplitTraverse and will cause vertex splitting to propogate upwards
 // until it encounters a module
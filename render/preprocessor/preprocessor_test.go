@@ -66,3 +66,45 @@ func TestVertexSplit(t *testing.T) {
 		assert.False(t, found)
 	})
 }
+
+// TestOutputSplit ensures that lookups on module calls are redirected to their
+// outputs
+func TestOutputSplit(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New()
+	g.Add(node.New("root", nil))
+	g.Add(node.New("root/module.x", nil))
+	g.Add(node.New("root/module.x/output.name", nil))
+	g.Add(node.New("root/module.x/task.query.y", nil))
+
+	t.Run("output", func(t *testing.T) {
+		vertex, terms := preprocessor.OutputSplit(g, "root/module.x", "output.name")
+		assert.Equal(t, "root/module.x/output.name", vertex)
+		assert.Equal(t, "value", terms)
+	})
+
+	t.Run("output field", func(t *testing.T) {
+		vertex, terms := preprocessor.OutputSplit(g, "root/module.x", "output.name.value")
+		assert.Equal(t, "root/module.x/output.name", vertex)
+		assert.Equal(t, "value", terms)
+	})
+
+	t.Run("same module", func(t *testing.T) {
+		vertex, terms := preprocessor.OutputSplit(g, "root/module.x/output.name", "")
+		assert.Equal(t, "root/module.x/output.name", vertex)
+		assert.Equal(t, "value", terms)
+	})
+
+	t.Run("missing output", func(t *testing.T) {
+		vertex, terms := preprocessor.OutputSplit(g, "root/module.x", "output.other")
+		assert.Equal(t, "root/module.x", vertex)
+		assert.Equal(t, "output.other", terms)
+	})
+
+	t.Run("not an output", func(t *testing.T) {
+		vertex, terms := preprocessor.OutputSplit(g, "root/module.x", "task.query.y")
+		assert.Equal(t, "root/module.x", vertex)
+		assert.Equal(t, "task.query.y", terms)
+	})
+}
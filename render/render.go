@@ -17,11 +17,13 @@ package render
 import (
 	"crypto/rand"
 	"fmt"
+	"time"
 
 	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	"github.com/asteris-llc/converge/graph/node/conditional"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/resource/module"
 	multierror "github.com/hashicorp/go-multierror"
@@ -98,7 +100,7 @@ func (p pipelineGen) prepareNode(ctx context.Context, idi interface{}) (interfac
 		_, metadataErr = p.renderMetadata(renderer)
 	}
 
-	prepared, err := res.Prepare(ctx, renderer)
+	prepared, err := p.prepare(ctx, res, renderer)
 
 	merged := mergeMaybeUnresolvables(err, metadataErr)
 
@@ -115,7 +117,7 @@ func (p pipelineGen) prepareNode(ctx context.Context, idi interface{}) (interfac
 						return nil, rendErr
 					}
 				}
-				return res.Prepare(ctx, dynamicRenderer)
+				return p.prepare(ctx, res, dynamicRenderer)
 			}), nil
 		}
 		return nil, merged
@@ -123,6 +125,40 @@ func (p pipelineGen) prepareNode(ctx context.Context, idi interface{}) (interfac
 	return prepared, nil
 }
 
+// prepare runs Prepare on the resource, subject to the timeout of the node
+func (p pipelineGen) prepare(ctx context.Context, res resource.Resource, renderer *Renderer) (resource.Task, error) {
+	var timeout time.Duration
+	if meta, ok := p.Graph.Get(p.ID); ok {
+		timeout = load.Timeout(meta)
+	}
+
+	prepared, err := resource.RunWithTimeout(ctx, "prepare", timeout, func(ctx context.Context) (interface{}, error) {
+		return res.Prepare(ctx, renderer)
+	})
+	task, _ := prepared.(resource.Task)
+	return task, p.withPosition(err)
+}
+
+// withPosition adds the position of the node in its module to errors that
+// will be reported to the user
+func (p pipelineGen) withPosition(err error) error {
+	if err == nil || errIsUnresolvable(err) || errIsBadTemplate(err) {
+		return err
+	}
+
+	meta, ok := p.Graph.Get(p.ID)
+	if !ok {
+		return err
+	}
+
+	pos, ok := load.Position(meta)
+	if !ok {
+		return err
+	}
+
+	return errors.Wrap(err, pos)
+}
+
 func mergeMaybeUnresolvables(err1, err2 error) error {
 	if err1 == nil {
 		return err2
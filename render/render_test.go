@@ -24,9 +24,12 @@ import (
 	"github.com/asteris-llc/converge/helpers/logging"
 	"github.com/asteris-llc/converge/helpers/testing/graphutils"
 	"github.com/asteris-llc/converge/helpers/testing/hclutils"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/render"
+	"github.com/asteris-llc/converge/render/extensions/facts"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/resource/file/content"
+	"github.com/asteris-llc/converge/resource/output"
 	"github.com/asteris-llc/converge/resource/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,3 +222,112 @@ task.query "b" {
 		assert.Equal(t, "true", strValue)
 	})
 }
+
+// TestRenderForeach tests rendering nodes generated by a foreach macro
+func TestRenderForeach(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	src := `
+macro.foreach "users" {
+	items = {
+		alice = { shell = "/bin/bash" }
+		bob = { shell = "/bin/zsh" }
+	}
+
+	file.content "shell" {
+		destination = "{{.Key}}.shell"
+		content = "{{.Value.shell}}"
+	}
+}
+`
+
+	gr, err := hclutils.LoadAndParseFromString("TestRenderForeach", src)
+	require.NoError(t, err)
+
+	g, err := render.Render(context.Background(), gr, render.Values{})
+	require.NoError(t, err)
+
+	for user, shell := range map[string]string{"alice": "/bin/bash", "bob": "/bin/zsh"} {
+		meta, ok := g.Get("root/file.content.shell." + user)
+		require.True(t, ok, "%s was missing from the graph", user)
+
+		wrapper, ok := meta.Value().(*resource.TaskWrapper)
+		require.True(t, ok, fmt.Sprintf("expected a %T, but it was %T", wrapper, meta.Value()))
+
+		fileContent, ok := wrapper.Task.(*content.Content)
+		require.True(t, ok, fmt.Sprintf("expected a %T, but it was %T", fileContent, wrapper.Task))
+
+		assert.Equal(t, user+".shell", fileContent.Destination)
+		assert.Equal(t, shell, fileContent.Content)
+	}
+}
+
+func TestRenderOutputs(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	gr, err := load.Load(context.Background(), "../samples/outputs.hcl", false)
+	require.NoError(t, err)
+
+	g, err := render.Render(context.Background(), gr, render.Values{})
+	require.NoError(t, err)
+
+	meta, ok := g.Get("root/module.greeter/output.name")
+	require.True(t, ok, "output was missing from the graph")
+
+	task, ok := resource.ResolveTask(meta.Value())
+	require.True(t, ok, fmt.Sprintf("expected a task, but it was %T", meta.Value()))
+
+	out, ok := task.(*output.Output)
+	require.True(t, ok, fmt.Sprintf("expected a %T, but it was %T", out, task))
+	assert.Equal(t, "Converge", out.Value)
+}
+
+func TestRenderLocals(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	gr, err := load.Load(context.Background(), "../samples/locals.hcl", false)
+	require.NoError(t, err)
+
+	g, err := render.Render(context.Background(), gr, render.Values{})
+	require.NoError(t, err)
+
+	meta, ok := g.Get("root/file.content.config")
+	require.True(t, ok, "file.content.config was missing from the graph")
+
+	task, ok := resource.ResolveTask(meta.Value())
+	require.True(t, ok, fmt.Sprintf("expected a task, but it was %T", meta.Value()))
+
+	fileContent, ok := task.(*content.Content)
+	require.True(t, ok, fmt.Sprintf("expected a %T, but it was %T", fileContent, task))
+
+	assert.Equal(t, "/tmp/converge-locals/etc/app/app.conf", fileContent.Destination)
+	assert.Equal(t, "image = registry.example.com/app:1.2.3\n", fileContent.Content)
+}
+
+func TestRenderFacts(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	gr, err := load.Load(context.Background(), "../samples/facts.hcl", false)
+	require.NoError(t, err)
+
+	g, err := render.Render(context.Background(), gr, render.Values{})
+	require.NoError(t, err)
+
+	meta, ok := g.Get("root/file.content.motd")
+	require.True(t, ok, "file.content.motd was missing from the graph")
+
+	task, ok := resource.ResolveTask(meta.Value())
+	require.True(t, ok, fmt.Sprintf("expected a task, but it was %T", meta.Value()))
+
+	fileContent, ok := task.(*content.Content)
+	require.True(t, ok, fmt.Sprintf("expected a %T, but it was %T", fileContent, task))
+
+	f, err := facts.NewCache().Get()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		fmt.Sprintf("Welcome to %s (%d CPUs, %s)\n", f.FQDN, f.CPU.Count, f.Init),
+		fileContent.Content,
+	)
+}
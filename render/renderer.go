@@ -22,8 +22,10 @@ import (
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/parse"
 	"github.com/asteris-llc/converge/render/extensions"
+	"github.com/asteris-llc/converge/render/extensions/facts"
 	"github.com/asteris-llc/converge/render/preprocessor"
 	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/local"
 	"github.com/asteris-llc/converge/resource/param"
 	"github.com/pkg/errors"
 )
@@ -51,6 +53,7 @@ type Renderer struct {
 	DotValuePresent bool
 	resolverErr     bool
 	Language        *extensions.LanguageExtension
+	Facts           *facts.Cache
 }
 
 // GetID returns the ID of this renderer
@@ -70,8 +73,11 @@ func (r *Renderer) Render(name, src string) (string, error) {
 	r.Language = r.Language.On("param", r.param)
 	r.Language = r.Language.On("paramList", r.paramList)
 	r.Language = r.Language.On("paramMap", r.paramMap)
+	r.Language = r.Language.On("local", r.local)
+	r.Language = r.Language.On("fact", r.fact)
 
 	r.Language = r.Language.On(extensions.RefFuncName, r.lookup)
+	r.Language = r.Language.On(extensions.OutputFuncName, r.output)
 	out, err := r.Language.Render(r.DotValue, name, src)
 	if err != nil {
 		if r.resolverErr {
@@ -170,6 +176,48 @@ func (r *Renderer) paramRawValue(name string) (interface{}, error) {
 	return param.Val, nil
 }
 
+// local returns the rendered value of a local in the current module
+func (r *Renderer) local(name string) (string, error) {
+	ancestor, found := getNearestAncestor(r.Graph(), r.ID, "local."+name)
+	if !found {
+		return "", fmt.Errorf("local.%s not found", name)
+	}
+	ancestorMeta, _ := r.Graph().Get(ancestor)
+
+	if _, isThunk := ancestorMeta.Value().(*PrepareThunk); isThunk {
+		r.resolverErr = true
+		return "", ErrUnresolvable{}
+	}
+
+	task, ok := resource.ResolveTask(ancestorMeta.Value())
+	if !ok {
+		r.resolverErr = true
+		return "", ErrUnresolvable{}
+	}
+
+	l, ok := task.(*local.Local)
+	if !ok {
+		return "", fmt.Errorf("%s is not a local, but a %T", ancestor, task)
+	}
+
+	return l.Value, nil
+}
+
+// fact returns a fact about the system, gathering the facts if they have not
+// been gathered yet in this run
+func (r *Renderer) fact(path string) (interface{}, error) {
+	if r.Facts == nil {
+		r.Facts = facts.NewCache()
+	}
+
+	f, err := r.Facts.Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not gather facts")
+	}
+
+	return f.Get(path)
+}
+
 func (r *Renderer) lookup(name string) (string, error) {
 	g := r.Graph()
 	// fully-qualified graph name
@@ -182,6 +230,8 @@ func (r *Renderer) lookup(name string) (string, error) {
 		make(map[string]struct{}),
 	)
 
+	vertexName, terms = preprocessor.OutputSplit(g, vertexName, terms)
+
 	if !validateLookup(g, r.ID, vertexName) {
 		return "", fmt.Errorf("%s cannot resolve inner-branch node at %s", r.ID, vertexName)
 	}
@@ -246,6 +296,11 @@ func (r *Renderer) lookup(name string) (string, error) {
 	return fmt.Sprintf("%v", result), nil
 }
 
+// output looks up the value of an output of a module call
+func (r *Renderer) output(module, name string) (string, error) {
+	return r.lookup(extensions.OutputRef(module, name))
+}
+
 // validateLookup ensures that the lookup is valid and resolvable over cases of
 // nesting and conditional evaluation.  It restricts lookups such that a nested
 // value may depend on an outer value, but an outer value may not depend on a
@@ -254,6 +309,9 @@ func validateLookup(g *graph.Graph, src, dst string) bool {
 	if g.AreSiblings(src, dst) {
 		return true
 	}
+	if preprocessor.IsOutput(dst) && g.AreSiblings(src, graph.ParentID(dst)) {
+		return true
+	}
 	if g.IsNibling(src, dst) {
 		return false
 	}
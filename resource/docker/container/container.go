@@ -97,6 +97,10 @@ type Container struct {
 	// Indicate whether the 'force' flag was set
 	Force  bool `export:"force"`
 	client docker.APIClient
+
+	// the container that Apply replaced, for Rollback
+	applied  bool
+	previous *dc.Container
 }
 
 // Check that a docker container with the specified configuration exists
@@ -154,10 +158,16 @@ func (c *Container) Apply(context.Context) (resource.TaskStatus, error) {
 		HostConfig: hostConfig,
 	}
 
+	previous, err := c.client.FindContainer(c.Name)
+	if err != nil {
+		return status, err
+	}
+
 	container, err := c.client.CreateContainer(opts)
 	if err != nil {
 		return status, err
 	}
+	c.applied, c.previous = true, previous
 
 	for _, name := range c.Networks {
 		err = c.client.ConnectNetwork(name, container)
@@ -176,6 +186,61 @@ func (c *Container) Apply(context.Context) (resource.TaskStatus, error) {
 	return status, nil
 }
 
+// Rollback removes the container created by Apply. If Apply replaced an
+// existing container, it is created again with its previous configuration and
+// started if it was running.
+func (c *Container) Rollback(context.Context) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+	if !c.applied {
+		status.AddMessage("nothing to roll back")
+		return status, nil
+	}
+
+	if c.previous == nil {
+		if err := c.client.RemoveContainer(c.Name); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, err
+		}
+		status.AddDifference("name", c.Name, "", "<container-missing>")
+		c.applied = false
+		return status, nil
+	}
+
+	opts := dc.CreateContainerOptions{
+		Name:       c.Name,
+		Config:     c.previous.Config,
+		HostConfig: c.previous.HostConfig,
+	}
+
+	container, err := c.client.CreateContainer(opts)
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	for _, name := range customNetworks(c.previous) {
+		if err := c.client.ConnectNetwork(name, container); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, err
+		}
+	}
+
+	if c.previous.State.Running {
+		if err := c.client.StartContainer(c.Name, container.ID); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, err
+		}
+	}
+
+	if c.previous.Config != nil {
+		status.AddDifference("image", c.Image, c.previous.Config.Image, "")
+	}
+	status.AddMessage(fmt.Sprintf("restored container %s", c.Name))
+	c.applied, c.previous = false, nil
+
+	return status, nil
+}
+
 // SetClient injects a docker api client
 func (c *Container) SetClient(client docker.APIClient) {
 	c.client = client
@@ -289,7 +354,22 @@ func (c *Container) compareNetworks(container *dc.Container) (actual, expected s
 		return "", ""
 	}
 
-	var containerNetworks []string
+	containerNetworks := customNetworks(container)
+
+	expectedNetworks := make([]string, len(c.Networks))
+	copy(expectedNetworks, c.Networks)
+	sort.Strings(expectedNetworks)
+
+	return strings.Join(containerNetworks, ", "), strings.Join(expectedNetworks, ", ")
+}
+
+// customNetworks returns the sorted names of the networks a container is
+// connected to, other than the builtin networks
+func customNetworks(container *dc.Container) (out []string) {
+	if container.NetworkSettings == nil {
+		return nil
+	}
+
 	for name := range container.NetworkSettings.Networks {
 		var isBuiltin bool
 		for _, builtin := range builtinNetworks {
@@ -299,16 +379,11 @@ func (c *Container) compareNetworks(container *dc.Container) (actual, expected s
 			}
 		}
 		if !isBuiltin {
-			containerNetworks = append(containerNetworks, name)
+			out = append(out, name)
 		}
 	}
-	sort.Strings(containerNetworks)
-
-	expectedNetworks := make([]string, len(c.Networks))
-	copy(expectedNetworks, c.Networks)
-	sort.Strings(expectedNetworks)
-
-	return strings.Join(containerNetworks, ", "), strings.Join(expectedNetworks, ", ")
+	sort.Strings(out)
+	return out
 }
 
 func (c *Container) compareEnv(container *dc.Container, image *dc.Image) (actual, expected string) {
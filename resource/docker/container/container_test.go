@@ -689,6 +689,7 @@ func TestContainerApply(t *testing.T) {
 	defer logging.HideLogs(t)()
 
 	c := &fakeAPIClient{
+		FindContainerFunc: func(string) (*dc.Container, error) { return nil, nil },
 		CreateContainerFunc: func(opts dc.CreateContainerOptions) (*dc.Container, error) {
 			return &dc.Container{}, nil
 		},
@@ -701,6 +702,80 @@ func TestContainerApply(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestContainerRollback tests the Container.Rollback function
+func TestContainerRollback(t *testing.T) {
+	t.Parallel()
+	defer logging.HideLogs(t)()
+
+	t.Run("not applied", func(t *testing.T) {
+		container := &container.Container{Name: "nginx"}
+		container.SetClient(&fakeAPIClient{})
+
+		status, err := container.Rollback(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nothing to roll back"}, status.Messages())
+	})
+
+	t.Run("created", func(t *testing.T) {
+		var removed string
+		c := &fakeAPIClient{
+			FindContainerFunc: func(string) (*dc.Container, error) { return nil, nil },
+			CreateContainerFunc: func(opts dc.CreateContainerOptions) (*dc.Container, error) {
+				return &dc.Container{}, nil
+			},
+			StartContainerFunc:  func(string, string) error { return nil },
+			RemoveContainerFunc: func(name string) error { removed = name; return nil },
+		}
+		container := &container.Container{Name: "nginx", Image: "nginx:latest"}
+		container.SetClient(c)
+
+		_, err := container.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = container.Rollback(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "nginx", removed)
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		previous := &dc.Container{
+			ID:         "old",
+			Config:     &dc.Config{Image: "nginx:1.10"},
+			HostConfig: &dc.HostConfig{},
+			State:      dc.State{Running: true},
+			NetworkSettings: &dc.NetworkSettings{
+				Networks: map[string]dc.ContainerNetwork{"bridge": {}, "app": {}},
+			},
+		}
+
+		var created []dc.CreateContainerOptions
+		var connected, started []string
+		c := &fakeAPIClient{
+			FindContainerFunc: func(string) (*dc.Container, error) { return previous, nil },
+			CreateContainerFunc: func(opts dc.CreateContainerOptions) (*dc.Container, error) {
+				created = append(created, opts)
+				return &dc.Container{ID: "new"}, nil
+			},
+			StartContainerFunc: func(name, id string) error { started = append(started, id); return nil },
+			ConnectNetworkFunc: func(name string, _ *dc.Container) error { connected = append(connected, name); return nil },
+		}
+		container := &container.Container{Name: "nginx", Image: "nginx:latest", CStatus: "created"}
+		container.SetClient(c)
+
+		_, err := container.Apply(context.Background())
+		require.NoError(t, err)
+
+		status, err := container.Rollback(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, created, 2)
+		assert.Equal(t, "nginx:1.10", created[1].Config.Image)
+		assert.Equal(t, []string{"app"}, connected)
+		assert.Equal(t, []string{"new"}, started)
+		assert.Equal(t, "nginx:1.10", status.Diffs()["image"].Current())
+	})
+}
+
 type fakeAPIClient struct {
 	FindImageFunc       func(repoTag string) (*dc.Image, error)
 	PullImageFunc       func(name, tag string) error
@@ -708,6 +783,7 @@ type fakeAPIClient struct {
 	CreateContainerFunc func(opts dc.CreateContainerOptions) (*dc.Container, error)
 	StartContainerFunc  func(name, id string) error
 	ConnectNetworkFunc  func(name string, container *dc.Container) error
+	RemoveContainerFunc func(name string) error
 }
 
 func (f *fakeAPIClient) FindImage(repoTag string) (*dc.Image, error) {
@@ -733,3 +809,7 @@ func (f *fakeAPIClient) StartContainer(name, id string) error {
 func (f *fakeAPIClient) ConnectNetwork(name string, container *dc.Container) error {
 	return f.ConnectNetworkFunc(name, container)
 }
+
+func (f *fakeAPIClient) RemoveContainer(name string) error {
+	return f.RemoveContainerFunc(name)
+}
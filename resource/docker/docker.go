@@ -33,6 +33,7 @@ type APIClient interface {
 	CreateContainer(dc.CreateContainerOptions) (*dc.Container, error)
 	StartContainer(string, string) error
 	ConnectNetwork(string, *dc.Container) error
+	RemoveContainer(string) error
 }
 
 // VolumeClient manages Docker volumes
@@ -194,20 +195,8 @@ func (c *Client) CreateContainer(opts dc.CreateContainerOptions) (*dc.Container,
 	if container != nil {
 		log.WithField("module", "docker").WithField("name", name).Debug("container exists")
 
-		// stop the container if running
-		if container.State.Running {
-			log.WithField("module", "docker").WithFields(log.Fields{"name": name, "id": container.ID}).Debug("stopping container")
-			err = c.Client.StopContainer(container.ID, 60)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to stop container %s (%s)", name, container.ID)
-			}
-		}
-
-		// remove the container
-		log.WithField("module", "docker").WithFields(log.Fields{"name": name, "id": container.ID}).Debug("removing container")
-		err = c.Client.RemoveContainer(dc.RemoveContainerOptions{ID: container.ID})
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to remove container %s (%s)", name, container.ID)
+		if err = c.removeContainer(name, container); err != nil {
+			return nil, err
 		}
 	}
 
@@ -222,6 +211,37 @@ func (c *Client) CreateContainer(opts dc.CreateContainerOptions) (*dc.Container,
 	return container, err
 }
 
+// RemoveContainer stops and removes the container with the specified name, if
+// it exists
+func (c *Client) RemoveContainer(name string) error {
+	container, err := c.FindContainer(name)
+	if err != nil || container == nil {
+		return err
+	}
+
+	return c.removeContainer(name, container)
+}
+
+func (c *Client) removeContainer(name string, container *dc.Container) error {
+	// stop the container if running
+	if container.State.Running {
+		log.WithField("module", "docker").WithFields(log.Fields{"name": name, "id": container.ID}).Debug("stopping container")
+		err := c.Client.StopContainer(container.ID, 60)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stop container %s (%s)", name, container.ID)
+		}
+	}
+
+	// remove the container
+	log.WithField("module", "docker").WithFields(log.Fields{"name": name, "id": container.ID}).Debug("removing container")
+	err := c.Client.RemoveContainer(dc.RemoveContainerOptions{ID: container.ID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove container %s (%s)", name, container.ID)
+	}
+
+	return nil
+}
+
 // StartContainer starts the container with the specified ID
 func (c *Client) StartContainer(name, containerID string) error {
 	log.WithField("module", "docker").WithFields(log.Fields{"name": name, "id": containerID}).Debug("starting container")
@@ -147,6 +147,7 @@ type fakeAPIClient struct {
 	CreateContainerFunc func(opts dc.CreateContainerOptions) (*dc.Container, error)
 	StartContainerFunc  func(name, id string) error
 	ConnectNetworkFunc  func(name string, container *dc.Container) error
+	RemoveContainerFunc func(name string) error
 }
 
 func (f *fakeAPIClient) FindImage(repoTag string) (*dc.Image, error) {
@@ -172,3 +173,7 @@ func (f *fakeAPIClient) StartContainer(name, id string) error {
 func (f *fakeAPIClient) ConnectNetwork(name string, container *dc.Container) error {
 	return f.ConnectNetworkFunc(name, container)
 }
+
+func (f *fakeAPIClient) RemoveContainer(name string) error {
+	return f.RemoveContainerFunc(name)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"golang.org/x/net/context"
+)
+
+// State type for Block
+type State string
+
+const (
+	// StatePresent indicates the block should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the block should be absent
+	StateAbsent State = "absent"
+)
+
+// Block manages the lines between a pair of markers in a file
+type Block struct {
+	// the file to edit
+	Destination string `export:"destination"`
+
+	// the text between the markers
+	Block string `export:"block"`
+
+	// the name of the block in the markers
+	Marker string `export:"marker"`
+
+	// the comment the markers start with
+	Comment string `export:"comment"`
+
+	// whether the block should be present
+	State State `export:"state"`
+
+	after, before *regexp.Regexp
+}
+
+// Check if the block needs to be changed
+func (b *Block) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	return file.CheckEdit(b.Destination, b.edit)
+}
+
+// Apply changes the block
+func (b *Block) Apply(context.Context) (resource.TaskStatus, error) {
+	return file.ApplyEdit(b.Destination, b.edit)
+}
+
+// Begin is the line that starts the block
+func (b *Block) Begin() string {
+	return fmt.Sprintf("%s BEGIN converge %s", b.Comment, b.Marker)
+}
+
+// End is the line that ends the block
+func (b *Block) End() string {
+	return fmt.Sprintf("%s END converge %s", b.Comment, b.Marker)
+}
+
+// edit the lines of the file
+func (b *Block) edit(lines []string) ([]string, error) {
+	begin, end := -1, -1
+	for i, line := range lines {
+		switch {
+		case line == b.Begin() && begin == -1:
+			begin = i
+		case line == b.End() && begin != -1:
+			end = i
+		}
+		if end != -1 {
+			break
+		}
+	}
+
+	if begin != -1 && end == -1 {
+		return nil, fmt.Errorf("%q has no matching %q", b.Begin(), b.End())
+	}
+
+	var block []string
+	if b.State == StatePresent {
+		block = append([]string{b.Begin()}, file.SplitLines(b.Block)...)
+		block = append(block, b.End())
+	}
+
+	if begin == -1 {
+		if b.State == StateAbsent {
+			return lines, nil
+		}
+		return file.Insert(lines, block, b.after, b.before), nil
+	}
+
+	out := make([]string, 0, len(lines)-(end-begin+1)+len(block))
+	out = append(out, lines[:begin]...)
+	out = append(out, block...)
+	return append(out, lines[end+1:]...), nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestBlockInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(block.Block))
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-block")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	const (
+		hosts   = "127.0.0.1 localhost\n::1 localhost\n"
+		managed = "127.0.0.1 localhost\n# BEGIN converge hosts\n10.0.0.1 a\n# END converge hosts\n::1 localhost\n"
+	)
+
+	for _, test := range []struct {
+		name     string
+		prep     *block.Preparer
+		content  string
+		expected string
+	}{
+		{
+			"append",
+			&block.Preparer{Block: "10.0.0.1 a\n10.0.0.2 b\n"},
+			hosts,
+			hosts + "# BEGIN converge hosts\n10.0.0.1 a\n10.0.0.2 b\n# END converge hosts\n",
+		},
+		{
+			"insert after",
+			&block.Preparer{Block: "10.0.0.1 a", InsertAfter: "^127"},
+			hosts,
+			managed,
+		},
+		{
+			"replace",
+			&block.Preparer{Block: "10.0.0.2 b"},
+			managed,
+			"127.0.0.1 localhost\n# BEGIN converge hosts\n10.0.0.2 b\n# END converge hosts\n::1 localhost\n",
+		},
+		{
+			"unchanged",
+			&block.Preparer{Block: "10.0.0.1 a\n"},
+			managed,
+			managed,
+		},
+		{
+			"other marker",
+			&block.Preparer{Block: "10.0.0.3 c", Marker: "other"},
+			managed,
+			managed + "# BEGIN converge other\n10.0.0.3 c\n# END converge other\n",
+		},
+		{
+			"absent",
+			&block.Preparer{State: block.StateAbsent},
+			managed,
+			hosts,
+		},
+		{
+			"already absent",
+			&block.Preparer{State: block.StateAbsent},
+			hosts,
+			hosts,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dest := filepath.Join(dir, test.name)
+			require.NoError(t, ioutil.WriteFile(dest, []byte(test.content), 0600))
+
+			test.prep.Destination = dest
+			task, err := test.prep.Prepare(context.Background(), fakerenderer.NewWithID("root/file.block.hosts"))
+			require.NoError(t, err)
+
+			status, err := task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.Equal(t, test.content != test.expected, status.HasChanges())
+
+			_, err = task.Apply(context.Background())
+			require.NoError(t, err)
+
+			actual, err := ioutil.ReadFile(dest)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+
+			status, err = task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.False(t, status.HasChanges(), "not idempotent")
+		})
+	}
+
+	t.Run("unterminated", func(t *testing.T) {
+		dest := filepath.Join(dir, "unterminated")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("# BEGIN converge hosts\nx\n"), 0600))
+
+		task, err := (&block.Preparer{Destination: dest}).Prepare(context.Background(), fakerenderer.NewWithID("root/file.block.hosts"))
+		require.NoError(t, err)
+
+		_, err = task.Check(context.Background(), fakerenderer.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"# BEGIN converge hosts" has no matching "# END converge hosts"`)
+	})
+}
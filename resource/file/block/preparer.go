@@ -0,0 +1,125 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/asteris-llc/converge/graph"
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Preparer for Block
+//
+// Block manages the lines between a pair of marker comments in a file, without
+// managing the rest of the file. The markers look like `# BEGIN converge
+// hosts` and `# END converge hosts`.
+type Preparer struct {
+	// Destination is the file to edit. It must already exist.
+	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// Block is the text between the markers. A trailing newline is ignored.
+	Block string `hcl:"block"`
+
+	// Marker identifies the block in the markers. The default value is the
+	// name of the resource. Set it to keep blocks apart when resources in
+	// different modules edit the same file.
+	Marker string `hcl:"marker"`
+
+	// Comment starts the marker lines, and must be a comment in the format of
+	// the file. The default value is "#".
+	Comment string `hcl:"comment"`
+
+	// InsertAfter is a regular expression. When the markers are not in the
+	// file, the block is inserted after the last line matching it. If no line
+	// matches, the block is added to the end of the file.
+	InsertAfter string `hcl:"insert_after" mutually_exclusive:"insert_after,insert_before"`
+
+	// InsertBefore is a regular expression. When the markers are not in the
+	// file, the block is inserted before the first line matching it. If no line
+	// matches, the block is added to the end of the file.
+	InsertBefore string `hcl:"insert_before" mutually_exclusive:"insert_after,insert_before"`
+
+	// State is whether the block should be present or absent. When it is
+	// absent, the markers are removed along with the block. The default value
+	// is present.
+	State State `hcl:"state" valid_values:"present,absent"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.State == "" {
+		p.State = StatePresent
+	}
+
+	if p.Marker == "" {
+		p.Marker = strings.TrimPrefix(graph.BaseID(render.GetID()), "file.block.")
+	}
+
+	if p.Comment == "" {
+		p.Comment = "#"
+	}
+
+	if p.State == StateAbsent && p.Block != "" {
+		return nil, errors.New("\"block\" cannot be set when \"state\" is absent")
+	}
+
+	if p.State == StateAbsent && (p.InsertAfter != "" || p.InsertBefore != "") {
+		return nil, errors.New("\"insert_after\" and \"insert_before\" are only valid when \"state\" is present")
+	}
+
+	if p.Marker == "" || strings.Contains(p.Marker, "\n") {
+		return nil, errors.Errorf("invalid marker %q", p.Marker)
+	}
+
+	block := &Block{
+		Destination: p.Destination,
+		Block:       strings.TrimSuffix(p.Block, "\n"),
+		Marker:      p.Marker,
+		Comment:     p.Comment,
+		State:       p.State,
+	}
+
+	var err error
+	if block.after, err = compile("insert_after", p.InsertAfter); err != nil {
+		return nil, err
+	}
+	if block.before, err = compile("insert_before", p.InsertBefore); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// compile a regular expression, or return nil if it is not set
+func compile(field, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %q", field)
+	}
+	return re, nil
+}
+
+func init() {
+	registry.Register("file.block", (*Preparer)(nil), (*Block)(nil))
+}
@@ -0,0 +1,64 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(block.Preparer))
+}
+
+func TestPreparerPrepare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		prep := &block.Preparer{Destination: "/tmp/x", Block: "x\n"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.NewWithID("root/file.block.hosts"))
+		require.NoError(t, err)
+
+		b := task.(*block.Block)
+		assert.Equal(t, block.StatePresent, b.State)
+		assert.Equal(t, "x", b.Block)
+		assert.Equal(t, "# BEGIN converge hosts", b.Begin())
+		assert.Equal(t, "# END converge hosts", b.End())
+	})
+
+	t.Run("marker and comment", func(t *testing.T) {
+		prep := &block.Preparer{Destination: "/tmp/x", Marker: "proxy", Comment: ";"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.NewWithID("root/file.block.hosts"))
+		require.NoError(t, err)
+		assert.Equal(t, "; BEGIN converge proxy", task.(*block.Block).Begin())
+	})
+
+	t.Run("absent with block", func(t *testing.T) {
+		prep := &block.Preparer{Destination: "/tmp/x", Block: "x", State: block.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.NewWithID("root/file.block.hosts"))
+		assert.EqualError(t, err, `"block" cannot be set when "state" is absent`)
+	})
+}
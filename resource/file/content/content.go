@@ -20,9 +20,22 @@ import (
 	"os"
 
 	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
+// State type for Content
+type State string
+
+const (
+	// StatePresent indicates the file should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the file should be absent
+	StateAbsent State = "absent"
+)
+
 // Content renders content to disk
 type Content struct {
 	// configured content of the file
@@ -30,10 +43,26 @@ type Content struct {
 
 	// configured destination of the file
 	Destination string `export:"destination"`
+
+	// whether the file should be present
+	State State `export:"state"`
+
+	// the file as it was before Apply, for Rollback
+	previous *previousContent
+}
+
+type previousContent struct {
+	existed bool
+	content []byte
+	perm    os.FileMode
 }
 
 // Check if the content needs to be rendered
 func (t *Content) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	if t.State == StateAbsent {
+		return t.checkAbsent()
+	}
+
 	diffs := make(map[string]resource.Diff)
 	contentDiff := resource.TextDiff{Values: [2]string{"", t.Content}}
 	stat, err := os.Stat(t.Destination)
@@ -75,8 +104,36 @@ func (t *Content) Check(context.Context, resource.Renderer) (resource.TaskStatus
 	}, nil
 }
 
+// checkAbsent checks whether the file needs to be removed
+func (t *Content) checkAbsent() (resource.TaskStatus, error) {
+	stat, err := os.Stat(t.Destination)
+	if os.IsNotExist(err) {
+		return &resource.Status{Output: []string{t.Destination + " does not exist"}}, nil
+	} else if err != nil {
+		return &resource.Status{
+			Level:  resource.StatusFatal,
+			Output: []string{"Cannot read `" + t.Destination + "`"},
+		}, nil
+	} else if stat.IsDir() {
+		return &resource.Status{
+			Level:  resource.StatusCantChange,
+			Output: []string{t.Destination + " is a directory"},
+		}, fmt.Errorf("cannot remove %q, it is a directory", t.Destination)
+	}
+
+	return &resource.Status{
+		Level:       resource.StatusWillChange,
+		Differences: map[string]resource.Diff{t.Destination: resource.TextDiff{Values: [2]string{"<present>", "<absent>"}}},
+		Output:      []string{t.Destination + ": File will be removed"},
+	}, nil
+}
+
 // Apply writes the content to disk
 func (t *Content) Apply(context.Context) (resource.TaskStatus, error) {
+	if t.State == StateAbsent {
+		return t.applyAbsent()
+	}
+
 	var perm os.FileMode
 	var preChange string
 	diffs := make(map[string]resource.Diff)
@@ -94,10 +151,13 @@ func (t *Content) Apply(context.Context) (resource.TaskStatus, error) {
 		perm = stat.Mode()
 	}
 
+	previous := &previousContent{perm: perm}
 	if rawData, readErr := ioutil.ReadFile(t.Destination); readErr != nil {
 		preChange = "<file-missing>"
 	} else {
 		preChange = string(rawData)
+		previous.existed = true
+		previous.content = rawData
 	}
 
 	diffs[t.Destination] = resource.TextDiff{Values: [2]string{preChange, t.Content}}
@@ -110,5 +170,83 @@ func (t *Content) Apply(context.Context) (resource.TaskStatus, error) {
 		}, err
 	}
 
+	t.previous = previous
+
+	return &resource.Status{Differences: diffs}, nil
+}
+
+// applyAbsent removes the file, keeping its content for Rollback
+func (t *Content) applyAbsent() (resource.TaskStatus, error) {
+	stat, err := os.Stat(t.Destination)
+	if os.IsNotExist(err) {
+		return &resource.Status{Output: []string{t.Destination + " does not exist"}}, nil
+	} else if err != nil {
+		return &resource.Status{
+			Level:  resource.StatusFatal,
+			Output: []string{err.Error()},
+		}, err
+	}
+
+	content, err := ioutil.ReadFile(t.Destination)
+	if err != nil {
+		return &resource.Status{
+			Level:  resource.StatusFatal,
+			Output: []string{err.Error()},
+		}, err
+	}
+
+	diffs := map[string]resource.Diff{t.Destination: resource.TextDiff{Values: [2]string{"<present>", "<absent>"}}}
+
+	if err := file.Remove(t.Destination, false); err != nil {
+		return &resource.Status{
+			Output:      []string{err.Error()},
+			Level:       resource.StatusFatal,
+			Differences: diffs,
+		}, err
+	}
+
+	t.previous = &previousContent{existed: true, content: content, perm: stat.Mode()}
+
 	return &resource.Status{Differences: diffs}, nil
 }
+
+// Rollback restores the content the file had before Apply, or removes the
+// file if Apply created it
+func (t *Content) Rollback(context.Context) (resource.TaskStatus, error) {
+	if t.previous == nil {
+		return &resource.Status{Output: []string{"nothing to roll back"}}, nil
+	}
+
+	applied := t.Content
+	if t.State == StateAbsent {
+		applied = "<absent>"
+	}
+
+	status := resource.NewStatus()
+	if t.previous.existed {
+		if err := ioutil.WriteFile(t.Destination, t.previous.content, t.previous.perm); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not restore %s", t.Destination)
+		}
+		// the file may have been removed, so it is created subject to the umask
+		if err := os.Chmod(t.Destination, t.previous.perm); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not restore mode of %s", t.Destination)
+		}
+		restored := string(t.previous.content)
+		if t.State == StateAbsent {
+			restored = "<present>"
+		}
+		status.AddDifference(t.Destination, applied, restored, "")
+	} else {
+		if err := os.Remove(t.Destination); err != nil && !os.IsNotExist(err) {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not remove %s", t.Destination)
+		}
+		status.AddDifference(t.Destination, applied, "<file-missing>", "")
+	}
+
+	t.previous = nil
+
+	return status, nil
+}
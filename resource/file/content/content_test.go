@@ -198,3 +198,122 @@ func TestContentApplyKeepPermission(t *testing.T) {
 
 	assert.Equal(t, perm, stat.Mode().Perm())
 }
+
+func TestContentAbsent(t *testing.T) {
+	t.Run("check", func(t *testing.T) {
+		tmpfile, err := ioutil.TempFile("", "test-content-absent")
+		require.NoError(t, err)
+		defer os.Remove(tmpfile.Name())
+
+		require.NoError(t, ioutil.WriteFile(tmpfile.Name(), []byte("stale"), 0644))
+
+		tmpl := content.Content{Destination: tmpfile.Name(), State: content.StateAbsent}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.True(t, status.HasChanges())
+		assert.Equal(t, "<present>", status.Diffs()[tmpfile.Name()].Original())
+		assert.Equal(t, "<absent>", status.Diffs()[tmpfile.Name()].Current())
+	})
+
+	t.Run("check missing", func(t *testing.T) {
+		tmpl := content.Content{Destination: "missing-file", State: content.StateAbsent}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges())
+	})
+
+	t.Run("check directory", func(t *testing.T) {
+		tmpdir, err := ioutil.TempDir("", "test-content-absent")
+		require.NoError(t, err)
+		defer os.RemoveAll(tmpdir)
+
+		tmpl := content.Content{Destination: tmpdir, State: content.StateAbsent}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, fmt.Sprintf("cannot remove %q, it is a directory", tmpdir))
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+	})
+
+	t.Run("apply and rollback", func(t *testing.T) {
+		tmpfile, err := ioutil.TempFile("", "test-content-absent")
+		require.NoError(t, err)
+		defer os.Remove(tmpfile.Name())
+
+		require.NoError(t, ioutil.WriteFile(tmpfile.Name(), []byte("stale"), 0640))
+		require.NoError(t, os.Chmod(tmpfile.Name(), 0640))
+
+		tmpl := content.Content{Destination: tmpfile.Name(), State: content.StateAbsent}
+
+		_, err = tmpl.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = os.Stat(tmpfile.Name())
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+
+		restored, err := ioutil.ReadFile(tmpfile.Name())
+		require.NoError(t, err)
+		assert.Equal(t, "stale", string(restored))
+
+		stat, err := os.Stat(tmpfile.Name())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), stat.Mode().Perm())
+	})
+}
+
+func TestContentRollback(t *testing.T) {
+	t.Run("existing file", func(t *testing.T) {
+		tmpfile, err := ioutil.TempFile("", "test-content-rollback")
+		require.NoError(t, err)
+		defer func() { require.NoError(t, os.Remove(tmpfile.Name())) }()
+
+		require.NoError(t, ioutil.WriteFile(tmpfile.Name(), []byte("0"), 0644))
+
+		tmpl := content.Content{
+			Destination: tmpfile.Name(),
+			Content:     "1",
+		}
+
+		_, err = tmpl.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadFile(tmpfile.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, "0", string(content))
+	})
+
+	t.Run("created file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "test-content-rollback")
+		require.NoError(t, err)
+		defer func() { require.NoError(t, os.RemoveAll(dir)) }()
+
+		tmpl := content.Content{
+			Destination: dir + "/created",
+			Content:     "1",
+		}
+
+		_, err = tmpl.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+
+		_, err = os.Stat(tmpl.Destination)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("not applied", func(t *testing.T) {
+		tmpl := content.Content{Destination: "/nonexistent", Content: "1"}
+
+		status, err := tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nothing to roll back"}, status.Messages())
+	})
+}
@@ -17,6 +17,7 @@ package content
 import (
 	"github.com/asteris-llc/converge/load/registry"
 	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
@@ -29,13 +30,27 @@ type Preparer struct {
 
 	// Destination is the location on disk where the content will be rendered.
 	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// State is whether the file should be present. When it is absent, the file
+	// at Destination is removed and Content must not be set.
+	// The default value is present.
+	State State `hcl:"state" valid_values:"present,absent"`
 }
 
 // Prepare a new task
 func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.State == "" {
+		p.State = StatePresent
+	}
+
+	if p.State == StateAbsent && p.Content != "" {
+		return nil, errors.New("\"content\" cannot be set when \"state\" is absent")
+	}
+
 	return &Content{
 		Destination: p.Destination,
 		Content:     p.Content,
+		State:       p.State,
 	}, nil
 }
 
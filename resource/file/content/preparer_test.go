@@ -17,9 +17,12 @@ package content_test
 import (
 	"testing"
 
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/resource/file/content"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
 )
 
 func TestPreparerInterface(t *testing.T) {
@@ -27,3 +30,22 @@ func TestPreparerInterface(t *testing.T) {
 
 	assert.Implements(t, (*resource.Resource)(nil), new(content.Preparer))
 }
+
+func TestPreparerState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		prep := &content.Preparer{Destination: "/tmp/x", Content: "x"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, content.StatePresent, task.(*content.Content).State)
+	})
+
+	t.Run("absent with content", func(t *testing.T) {
+		prep := &content.Preparer{Destination: "/tmp/x", Content: "x", State: content.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"content" cannot be set when "state" is absent`)
+	})
+}
@@ -20,10 +20,22 @@ import (
 	"path"
 
 	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
+// State type for Directory
+type State string
+
+const (
+	// StatePresent indicates the directory should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the directory should be absent
+	StateAbsent State = "absent"
+)
+
 // Directory makes sure a directory is present on disk
 type Directory struct {
 	resource.TaskStatus
@@ -33,19 +45,29 @@ type Directory struct {
 
 	// if true, directories will be created recursively
 	CreateAll bool `export:"createall"`
+
+	// whether the directory should be present
+	State State `export:"state"`
+
+	// if true, directories will be removed along with their contents
+	Recursive bool `export:"recursive"`
 }
 
 // Check if the directory exists
 func (d *Directory) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
 	status := resource.NewStatus()
 
+	if d.State == StateAbsent {
+		return d.checkAbsent(status)
+	}
+
 	dest := d.Destination
 	for dest != "/" {
 		stat, err := os.Stat(dest)
 
 		switch {
 		case err != nil && !os.IsNotExist(err):
-			return status, errors.Wrapf(err, "could not stat %q")
+			return status, errors.Wrapf(err, "could not stat %q", dest)
 
 		case os.IsNotExist(err):
 			// if we aren't told to create everything, we should fail early
@@ -81,10 +103,79 @@ func (d *Directory) Check(context.Context, resource.Renderer) (resource.TaskStat
 	return d, nil
 }
 
+// checkAbsent checks whether the directory needs to be removed
+func (d *Directory) checkAbsent(status *resource.Status) (resource.TaskStatus, error) {
+	exists, err := d.removable()
+	if err != nil {
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, err
+	}
+
+	if !exists {
+		status.AddMessage(fmt.Sprintf("%q does not exist", d.Destination))
+	} else {
+		status.RaiseLevel(resource.StatusWillChange)
+		status.AddDifference(d.Destination, "<present>", "<absent>", "<present>")
+	}
+
+	d.TaskStatus = status
+	return d, nil
+}
+
+// removable returns whether the destination exists, and an error if it exists
+// but must not be removed: because it is not a directory, or because it is not
+// empty and recursive is not set
+func (d *Directory) removable() (bool, error) {
+	stat, err := os.Lstat(d.Destination)
+
+	switch {
+	case os.IsNotExist(err):
+		return false, nil
+
+	case err != nil:
+		return false, errors.Wrapf(err, "could not stat %q", d.Destination)
+
+	case !stat.IsDir():
+		return true, fmt.Errorf("%q is not a directory and will not be removed", d.Destination)
+	}
+
+	empty, err := file.IsEmptyDir(d.Destination)
+	if err != nil {
+		return true, errors.Wrapf(err, "could not read %q", d.Destination)
+	}
+
+	if !empty && !d.Recursive {
+		return true, fmt.Errorf("%q is not empty and will not be removed (enable recursive to do this)", d.Destination)
+	}
+
+	return true, nil
+}
+
 // Apply creates the directory
 func (d *Directory) Apply(context.Context) (resource.TaskStatus, error) {
 	var err error
 
+	if d.State == StateAbsent {
+		// the destination may have changed since it was checked, so make sure
+		// again that it can be removed
+		if _, err = d.removable(); err != nil {
+			status := resource.NewStatus()
+			status.RaiseLevel(resource.StatusFatal)
+			return status, err
+		}
+
+		if err = file.Remove(d.Destination, d.Recursive); err != nil {
+			return nil, err
+		}
+
+		status := resource.NewStatus()
+		status.RaiseLevel(resource.StatusWillChange)
+		status.AddMessage(fmt.Sprintf("%q removed", d.Destination))
+		d.TaskStatus = status
+
+		return d, nil
+	}
+
 	if d.CreateAll {
 		err = os.MkdirAll(d.Destination, 0700)
 	} else {
@@ -184,3 +184,86 @@ func TestDirectoryApply(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestDirectoryAbsent(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "converge-directory-absent")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	t.Run("missing", func(t *testing.T) {
+		dest := path.Join(tmpDir, "missing")
+		dir := directory.Directory{Destination: dest, State: directory.StateAbsent}
+
+		plan, err := dir.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		assert.False(t, plan.HasChanges())
+		assert.Equal(t, []string{fmt.Sprintf("%q does not exist", dest)}, plan.Messages())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		dest := path.Join(tmpDir, "empty")
+		require.NoError(t, os.Mkdir(dest, 0700))
+
+		dir := directory.Directory{Destination: dest, State: directory.StateAbsent}
+
+		plan, err := dir.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		assert.True(t, plan.HasChanges())
+		assert.Equal(t, resource.StatusWillChange, plan.StatusCode())
+		assert.Equal(t, "<present>", plan.Diffs()[dest].Original())
+		assert.Equal(t, "<absent>", plan.Diffs()[dest].Current())
+
+		apply, err := dir.Apply(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{fmt.Sprintf("%q removed", dest)}, apply.Messages())
+
+		_, err = os.Stat(dest)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("not-empty", func(t *testing.T) {
+		dest := path.Join(tmpDir, "not-empty")
+		require.NoError(t, os.MkdirAll(path.Join(dest, "sub"), 0700))
+		require.NoError(t, ioutil.WriteFile(path.Join(dest, "sub", "file"), []byte("test"), 0600))
+
+		dir := directory.Directory{Destination: dest, State: directory.StateAbsent}
+
+		plan, err := dir.Check(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, fmt.Sprintf("%q is not empty and will not be removed (enable recursive to do this)", dest))
+		assert.Equal(t, resource.StatusCantChange, plan.StatusCode())
+
+		_, err = dir.Apply(context.Background())
+		assert.Error(t, err)
+
+		dir.Recursive = true
+		plan, err = dir.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusWillChange, plan.StatusCode())
+
+		_, err = dir.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = os.Stat(dest)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dest := path.Join(tmpDir, "file")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("test"), 0600))
+
+		dir := directory.Directory{Destination: dest, State: directory.StateAbsent}
+
+		plan, err := dir.Check(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, fmt.Sprintf("%q is not a directory and will not be removed", dest))
+		assert.Equal(t, resource.StatusCantChange, plan.StatusCode())
+
+		_, err = dir.Apply(context.Background())
+		assert.EqualError(t, err, fmt.Sprintf("%q is not a directory and will not be removed", dest))
+
+		content, err := ioutil.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "test", string(content))
+	})
+}
@@ -17,6 +17,7 @@ package directory
 import (
 	"github.com/asteris-llc/converge/load/registry"
 	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
@@ -29,13 +30,35 @@ type Preparer struct {
 
 	// whether or not to create all parent directories on the way up
 	CreateAll bool `hcl:"create_all"`
+
+	// whether the directory should be present or absent. The default value is
+	// present.
+	State State `hcl:"state" valid_values:"present,absent"`
+
+	// whether or not to remove the directory if it is not empty, along with
+	// everything in it. Only valid when state is absent.
+	Recursive bool `hcl:"recursive"`
 }
 
 // Prepare the new directory
 func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.State == "" {
+		p.State = StatePresent
+	}
+
+	if p.State == StatePresent && p.Recursive {
+		return nil, errors.New("\"recursive\" is only valid when \"state\" is absent")
+	}
+
+	if p.State == StateAbsent && p.CreateAll {
+		return nil, errors.New("\"create_all\" is only valid when \"state\" is present")
+	}
+
 	return &Directory{
 		Destination: p.Destination,
 		CreateAll:   p.CreateAll,
+		State:       p.State,
+		Recursive:   p.Recursive,
 	}, nil
 }
 
@@ -17,9 +17,12 @@ package directory_test
 import (
 	"testing"
 
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/resource/file/directory"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
 )
 
 // TestPreparerInterface tests that the Preparer interface is properly
@@ -29,3 +32,30 @@ func TestPreparerInterface(t *testing.T) {
 
 	assert.Implements(t, (*resource.Resource)(nil), new(directory.Preparer))
 }
+
+// TestPreparerState tests preparing directories that should be absent
+func TestPreparerState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		prep := &directory.Preparer{Destination: "/tmp/x"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, directory.StatePresent, task.(*directory.Directory).State)
+	})
+
+	t.Run("recursive when present", func(t *testing.T) {
+		prep := &directory.Preparer{Destination: "/tmp/x", Recursive: true}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"recursive" is only valid when "state" is absent`)
+	})
+
+	t.Run("create_all when absent", func(t *testing.T) {
+		prep := &directory.Preparer{Destination: "/tmp/x", CreateAll: true, State: directory.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"create_all" is only valid when "state" is present`)
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+)
+
+// EditFunc changes the lines of a file. It returns the lines unchanged when
+// there is nothing to do.
+type EditFunc func(lines []string) ([]string, error)
+
+// CheckEdit reports the changes an edit would make to the existing file at
+// path, as a difference between the whole content before and after
+func CheckEdit(path string, edit EditFunc) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	original, updated, err := readEdit(path, edit)
+	if err != nil {
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, err
+	}
+
+	if original == updated {
+		status.AddMessage("OK")
+		return status, nil
+	}
+
+	status.RaiseLevel(resource.StatusWillChange)
+	status.AddMessage(path + ": File will be edited")
+	status.AddDifference(path, original, updated, "")
+	return status, nil
+}
+
+// ApplyEdit edits the existing file at path, replacing it atomically. The file
+// keeps its mode and owner.
+func ApplyEdit(path string, edit EditFunc) (resource.TaskStatus, error) {
+	defer LockEdits(path)()
+
+	status := resource.NewStatus()
+
+	original, updated, err := readEdit(path, edit)
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	if original == updated {
+		return status, nil
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	status.AddDifference(path, original, updated, "")
+
+	if err := WriteAtomic(path, []byte(updated), stat.Mode().Perm(), -1, -1); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	return status, nil
+}
+
+var (
+	editLocks   = map[string]*sync.Mutex{}
+	editLocksMu sync.Mutex
+)
+
+// LockEdits keeps edits to a file from running at the same time, since
+// resources that edit the same file may be applied concurrently and would
+// otherwise overwrite each other's changes. It returns a function to unlock.
+func LockEdits(path string) func() {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	editLocksMu.Lock()
+	lock, ok := editLocks[path]
+	if !ok {
+		lock = new(sync.Mutex)
+		editLocks[path] = lock
+	}
+	editLocksMu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// readEdit reads the file at path and returns its content before and after an
+// edit. If the edit does not change any lines, both are the same.
+func readEdit(path string, edit EditFunc) (original, updated string, err error) {
+	stat, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", "", fmt.Errorf("%q does not exist", path)
+	} else if err != nil {
+		return "", "", errors.Wrapf(err, "cannot read %q", path)
+	} else if stat.IsDir() {
+		return "", "", fmt.Errorf("cannot edit %q, it is a directory", path)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "cannot read %q", path)
+	}
+	original = string(content)
+
+	lines := SplitLines(original)
+	edited, err := edit(append([]string(nil), lines...))
+	if err != nil {
+		return "", "", errors.Wrapf(err, "cannot edit %q", path)
+	}
+
+	if equalLines(lines, edited) {
+		return original, original, nil
+	}
+	return original, JoinLines(edited), nil
+}
+
+// SplitLines splits content into lines, without their newlines
+func SplitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// JoinLines joins lines into content, ending every line with a newline
+func JoinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Insert adds new lines after the last line matching after, or before the
+// first line matching before. If neither is set or nothing matches, the lines
+// are added at the end.
+func Insert(lines, insert []string, after, before *regexp.Regexp) []string {
+	at := len(lines)
+
+	switch {
+	case after != nil:
+		for i := len(lines) - 1; i >= 0; i-- {
+			if after.MatchString(lines[i]) {
+				at = i + 1
+				break
+			}
+		}
+	case before != nil:
+		for i, line := range lines {
+			if before.MatchString(line) {
+				at = i
+				break
+			}
+		}
+	}
+
+	out := make([]string, 0, len(lines)+len(insert))
+	out = append(out, lines[:at]...)
+	out = append(out, insert...)
+	return append(out, lines[at:]...)
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
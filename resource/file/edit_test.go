@@ -0,0 +1,124 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLines tests splitting and joining the lines of a file
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, file.SplitLines(""))
+	assert.Equal(t, []string{"a", "b"}, file.SplitLines("a\nb\n"))
+	assert.Equal(t, []string{"a", "b"}, file.SplitLines("a\nb"))
+	assert.Equal(t, []string{"a", ""}, file.SplitLines("a\n\n"))
+
+	assert.Equal(t, "", file.JoinLines(nil))
+	assert.Equal(t, "a\nb\n", file.JoinLines([]string{"a", "b"}))
+}
+
+// TestInsert tests inserting lines at anchors
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{"a", "b", "a", "c"}
+
+	t.Run("end", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "a", "c", "x"}, file.Insert(lines, []string{"x"}, nil, nil))
+	})
+
+	t.Run("after", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "a", "x", "c"}, file.Insert(lines, []string{"x"}, regexp.MustCompile("^a$"), nil))
+	})
+
+	t.Run("before", func(t *testing.T) {
+		assert.Equal(t, []string{"x", "y", "a", "b", "a", "c"}, file.Insert(lines, []string{"x", "y"}, nil, regexp.MustCompile("^a$")))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "a", "c", "x"}, file.Insert(lines, []string{"x"}, regexp.MustCompile("^z$"), nil))
+	})
+
+	assert.Equal(t, []string{"a", "b", "a", "c"}, lines, "the lines were modified")
+}
+
+// TestEdit tests checking and applying edits to files
+func TestEdit(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-edit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	appendX := func(lines []string) ([]string, error) {
+		return append(lines, "x"), nil
+	}
+	unchanged := func(lines []string) ([]string, error) {
+		return lines, nil
+	}
+
+	t.Run("changes", func(t *testing.T) {
+		path := filepath.Join(dir, "changes")
+		require.NoError(t, ioutil.WriteFile(path, []byte("a"), 0600))
+		require.NoError(t, os.Chmod(path, 0640))
+
+		status, err := file.CheckEdit(path, appendX)
+		require.NoError(t, err)
+		assert.True(t, status.HasChanges())
+		assert.Equal(t, "a", status.Diffs()[path].Original())
+		assert.Equal(t, "a\nx\n", status.Diffs()[path].Current())
+
+		_, err = file.ApplyEdit(path, appendX)
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "a\nx\n", string(content))
+
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), stat.Mode().Perm())
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		path := filepath.Join(dir, "unchanged")
+
+		// a missing trailing newline is not a change by itself
+		require.NoError(t, ioutil.WriteFile(path, []byte("a"), 0600))
+
+		status, err := file.CheckEdit(path, unchanged)
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		path := filepath.Join(dir, "missing")
+
+		status, err := file.CheckEdit(path, unchanged)
+		assert.EqualError(t, err, `"`+path+`" does not exist`)
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"os"
 
 	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
 	"github.com/hashicorp/go-getter"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -49,6 +50,17 @@ const (
 	HashSHA512 Hash = "sha512"
 )
 
+// State type for Fetch
+type State string
+
+const (
+	// StatePresent indicates the file should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the file should be absent
+	StateAbsent State = "absent"
+)
+
 // Fetch gets a file and makes it available on disk
 type Fetch struct {
 	// location of the file to fetch
@@ -70,6 +82,9 @@ type Fetch struct {
 	// whether the fetched file will be unarchived
 	Unarchive bool
 
+	// whether the file should be present
+	State State `export:"state"`
+
 	hasApplied bool
 }
 
@@ -126,6 +141,10 @@ func (f *Fetch) checkWithContext(context.Context, resource.Renderer) (resource.T
 		return status, nil
 	}
 
+	if f.State == StateAbsent {
+		return f.diffAbsent(status)
+	}
+
 	if f.Hash != "" {
 		hsh, err = f.getHash()
 		if err != nil {
@@ -149,6 +168,10 @@ func (f *Fetch) applyWithContext(context.Context) (resource.TaskStatus, error) {
 		mode     = getter.ClientModeFile
 	)
 
+	if f.State == StateAbsent {
+		return f.applyAbsent(status)
+	}
+
 	if f.Hash != "" {
 		hsh, err = f.getHash()
 		if err != nil {
@@ -203,6 +226,46 @@ func (f *Fetch) applyWithContext(context.Context) (resource.TaskStatus, error) {
 	return status, nil
 }
 
+// diffAbsent evaluates whether the destination needs to be removed
+func (f *Fetch) diffAbsent(status *resource.Status) (*resource.Status, error) {
+	stat, err := os.Lstat(f.Destination)
+	switch {
+	case os.IsNotExist(err):
+		status.AddMessage("file does not exist")
+		return status, nil
+
+	case err != nil:
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrapf(err, "could not stat %q", f.Destination)
+
+	case stat.IsDir():
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, fmt.Errorf("invalid destination %q, cannot be directory", f.Destination)
+	}
+
+	status.AddDifference(f.Destination, "<present>", "<absent>", "<present>")
+	status.RaiseLevelForDiffs()
+
+	return status, nil
+}
+
+// applyAbsent removes the destination
+func (f *Fetch) applyAbsent(status *resource.Status) (*resource.Status, error) {
+	stat, err := f.diffAbsent(status)
+	if err != nil || !resource.AnyChanges(stat.Differences) {
+		return stat, err
+	}
+
+	if err := file.Remove(f.Destination, false); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrap(err, "failed to remove")
+	}
+	status.AddMessage("removed successfully")
+	f.hasApplied = true
+
+	return status, nil
+}
+
 // DiffFile evaluates the differences of the file to be fetched and the current
 // state of the system
 func (f *Fetch) DiffFile(status *resource.Status, hsh hash.Hash) (*resource.Status, error) {
@@ -467,6 +467,68 @@ func TestApply(t *testing.T) {
 	})
 }
 
+// TestAbsent tests removing fetched files
+func TestAbsent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("file exists", func(t *testing.T) {
+		dest, err := ioutil.TempFile("", "fetch_test_absent")
+		require.NoError(t, err)
+		defer os.Remove(dest.Name())
+
+		task := fetch.Fetch{
+			Source:      "https://github.com/asteris-llc/converge/releases/download/0.2.0/converge_0.2.0_darwin_amd64.tar.gz",
+			Destination: dest.Name(),
+			State:       fetch.StateAbsent,
+		}
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusWillChange, status.StatusCode())
+		assert.Equal(t, "<present>", status.Diffs()[task.Destination].Original())
+		assert.Equal(t, "<absent>", status.Diffs()[task.Destination].Current())
+
+		status, err = task.Apply(context.Background())
+		require.NoError(t, err)
+		assert.Contains(t, status.Messages(), "removed successfully")
+
+		_, err = os.Stat(task.Destination)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("file does not exist", func(t *testing.T) {
+		task := fetch.Fetch{
+			Source:      "https://github.com/asteris-llc/converge/releases/download/0.2.0/converge_0.2.0_darwin_amd64.tar.gz",
+			Destination: "/tmp/fetch_test_absent_missing",
+			State:       fetch.StateAbsent,
+		}
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges())
+		assert.Contains(t, status.Messages(), "file does not exist")
+	})
+
+	t.Run("dest is directory", func(t *testing.T) {
+		dest, err := ioutil.TempDir("", "fetch_test_absent")
+		require.NoError(t, err)
+		defer os.RemoveAll(dest)
+
+		task := fetch.Fetch{
+			Source:      "https://github.com/asteris-llc/converge/releases/download/0.2.0/converge_0.2.0_darwin_amd64.tar.gz",
+			Destination: dest,
+			State:       fetch.StateAbsent,
+		}
+
+		status, err := task.Apply(context.Background())
+		assert.EqualError(t, err, fmt.Sprintf("invalid destination %q, cannot be directory", dest))
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+
+		_, err = os.Stat(dest)
+		assert.NoError(t, err)
+	})
+}
+
 // TestDiffFile tests DiffFile
 func TestDiffFile(t *testing.T) {
 	t.Parallel()
@@ -29,8 +29,9 @@ import (
 //
 // Fetch is responsible for fetching files
 type Preparer struct {
-	// Source is the location of the file to fetch
-	Source string `hcl:"source" required:"true" nonempty:"true"`
+	// Source is the location of the file to fetch. It is required unless
+	// state is absent.
+	Source string `hcl:"source"`
 
 	// Destination for the fetched file
 	Destination string `hcl:"destination" required:"true" nonempty:"true"`
@@ -47,16 +48,27 @@ type Preparer struct {
 	// 1. no checksum is provided
 	// 2. the checksum of the existing file differs from the checksum provided
 	Force bool `hcl:"force"`
+
+	// State is whether the file should be present. When it is absent, the
+	// file at Destination is removed instead of fetched.
+	// The default value is present.
+	State State `hcl:"state" valid_values:"present,absent"`
 }
 
 // Prepare a new fetch task
 func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
-	if strings.TrimSpace(p.Source) == "" {
-		return nil, errors.New("\"source\" must contain a value")
+	if p.State == "" {
+		p.State = StatePresent
 	}
-	_, err := url.Parse(p.Source)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse \"source\"")
+
+	if p.State == StatePresent {
+		if strings.TrimSpace(p.Source) == "" {
+			return nil, errors.New("\"source\" must contain a value")
+		}
+		_, err := url.Parse(p.Source)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse \"source\"")
+		}
 	}
 
 	if strings.TrimSpace(p.Destination) == "" {
@@ -89,6 +101,7 @@ func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resou
 		Source:      p.Source,
 		Destination: p.Destination,
 		Force:       p.Force,
+		State:       p.State,
 	}
 
 	if p.HashType != nil {
@@ -101,6 +101,16 @@ func TestPreparer(t *testing.T) {
 			_, err := prep.Prepare(context.Background(), &fr)
 			assert.NoError(t, err)
 		})
+
+		t.Run("absent without source", func(t *testing.T) {
+			prep := fetch.Preparer{
+				Destination: "/tmp/converge.tar.gz",
+				State:       fetch.StateAbsent,
+			}
+
+			_, err := prep.Prepare(context.Background(), &fr)
+			assert.NoError(t, err)
+		})
 	})
 
 	t.Run("invalid", func(t *testing.T) {
@@ -0,0 +1,96 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package line
+
+import (
+	"regexp"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"golang.org/x/net/context"
+)
+
+// State type for Line
+type State string
+
+const (
+	// StatePresent indicates the line should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the line should be absent
+	StateAbsent State = "absent"
+)
+
+// Line manages a single line in a file
+type Line struct {
+	// the file to edit
+	Destination string `export:"destination"`
+
+	// the line that should be in the file
+	Line string `export:"line"`
+
+	// whether the line should be present
+	State State `export:"state"`
+
+	match, after, before *regexp.Regexp
+}
+
+// Check if the line needs to be changed
+func (l *Line) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	return file.CheckEdit(l.Destination, l.edit)
+}
+
+// Apply changes the line
+func (l *Line) Apply(context.Context) (resource.TaskStatus, error) {
+	return file.ApplyEdit(l.Destination, l.edit)
+}
+
+// matches tests whether a line is the one being managed
+func (l *Line) matches(line string) bool {
+	if l.match != nil {
+		return l.match.MatchString(line)
+	}
+	return line == l.Line
+}
+
+// edit the lines of the file
+func (l *Line) edit(lines []string) ([]string, error) {
+	if l.State == StateAbsent {
+		out := lines[:0]
+		for _, line := range lines {
+			if !l.matches(line) {
+				out = append(out, line)
+			}
+		}
+		return out, nil
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if l.matches(lines[i]) {
+			lines[i] = l.Line
+			return lines, nil
+		}
+	}
+
+	// the line may already be present without matching, if match does not
+	// match the line itself
+	for _, line := range lines {
+		if line == l.Line {
+			return lines, nil
+		}
+	}
+
+	return file.Insert(lines, []string{l.Line}, l.after, l.before), nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package line_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/line"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestLineInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(line.Line))
+}
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-line")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	const sshd = "Port 22\n#PermitRootLogin yes\nX11Forwarding no\n"
+
+	for _, test := range []struct {
+		name     string
+		prep     *line.Preparer
+		content  string
+		expected string
+	}{
+		{
+			"replace match",
+			&line.Preparer{Line: "PermitRootLogin no", Match: "^#?PermitRootLogin\\s"},
+			sshd,
+			"Port 22\nPermitRootLogin no\nX11Forwarding no\n",
+		},
+		{
+			"replace last match",
+			&line.Preparer{Line: "a = 3", Match: "^a ="},
+			"a = 1\na = 2\nb = 1\n",
+			"a = 1\na = 3\nb = 1\n",
+		},
+		{
+			"already present",
+			&line.Preparer{Line: "X11Forwarding no"},
+			sshd,
+			sshd,
+		},
+		{
+			"present without matching",
+			&line.Preparer{Line: "Port 2222", Match: "^Port 22$"},
+			"Port 2222\n",
+			"Port 2222\n",
+		},
+		{
+			"append",
+			&line.Preparer{Line: "UseDNS no"},
+			sshd,
+			sshd + "UseDNS no\n",
+		},
+		{
+			"insert after",
+			&line.Preparer{Line: "UseDNS no", InsertAfter: "^Port"},
+			sshd,
+			"Port 22\nUseDNS no\n#PermitRootLogin yes\nX11Forwarding no\n",
+		},
+		{
+			"insert before",
+			&line.Preparer{Line: "UseDNS no", InsertBefore: "^X11"},
+			sshd,
+			"Port 22\n#PermitRootLogin yes\nUseDNS no\nX11Forwarding no\n",
+		},
+		{
+			"absent",
+			&line.Preparer{Line: "X11Forwarding no", State: line.StateAbsent},
+			sshd,
+			"Port 22\n#PermitRootLogin yes\n",
+		},
+		{
+			"absent match",
+			&line.Preparer{Match: "^#", State: line.StateAbsent},
+			"#a\nb\n#c\n",
+			"b\n",
+		},
+		{
+			"already absent",
+			&line.Preparer{Line: "UseDNS no", State: line.StateAbsent},
+			sshd,
+			sshd,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dest := filepath.Join(dir, test.name)
+			require.NoError(t, ioutil.WriteFile(dest, []byte(test.content), 0600))
+
+			test.prep.Destination = dest
+			task, err := test.prep.Prepare(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+
+			status, err := task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.Equal(t, test.content != test.expected, status.HasChanges())
+
+			_, err = task.Apply(context.Background())
+			require.NoError(t, err)
+
+			actual, err := ioutil.ReadFile(dest)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+
+			status, err = task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.False(t, status.HasChanges(), "not idempotent")
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package line
+
+import (
+	"regexp"
+
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Preparer for Line
+//
+// Line makes sure a single line is present in a file, or absent from it,
+// without managing the rest of the file
+type Preparer struct {
+	// Destination is the file to edit. It must already exist.
+	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// Line is the line that should be in the file. It is required when state
+	// is present.
+	Line string `hcl:"line"`
+
+	// Match is a regular expression for the line to replace when state is
+	// present, or the lines to remove when state is absent. When several lines
+	// match, only the last one is replaced. When it is not set, only lines
+	// equal to Line match.
+	Match string `hcl:"match"`
+
+	// InsertAfter is a regular expression. When no line matches, Line is
+	// inserted after the last line matching it. If no line matches it either,
+	// Line is added to the end of the file.
+	InsertAfter string `hcl:"insert_after" mutually_exclusive:"insert_after,insert_before"`
+
+	// InsertBefore is a regular expression. When no line matches, Line is
+	// inserted before the first line matching it. If no line matches it either,
+	// Line is added to the end of the file.
+	InsertBefore string `hcl:"insert_before" mutually_exclusive:"insert_after,insert_before"`
+
+	// State is whether the line should be present or absent. The default value
+	// is present.
+	State State `hcl:"state" valid_values:"present,absent"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.State == "" {
+		p.State = StatePresent
+	}
+
+	if p.State == StatePresent && p.Line == "" {
+		return nil, errors.New("\"line\" is required when \"state\" is present")
+	}
+
+	if p.State == StateAbsent && p.Line == "" && p.Match == "" {
+		return nil, errors.New("\"line\" or \"match\" is required when \"state\" is absent")
+	}
+
+	if p.State == StateAbsent && (p.InsertAfter != "" || p.InsertBefore != "") {
+		return nil, errors.New("\"insert_after\" and \"insert_before\" are only valid when \"state\" is present")
+	}
+
+	line := &Line{
+		Destination: p.Destination,
+		Line:        p.Line,
+		State:       p.State,
+	}
+
+	var err error
+	if line.match, err = compile("match", p.Match); err != nil {
+		return nil, err
+	}
+	if line.after, err = compile("insert_after", p.InsertAfter); err != nil {
+		return nil, err
+	}
+	if line.before, err = compile("insert_before", p.InsertBefore); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// compile a regular expression, or return nil if it is not set
+func compile(field, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %q", field)
+	}
+	return re, nil
+}
+
+func init() {
+	registry.Register("file.line", (*Preparer)(nil), (*Line)(nil))
+}
@@ -0,0 +1,73 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package line_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/line"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(line.Preparer))
+}
+
+func TestPreparerPrepare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		prep := &line.Preparer{Destination: "/tmp/x", Line: "x"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, line.StatePresent, task.(*line.Line).State)
+	})
+
+	t.Run("present without line", func(t *testing.T) {
+		prep := &line.Preparer{Destination: "/tmp/x", Match: "x"}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"line" is required when "state" is present`)
+	})
+
+	t.Run("absent without line or match", func(t *testing.T) {
+		prep := &line.Preparer{Destination: "/tmp/x", State: line.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"line" or "match" is required when "state" is absent`)
+	})
+
+	t.Run("absent with anchor", func(t *testing.T) {
+		prep := &line.Preparer{Destination: "/tmp/x", Line: "x", InsertAfter: "y", State: line.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"insert_after" and "insert_before" are only valid when "state" is present`)
+	})
+
+	t.Run("invalid match", func(t *testing.T) {
+		prep := &line.Preparer{Destination: "/tmp/x", Line: "x", Match: "("}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid "match"`)
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package link
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Type of a link
+type Type string
+
+const (
+	// TypeSymbolic is a symbolic link
+	TypeSymbolic Type = "symbolic"
+
+	// TypeHard is a hard link
+	TypeHard Type = "hard"
+)
+
+// State type for Link
+type State string
+
+const (
+	// StatePresent indicates the link should be present
+	StatePresent State = "present"
+
+	// StateAbsent indicates the link should be absent
+	StateAbsent State = "absent"
+)
+
+// absent is the target of a link that does not exist
+const absent = "<absent>"
+
+// Link manages a symbolic or hard link
+type Link struct {
+	// what the link points to
+	Source string `export:"source"`
+
+	// the location of the link
+	Destination string `export:"destination"`
+
+	// the type of the link
+	Type Type `export:"type"`
+
+	// whether the link should be present
+	State State `export:"state"`
+
+	// whether files and other links at the destination are replaced
+	Force bool `export:"force"`
+}
+
+// Check the current target of the link
+func (l *Link) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	current, err := l.current()
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	if l.State == StateAbsent {
+		return l.checkAbsent(status, current)
+	}
+
+	if current.target != absent {
+		status.AddMessage(fmt.Sprintf("%q links to %q", l.Destination, current.target))
+	}
+
+	switch {
+	case current.linked:
+		status.AddMessage("OK")
+		return status, nil
+
+	case current.dir:
+		status.RaiseLevel(resource.StatusCantChange)
+		status.AddMessage(fmt.Sprintf("%q is a directory and will not be replaced", l.Destination))
+		return status, nil
+
+	case current.target != absent && !l.Force:
+		status.RaiseLevel(resource.StatusCantChange)
+		status.AddMessage(fmt.Sprintf("%q already exists, use the \"force\" option to replace it", l.Destination))
+		return status, nil
+	}
+
+	if _, err := os.Stat(filepath.Dir(l.Destination)); os.IsNotExist(err) {
+		status.RaiseLevel(resource.StatusCantChange)
+		status.AddMessage(fmt.Sprintf("%q does not exist", filepath.Dir(l.Destination)))
+		return status, nil
+	}
+
+	status.AddDifference("target", current.target, l.Source, absent)
+	status.RaiseLevelForDiffs()
+	return status, nil
+}
+
+// checkAbsent checks whether the link needs to be removed
+func (l *Link) checkAbsent(status *resource.Status, current *target) (resource.TaskStatus, error) {
+	switch {
+	case current.target == absent:
+		status.AddMessage(fmt.Sprintf("%q does not exist", l.Destination))
+		return status, nil
+
+	case current.dir:
+		status.RaiseLevel(resource.StatusCantChange)
+		status.AddMessage(fmt.Sprintf("%q is a directory and will not be removed", l.Destination))
+		return status, nil
+
+	case !current.link && !l.Force:
+		status.RaiseLevel(resource.StatusCantChange)
+		status.AddMessage(fmt.Sprintf("%q is not a %s link, use the \"force\" option to remove it", l.Destination, l.Type))
+		return status, nil
+	}
+
+	status.AddDifference("target", current.target, absent, absent)
+	status.RaiseLevelForDiffs()
+	return status, nil
+}
+
+// Apply creates, replaces, or removes the link
+func (l *Link) Apply(context.Context) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	current, err := l.current()
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	if l.State == StateAbsent {
+		switch {
+		case current.target == absent:
+			return status, nil
+
+		case current.dir:
+			status.RaiseLevel(resource.StatusFatal)
+			return status, fmt.Errorf("%q is a directory and will not be removed", l.Destination)
+
+		case !current.link && !l.Force:
+			status.RaiseLevel(resource.StatusFatal)
+			return status, fmt.Errorf("%q is not a %s link, use the \"force\" option to remove it", l.Destination, l.Type)
+		}
+
+		if err := os.Remove(l.Destination); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not remove %q", l.Destination)
+		}
+
+		status.AddDifference("target", current.target, absent, absent)
+		return status, nil
+	}
+
+	if current.linked {
+		return status, nil
+	}
+
+	switch {
+	case current.dir:
+		status.RaiseLevel(resource.StatusFatal)
+		return status, fmt.Errorf("%q is a directory and will not be replaced", l.Destination)
+
+	case current.target != absent && !l.Force:
+		status.RaiseLevel(resource.StatusFatal)
+		return status, fmt.Errorf("%q already exists, use the \"force\" option to replace it", l.Destination)
+	}
+
+	if err := l.swap(); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	status.AddDifference("target", current.target, l.Source, absent)
+	return status, nil
+}
+
+// target describes what is at the destination
+type target struct {
+	// what the destination points to, as it is shown in differences
+	target string
+
+	// whether the destination is a link of the right type
+	link bool
+
+	// whether the destination is a link of the right type to the source
+	linked bool
+
+	// whether the destination is a directory, and not a link to one
+	dir bool
+}
+
+// current finds what the destination points to now
+func (l *Link) current() (*target, error) {
+	stat, err := os.Lstat(l.Destination)
+	if os.IsNotExist(err) {
+		return &target{target: absent}, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "could not stat %q", l.Destination)
+	}
+
+	if stat.Mode()&os.ModeSymlink != 0 {
+		dest, err := os.Readlink(l.Destination)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read link %q", l.Destination)
+		}
+
+		return &target{
+			target: dest,
+			link:   l.Type == TypeSymbolic,
+			linked: l.Type == TypeSymbolic && dest == l.Source,
+		}, nil
+	}
+
+	if stat.IsDir() {
+		return &target{target: "<directory>", dir: true}, nil
+	}
+
+	if l.Type == TypeHard {
+		if l.Source != "" {
+			if source, err := os.Stat(l.Source); err == nil && os.SameFile(source, stat) {
+				return &target{target: l.Source, link: true, linked: true}, nil
+			}
+		}
+
+		// without a source, any file with more than one name is a hard link
+		if l.Source == "" && links(stat) > 1 {
+			return &target{target: "<hard link>", link: true}, nil
+		}
+	}
+
+	return &target{target: "<file>"}, nil
+}
+
+// links returns the number of names a file has
+func links(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat != nil {
+		return uint64(stat.Nlink)
+	}
+	return 1
+}
+
+// swap replaces the destination with a new link. The link is made with a
+// temporary name in the same directory and renamed over the destination, so
+// the destination always exists while it is being replaced.
+func (l *Link) swap() error {
+	dir, base := filepath.Split(l.Destination)
+	if dir == "" {
+		dir = "."
+	}
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var tmp string
+	for attempt := 0; ; attempt++ {
+		tmp = filepath.Join(dir, "."+base+".converge-"+strconv.Itoa(random.Int()))
+
+		var err error
+		if l.Type == TypeHard {
+			err = os.Link(l.Source, tmp)
+		} else {
+			err = os.Symlink(l.Source, tmp)
+		}
+
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) || attempt >= 10 {
+			return errors.Wrapf(err, "could not link %q to %q", l.Destination, l.Source)
+		}
+	}
+
+	// renaming a hard link over another name for the same file does nothing,
+	// so the temporary link is removed either way
+	defer os.Remove(tmp)
+
+	if err := os.Rename(tmp, l.Destination); err != nil {
+		return errors.Wrapf(err, "could not link %q to %q", l.Destination, l.Source)
+	}
+
+	return nil
+}
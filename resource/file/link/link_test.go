@@ -0,0 +1,210 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package link_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/link"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestLinkInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(link.Link))
+}
+
+func TestLinkSymbolic(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-link")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dest := filepath.Join(dir, "current")
+
+	t.Run("create", func(t *testing.T) {
+		task := &link.Link{Source: "releases/1", Destination: dest, Type: link.TypeSymbolic, State: link.StatePresent}
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusWillChange, status.StatusCode())
+		assert.Equal(t, "<absent>", status.Diffs()["target"].Original())
+		assert.Equal(t, "releases/1", status.Diffs()["target"].Current())
+
+		_, err = task.Apply(context.Background())
+		require.NoError(t, err)
+		assertLink(t, dest, "releases/1")
+
+		status, err = task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges(), "not idempotent")
+		assert.Contains(t, status.Messages(), `"`+dest+`" links to "releases/1"`)
+	})
+
+	t.Run("wrong link", func(t *testing.T) {
+		task := &link.Link{Source: "releases/2", Destination: dest, Type: link.TypeSymbolic, State: link.StatePresent}
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+
+		task.Force = true
+		status, err = task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusWillChange, status.StatusCode())
+		assert.Equal(t, "releases/1", status.Diffs()["target"].Original())
+		assert.Equal(t, "releases/2", status.Diffs()["target"].Current())
+
+		_, err = task.Apply(context.Background())
+		require.NoError(t, err)
+		assertLink(t, dest, "releases/2")
+
+		// the temporary link is renamed, so nothing is left behind
+		entries, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		task := &link.Link{Destination: dest, Type: link.TypeSymbolic, State: link.StateAbsent}
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, "releases/2", status.Diffs()["target"].Original())
+		assert.Equal(t, "<absent>", status.Diffs()["target"].Current())
+
+		_, err = task.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = os.Lstat(dest)
+		assert.True(t, os.IsNotExist(err))
+
+		status, err = task.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges(), "not idempotent")
+	})
+}
+
+func TestLinkHard(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-link")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "source")
+	require.NoError(t, ioutil.WriteFile(source, []byte("x"), 0600))
+
+	dest := filepath.Join(dir, "dest")
+	require.NoError(t, ioutil.WriteFile(dest, []byte("y"), 0600))
+
+	task := &link.Link{Source: source, Destination: dest, Type: link.TypeHard, State: link.StatePresent}
+
+	status, err := task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+
+	task.Force = true
+	status, err = task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, "<file>", status.Diffs()["target"].Original())
+	assert.Equal(t, source, status.Diffs()["target"].Current())
+
+	_, err = task.Apply(context.Background())
+	require.NoError(t, err)
+
+	sourceStat, err := os.Stat(source)
+	require.NoError(t, err)
+	destStat, err := os.Stat(dest)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(sourceStat, destStat))
+
+	status, err = task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.False(t, status.HasChanges(), "not idempotent")
+
+	// removing the link leaves the source
+	task = &link.Link{Source: source, Destination: dest, Type: link.TypeHard, State: link.StateAbsent}
+	_, err = task.Apply(context.Background())
+	require.NoError(t, err)
+
+	_, err = os.Stat(source)
+	assert.NoError(t, err)
+}
+
+func TestLinkDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-link")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	task := &link.Link{Source: "/tmp", Destination: dir, Type: link.TypeSymbolic, State: link.StatePresent, Force: true}
+
+	status, err := task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+
+	task.State = link.StateAbsent
+	status, err = task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+}
+
+func TestLinkWithoutForce(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-link")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dest := filepath.Join(dir, "current")
+	require.NoError(t, ioutil.WriteFile(dest, []byte("x"), 0600))
+
+	t.Run("present", func(t *testing.T) {
+		task := &link.Link{Source: "releases/1", Destination: dest, Type: link.TypeSymbolic, State: link.StatePresent}
+
+		status, err := task.Apply(context.Background())
+		assert.EqualError(t, err, `"`+dest+`" already exists, use the "force" option to replace it`)
+		assert.Equal(t, resource.StatusFatal, status.StatusCode())
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		task := &link.Link{Destination: dest, Type: link.TypeSymbolic, State: link.StateAbsent}
+
+		status, err := task.Apply(context.Background())
+		assert.EqualError(t, err, `"`+dest+`" is not a symbolic link, use the "force" option to remove it`)
+		assert.Equal(t, resource.StatusFatal, status.StatusCode())
+	})
+
+	content, err := ioutil.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "x", string(content))
+}
+
+func assertLink(t *testing.T, path, expected string) {
+	actual, err := os.Readlink(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package link
+
+import (
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Preparer for Link
+//
+// Link makes sure a symbolic or hard link is present on disk, or absent. Links
+// are replaced atomically, so a link like `/opt/app/current` can be switched
+// between releases without ever being missing.
+type Preparer struct {
+	// Source is what the link points to. Symbolic links are created with Source
+	// as it is written, so a relative path is relative to the directory of the
+	// link. Hard links need Source to be an existing file. It is required when
+	// state is present.
+	Source string `hcl:"source"`
+
+	// Destination is the location of the link
+	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// Type is the type of the link. The default value is symbolic.
+	Type Type `hcl:"type" valid_values:"symbolic,hard"`
+
+	// State is whether the link should be present or absent. The default value
+	// is present.
+	State State `hcl:"state" valid_values:"present,absent"`
+
+	// Force replaces a file or a link to something else at Destination when
+	// state is present, and removes a file that is not a link when state is
+	// absent. Directories are never replaced or removed.
+	Force bool `hcl:"force"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.Type == "" {
+		p.Type = TypeSymbolic
+	}
+
+	if p.State == "" {
+		p.State = StatePresent
+	}
+
+	if p.State == StatePresent && p.Source == "" {
+		return nil, errors.New("\"source\" is required when \"state\" is present")
+	}
+
+	return &Link{
+		Source:      p.Source,
+		Destination: p.Destination,
+		Type:        p.Type,
+		State:       p.State,
+		Force:       p.Force,
+	}, nil
+}
+
+func init() {
+	registry.Register("file.link", (*Preparer)(nil), (*Link)(nil))
+}
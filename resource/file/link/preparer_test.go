@@ -0,0 +1,59 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package link_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/link"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(link.Preparer))
+}
+
+func TestPreparerPrepare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		prep := &link.Preparer{Source: "/tmp/x", Destination: "/tmp/y"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, link.TypeSymbolic, task.(*link.Link).Type)
+		assert.Equal(t, link.StatePresent, task.(*link.Link).State)
+	})
+
+	t.Run("present without source", func(t *testing.T) {
+		prep := &link.Preparer{Destination: "/tmp/y"}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"source" is required when "state" is present`)
+	})
+
+	t.Run("absent without source", func(t *testing.T) {
+		prep := &link.Preparer{Destination: "/tmp/y", State: link.StateAbsent}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.NoError(t, err)
+	})
+}
@@ -31,6 +31,9 @@ type Mode struct {
 
 	// the mode that the file or directory should be configured with
 	Mode os.FileMode `export:"mode"`
+
+	// the mode before Apply, for Rollback
+	previous *os.FileMode
 }
 
 // Check whether the Destination has the right Mode
@@ -71,7 +74,10 @@ func (t *Mode) Check(context.Context, resource.Renderer) (resource.TaskStatus, e
 
 // Apply the changes the Mode
 func (t *Mode) Apply(context.Context) (resource.TaskStatus, error) {
-	err := os.Chmod(t.Destination, t.Mode.Perm())
+	stat, err := os.Stat(t.Destination)
+	if err == nil {
+		err = os.Chmod(t.Destination, t.Mode.Perm())
+	}
 
 	if err != nil {
 		return &resource.Status{
@@ -80,9 +86,35 @@ func (t *Mode) Apply(context.Context) (resource.TaskStatus, error) {
 		}, err
 	}
 
+	previous := stat.Mode().Perm()
+	t.previous = &previous
+
 	return t, nil
 }
 
+// Rollback restores the mode the file had before Apply
+func (t *Mode) Rollback(context.Context) (resource.TaskStatus, error) {
+	if t.previous == nil {
+		return &resource.Status{Output: []string{"nothing to roll back"}}, nil
+	}
+
+	if err := os.Chmod(t.Destination, *t.previous); err != nil {
+		return &resource.Status{
+			Level:  resource.StatusFatal,
+			Output: []string{fmt.Sprintf("failed to restore mode on %s: %s", t.Destination, err)},
+		}, err
+	}
+
+	status := &resource.Status{
+		Differences: map[string]resource.Diff{
+			t.Destination: &FileModeDiff{Actual: t.Mode.Perm(), Expected: *t.previous},
+		},
+	}
+	t.previous = nil
+
+	return status, nil
+}
+
 // Validate Mode
 func (t *Mode) Validate() error {
 	if t.Destination == "" {
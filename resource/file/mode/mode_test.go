@@ -65,3 +65,22 @@ func TestApply(t *testing.T) {
 	assert.Contains(t, status.Messages(), fmt.Sprintf("%q's mode is \"-rwxrwxrwx\" expected \"-rwxrwxrwx\"", tmpfile.Name()))
 	assert.False(t, status.HasChanges())
 }
+
+// TestRollback tests Rollback() for file mode
+func TestRollback(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "mode_test")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	require.NoError(t, os.Chmod(tmpfile.Name(), 0600))
+
+	mode := mode.Mode{Destination: tmpfile.Name(), Mode: os.FileMode(int(0777))}
+	_, err = mode.Apply(context.Background())
+	require.NoError(t, err)
+
+	_, err = mode.Rollback(context.Background())
+	require.NoError(t, err)
+
+	stat, err := os.Stat(tmpfile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+}
@@ -0,0 +1,98 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package file has helpers shared by the file resources
+package file
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotEmpty is returned by Remove for directories that aren't empty when
+// removal is not recursive
+type ErrNotEmpty struct {
+	Path string
+}
+
+func (e *ErrNotEmpty) Error() string {
+	return fmt.Sprintf("%q is not empty", e.Path)
+}
+
+// IsEmptyDir tests whether the directory at path has no entries
+func IsEmptyDir(path string) (bool, error) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer dir.Close()
+
+	_, err = dir.Readdirnames(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
+
+// Remove removes the file or directory at path. Directories that aren't empty
+// are only removed if recursive is set. In that case, the directory is first
+// renamed to a temporary name next to it, so that it disappears all at once
+// even if removing what's in it takes a while or fails partway. Removing a
+// path that doesn't exist is not an error.
+func Remove(path string, recursive bool) error {
+	stat, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !stat.IsDir() {
+		return os.Remove(path)
+	}
+
+	empty, err := IsEmptyDir(path)
+	if err != nil {
+		return err
+	}
+
+	if empty {
+		return os.Remove(path)
+	}
+
+	if !recursive {
+		return &ErrNotEmpty{Path: path}
+	}
+
+	trash := filepath.Join(
+		filepath.Dir(path),
+		fmt.Sprintf(".%s.converge-removing-%d", filepath.Base(path), time.Now().UnixNano()),
+	)
+
+	// if the directory can't be renamed (say, because it's a mount point), it's
+	// removed in place
+	if err := os.Rename(path, trash); err != nil {
+		return os.RemoveAll(path)
+	}
+
+	if err := os.RemoveAll(trash); err != nil {
+		return errors.Wrapf(err, "%q was removed, but some of its contents are left in %q", path, trash)
+	}
+	return nil
+}
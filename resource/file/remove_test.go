@@ -0,0 +1,78 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemove tests removing files and directories
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-remove")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(dir, "file")
+		require.NoError(t, ioutil.WriteFile(path, []byte("x"), 0600))
+
+		assert.NoError(t, file.Remove(path, false))
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		assert.NoError(t, file.Remove(filepath.Join(dir, "missing"), false))
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		path := filepath.Join(dir, "empty")
+		require.NoError(t, os.Mkdir(path, 0700))
+
+		assert.NoError(t, file.Remove(path, false))
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("full directory", func(t *testing.T) {
+		path := filepath.Join(dir, "full")
+		require.NoError(t, os.MkdirAll(filepath.Join(path, "sub"), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(path, "sub", "file"), []byte("x"), 0600))
+
+		err := file.Remove(path, false)
+		assert.IsType(t, &file.ErrNotEmpty{}, err)
+		assert.EqualError(t, err, `"`+path+`" is not empty`)
+
+		assert.NoError(t, file.Remove(path, true))
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+
+		// nothing is left behind in the parent
+		entries, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			assert.NotContains(t, entry.Name(), "converge-removing")
+		}
+	})
+}
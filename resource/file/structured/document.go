@@ -0,0 +1,332 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Format of a structured file
+type Format string
+
+const (
+	// FormatINI is for INI files. Keys are in sections, which are named by
+	// every part of the key path but the last.
+	FormatINI Format = "ini"
+
+	// FormatJSON is for JSON files
+	FormatJSON Format = "json"
+
+	// FormatYAML is for YAML files
+	FormatYAML Format = "yaml"
+
+	// FormatTOML is for TOML files
+	FormatTOML Format = "toml"
+)
+
+// FormatFor returns the format of a file by its extension
+func FormatFor(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ini":
+		return FormatINI, true
+	case ".json":
+		return FormatJSON, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".toml":
+		return FormatTOML, true
+	}
+	return "", false
+}
+
+// document is a parsed structured file
+type document interface {
+	// Get the value at a key path, if it is set
+	Get(path []string) (interface{}, bool)
+
+	// Set the value at a key path
+	Set(path []string, value interface{}) error
+
+	// Delete the value at a key path
+	Delete(path []string)
+
+	// Convert a value from a module to the types of the document
+	Convert(value interface{}) (interface{}, error)
+
+	// Bytes encodes the document
+	Bytes() ([]byte, error)
+}
+
+// parse the content of a file in a format. Empty content is an empty document.
+func parse(format Format, content []byte) (document, error) {
+	switch format {
+	case FormatINI:
+		return parseINI(content), nil
+	case FormatJSON:
+		return parseJSON(content)
+	case FormatYAML:
+		return parseYAML(content)
+	case FormatTOML:
+		return parseTOML(content)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// SplitPath splits a key path at dots. A dot that is part of a key is escaped
+// with a backslash, as in `labels.com\.example\.team`.
+func SplitPath(path string) ([]string, error) {
+	var (
+		parts   []string
+		current bytes.Buffer
+	)
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case path[i] == '.':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	parts = append(parts, current.String())
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid key path %q: keys cannot be empty", path)
+		}
+	}
+
+	return parts, nil
+}
+
+// object is a map that keeps the order of its keys, so rewriting a file does
+// not reorder it
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: map[string]interface{}{}}
+}
+
+func (o *object) get(key string) (interface{}, bool) {
+	val, ok := o.values[key]
+	return val, ok
+}
+
+func (o *object) set(key string, val interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = val
+}
+
+func (o *object) delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// tree is a document made of nested objects, which is how JSON, YAML, and TOML
+// are edited
+type tree struct {
+	root   *object
+	encode func(*object) ([]byte, error)
+}
+
+func (t *tree) Get(path []string) (interface{}, bool) {
+	var current interface{} = t.root
+	for _, key := range path {
+		obj, ok := current.(*object)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj.get(key); !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func (t *tree) Set(path []string, value interface{}) error {
+	obj := t.root
+	for i, key := range path[:len(path)-1] {
+		next, ok := obj.get(key)
+		if !ok {
+			next = newObject()
+			obj.set(key, next)
+		}
+
+		if obj, ok = next.(*object); !ok {
+			return fmt.Errorf("cannot set %q: %q is not an object", strings.Join(path, "."), strings.Join(path[:i+1], "."))
+		}
+	}
+
+	obj.set(path[len(path)-1], value)
+	return nil
+}
+
+func (t *tree) Delete(path []string) {
+	parent, ok := t.Get(path[:len(path)-1])
+	if !ok {
+		return
+	}
+	if obj, ok := parent.(*object); ok {
+		obj.delete(path[len(path)-1])
+	}
+}
+
+// Convert maps to objects, with their keys sorted, and lists to the lists of
+// the document
+func (t *tree) Convert(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		obj := newObject()
+		for _, key := range keys {
+			converted, err := t.Convert(val[key])
+			if err != nil {
+				return nil, err
+			}
+			obj.set(key, converted)
+		}
+		return obj, nil
+
+	case []map[string]interface{}:
+		// HCL decodes a map inside another as a list of one map
+		if len(val) == 1 {
+			return t.Convert(val[0])
+		}
+
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			converted, err := t.Convert(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			converted, err := t.Convert(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = rv.Index(i).Interface()
+		}
+		return t.Convert(out)
+	}
+
+	return value, nil
+}
+
+func (t *tree) Bytes() ([]byte, error) {
+	return t.encode(t.root)
+}
+
+// normalize values so they can be compared across formats: objects become
+// maps, and all numbers become float64
+func normalize(value interface{}) interface{} {
+	switch val := value.(type) {
+	case *object:
+		out := map[string]interface{}{}
+		for _, key := range val.keys {
+			out[key] = normalize(val.values[key])
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = normalize(item)
+		}
+		return out
+	case json.Number:
+		if f, err := val.Float64(); err == nil {
+			return f
+		}
+		return val.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+
+	return value
+}
+
+// equal tests whether two values are the same, regardless of their formats
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// display returns a value as it is shown in differences: strings as they are,
+// and everything else as compact JSON
+func display(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	return quoted(value)
+}
+
+// quoted returns a value as compact JSON, so strings are quoted
+func quoted(value interface{}) string {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, value, "", 0); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return buf.String()
+}
@@ -0,0 +1,262 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// iniDocument edits INI files line by line, so comments and formatting are
+// kept. Keys before the first section header are in the default section, which
+// is named by a key path with a single part.
+type iniDocument struct {
+	lines []string
+}
+
+func parseINI(content []byte) document {
+	doc := new(iniDocument)
+	if len(content) > 0 {
+		doc.lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+	return doc
+}
+
+// iniLine is a key line in an INI file
+type iniLine struct {
+	index   int
+	section string
+	key     string
+	value   string
+}
+
+// section returns the name of a section header, if the line is one
+func iniSection(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+		return strings.TrimSpace(trimmed[1 : len(trimmed)-1]), true
+	}
+	return "", false
+}
+
+// iniSeparator returns the index of the separator between the key and value
+// of a line, or -1 if it has none
+func iniSeparator(line string) int {
+	if i := strings.Index(line, "="); i >= 0 {
+		return i
+	}
+	return strings.Index(line, ":")
+}
+
+func iniComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";")
+}
+
+// keys returns the key lines of the file, in order
+func (d *iniDocument) keys() []iniLine {
+	var (
+		out     []iniLine
+		section string
+	)
+
+	for i, line := range d.lines {
+		if name, ok := iniSection(line); ok {
+			section = name
+			continue
+		}
+		if iniComment(line) {
+			continue
+		}
+
+		sep := iniSeparator(line)
+		if sep < 0 {
+			continue
+		}
+
+		out = append(out, iniLine{
+			index:   i,
+			section: section,
+			key:     strings.TrimSpace(line[:sep]),
+			value:   strings.TrimSpace(line[sep+1:]),
+		})
+	}
+
+	return out
+}
+
+// splitINIPath splits a key path into a section and a key
+func splitINIPath(path []string) (section, key string) {
+	return strings.Join(path[:len(path)-1], "."), path[len(path)-1]
+}
+
+// find the last line that sets a key, since that is the one that wins
+func (d *iniDocument) find(section, key string) (iniLine, bool) {
+	var (
+		found iniLine
+		ok    bool
+	)
+	for _, line := range d.keys() {
+		if line.section == section && line.key == key {
+			found, ok = line, true
+		}
+	}
+	return found, ok
+}
+
+// sectionBounds returns the index of a section header, and the index of the
+// last non-blank line in it. The default section has a header index of -1.
+func (d *iniDocument) sectionBounds(section string) (header, last int, ok bool) {
+	header, last = -1, -1
+	ok = section == ""
+	current := ""
+
+	for i, line := range d.lines {
+		if name, isSection := iniSection(line); isSection {
+			if ok {
+				return header, last, true
+			}
+			if name == section {
+				header, last, ok = i, i, true
+			}
+			current = name
+			continue
+		}
+
+		if current == section && ok && strings.TrimSpace(line) != "" {
+			last = i
+		}
+	}
+
+	return header, last, ok
+}
+
+func (d *iniDocument) Get(path []string) (interface{}, bool) {
+	section, key := splitINIPath(path)
+	if line, ok := d.find(section, key); ok {
+		return line.value, true
+	}
+
+	// the path might name a whole section
+	name := strings.Join(path, ".")
+	if _, _, ok := d.sectionBounds(name); !ok || name == "" {
+		return nil, false
+	}
+
+	obj := newObject()
+	for _, line := range d.keys() {
+		if line.section == name {
+			obj.set(line.key, line.value)
+		}
+	}
+	return obj, true
+}
+
+func (d *iniDocument) Set(path []string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot set %q: INI values must be strings", strings.Join(path, "."))
+	}
+
+	section, key := splitINIPath(path)
+
+	// replace the value, keeping the key and separator as they were
+	if line, ok := d.find(section, key); ok {
+		text := d.lines[line.index]
+		sep := iniSeparator(text)
+		prefix := text[:sep+1]
+		if rest := text[sep+1:]; strings.HasPrefix(rest, " ") {
+			prefix += " "
+		}
+		d.lines[line.index] = prefix + str
+		return nil
+	}
+
+	entry := key + " = " + str
+
+	_, last, ok := d.sectionBounds(section)
+	if !ok {
+		if len(d.lines) > 0 && strings.TrimSpace(d.lines[len(d.lines)-1]) != "" {
+			d.lines = append(d.lines, "")
+		}
+		d.lines = append(d.lines, "["+section+"]", entry)
+		return nil
+	}
+
+	d.lines = append(d.lines[:last+1], append([]string{entry}, d.lines[last+1:]...)...)
+	return nil
+}
+
+func (d *iniDocument) Delete(path []string) {
+	section, key := splitINIPath(path)
+
+	var remove []int
+	for _, line := range d.keys() {
+		if line.section == section && line.key == key {
+			remove = append(remove, line.index)
+		}
+	}
+
+	// a path can name a whole section, which is removed with its keys
+	if len(remove) == 0 {
+		name := strings.Join(path, ".")
+		header, _, ok := d.sectionBounds(name)
+		if !ok || name == "" {
+			return
+		}
+
+		end := len(d.lines)
+		for i := header + 1; i < len(d.lines); i++ {
+			if _, isSection := iniSection(d.lines[i]); isSection {
+				end = i
+				break
+			}
+		}
+
+		// the blank lines that separated the section go with it
+		start := header
+		for start > 0 && strings.TrimSpace(d.lines[start-1]) == "" {
+			start--
+		}
+
+		for i := start; i < end; i++ {
+			remove = append(remove, i)
+		}
+	}
+
+	for i := len(remove) - 1; i >= 0; i-- {
+		d.lines = append(d.lines[:remove[i]], d.lines[remove[i]+1:]...)
+	}
+}
+
+// Convert values to strings, since INI has no other types
+func (d *iniDocument) Convert(value interface{}) (interface{}, error) {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value), nil
+	}
+	return nil, fmt.Errorf("only strings, numbers, and booleans can be set in INI files, not %T", value)
+}
+
+func (d *iniDocument) Bytes() ([]byte, error) {
+	if len(d.lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(d.lines, "\n") + "\n"), nil
+}
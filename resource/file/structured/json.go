@@ -0,0 +1,202 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// parseJSON parses a JSON object, keeping the order of its keys and the text of
+// its numbers
+func parseJSON(content []byte) (document, error) {
+	indent := jsonIndent(content)
+	encode := func(root *object) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, root, indent, 0); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return &tree{root: newObject(), encode: encode}, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	val, err := decodeJSON(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := val.(*object)
+	if !ok {
+		return nil, errors.New("the top level of the file must be an object")
+	}
+
+	if dec.More() {
+		return nil, errors.New("unexpected content after the top-level object")
+	}
+
+	return &tree{root: root, encode: encode}, nil
+}
+
+// decodeJSON decodes the next value from dec
+func decodeJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := newObject()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			val, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.set(key.(string), val)
+		}
+		_, err = dec.Token()
+		return obj, err
+
+	case '[':
+		list := []interface{}{}
+		for dec.More() {
+			val, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+
+	return nil, fmt.Errorf("unexpected %q", delim)
+}
+
+// jsonIndent returns the indentation of the first indented line of content, so
+// files keep their indentation when they're rewritten
+func jsonIndent(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// writeJSON writes a value as JSON. Objects and lists are written one item per
+// line with indent, or on a single line if indent is empty.
+func writeJSON(buf *bytes.Buffer, value interface{}, indent string, depth int) error {
+	newline := func(depth int) {
+		if indent != "" {
+			buf.WriteByte('\n')
+			buf.WriteString(strings.Repeat(indent, depth))
+		}
+	}
+
+	switch val := value.(type) {
+	case *object:
+		if len(val.keys) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+
+		buf.WriteByte('{')
+		for i, key := range val.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			newline(depth + 1)
+
+			if err := writeJSONScalar(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if indent != "" {
+				buf.WriteByte(' ')
+			}
+
+			if err := writeJSON(buf, val.values[key], indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		buf.WriteByte('}')
+
+	case []interface{}:
+		if len(val) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			newline(depth + 1)
+
+			if err := writeJSON(buf, item, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		buf.WriteByte(']')
+
+	default:
+		return writeJSONScalar(buf, value)
+	}
+
+	return nil
+}
+
+// writeJSONScalar writes a value that isn't an object or a list, without
+// escaping HTML
+func writeJSONScalar(buf *bytes.Buffer, value interface{}) error {
+	if num, ok := value.(json.Number); ok {
+		buf.WriteString(num.String())
+		return nil
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+	return nil
+}
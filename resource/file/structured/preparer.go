@@ -0,0 +1,101 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Preparer for Structured
+//
+// Structured sets and removes values in a structured file, like a JSON or INI
+// configuration file, without managing the rest of the file. Keys that are not
+// named are left as they are. Comments are kept in INI files, but not in YAML
+// or TOML files, which are rewritten from their parsed values.
+type Preparer struct {
+	// Destination is the file to edit. It is created if it does not exist.
+	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// Format is the format of the file. If it is not set, it is guessed from the
+	// extension of the destination: `.ini`, `.json`, `.yaml`, `.yml`, or
+	// `.toml`.
+	Format Format `hcl:"format" valid_values:"ini,json,yaml,toml"`
+
+	// Set is a map of key paths to the values they should have. Parts of a path
+	// are separated by dots, as in `log-opts.max-size`. A dot that is part of a
+	// key is escaped with a backslash. In INI files, the last part of the path
+	// is the key and the rest is the section.
+	Set map[string]interface{} `hcl:"set"`
+
+	// Remove is a list of key paths that should not be in the file. In INI
+	// files, a path can name a whole section.
+	Remove []string `hcl:"remove"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	if p.Format == "" {
+		format, ok := FormatFor(p.Destination)
+		if !ok {
+			return nil, fmt.Errorf("\"format\" is required, since it cannot be guessed from %q", p.Destination)
+		}
+		p.Format = format
+	}
+
+	if len(p.Set) == 0 && len(p.Remove) == 0 {
+		return nil, errors.New("\"set\" or \"remove\" is required")
+	}
+
+	structured := &Structured{
+		Destination: p.Destination,
+		Format:      p.Format,
+		Set:         p.Set,
+		Remove:      p.Remove,
+	}
+
+	for key := range p.Set {
+		path, err := SplitPath(key)
+		if err != nil {
+			return nil, err
+		}
+		structured.sets = append(structured.sets, keyPath{key, path})
+	}
+	sort.Sort(byKey(structured.sets))
+
+	for _, key := range p.Remove {
+		if _, ok := p.Set[key]; ok {
+			return nil, fmt.Errorf("%q cannot be both set and removed", key)
+		}
+
+		path, err := SplitPath(key)
+		if err != nil {
+			return nil, err
+		}
+		structured.removes = append(structured.removes, keyPath{key, path})
+	}
+	sort.Sort(byKey(structured.removes))
+
+	return structured, nil
+}
+
+func init() {
+	registry.Register("file.structured", (*Preparer)(nil), (*Structured)(nil))
+}
@@ -0,0 +1,84 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/structured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(structured.Preparer))
+}
+
+func TestPreparerPrepare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("format from extension", func(t *testing.T) {
+		prep := &structured.Preparer{Destination: "/tmp/x.yml", Remove: []string{"a"}}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, structured.FormatYAML, task.(*structured.Structured).Format)
+	})
+
+	t.Run("explicit format", func(t *testing.T) {
+		prep := &structured.Preparer{Destination: "/tmp/x.conf", Format: structured.FormatINI, Remove: []string{"a"}}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Equal(t, structured.FormatINI, task.(*structured.Structured).Format)
+	})
+
+	t.Run("unknown extension", func(t *testing.T) {
+		prep := &structured.Preparer{Destination: "/tmp/x.conf", Remove: []string{"a"}}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"format" is required, since it cannot be guessed from "/tmp/x.conf"`)
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		prep := &structured.Preparer{Destination: "/tmp/x.json"}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"set" or "remove" is required`)
+	})
+
+	t.Run("set and removed", func(t *testing.T) {
+		prep := &structured.Preparer{
+			Destination: "/tmp/x.json",
+			Set:         map[string]interface{}{"a.b": 1},
+			Remove:      []string{"a.b"},
+		}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `"a.b" cannot be both set and removed`)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		prep := &structured.Preparer{Destination: "/tmp/x.json", Remove: []string{"a..b"}}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `invalid key path "a..b": keys cannot be empty`)
+	})
+}
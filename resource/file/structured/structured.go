@@ -0,0 +1,178 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// absent is shown in differences for keys that are not in the file
+const absent = "<absent>"
+
+// Structured manages values in a structured file
+type Structured struct {
+	// the file to edit
+	Destination string `export:"destination"`
+
+	// the format of the file
+	Format Format `export:"format"`
+
+	// the values that should be set
+	Set map[string]interface{} `export:"set"`
+
+	// the keys that should be removed
+	Remove []string `export:"remove"`
+
+	sets, removes []keyPath
+}
+
+// keyPath is a key path as it was written, and split into its parts
+type keyPath struct {
+	key  string
+	path []string
+}
+
+type byKey []keyPath
+
+func (k byKey) Len() int           { return len(k) }
+func (k byKey) Swap(i, j int)      { k[i], k[j] = k[j], k[i] }
+func (k byKey) Less(i, j int) bool { return k[i].key < k[j].key }
+
+// Check which keys need to be changed. Every key that will change is reported
+// as a separate difference.
+func (s *Structured) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	doc, err := s.load()
+	if err != nil {
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, err
+	}
+
+	if err := s.edit(doc, status); err != nil {
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, err
+	}
+
+	status.RaiseLevelForDiffs()
+	if !status.HasChanges() {
+		status.AddMessage("OK")
+	}
+	return status, nil
+}
+
+// Apply the changes to the file, replacing it atomically
+func (s *Structured) Apply(context.Context) (resource.TaskStatus, error) {
+	defer file.LockEdits(s.Destination)()
+
+	status := resource.NewStatus()
+
+	doc, err := s.load()
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	if err := s.edit(doc, status); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	if !status.HasChanges() {
+		return status, nil
+	}
+
+	content, err := doc.Bytes()
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrapf(err, "cannot write %q", s.Destination)
+	}
+
+	perm := os.FileMode(0600)
+	if stat, err := os.Stat(s.Destination); err == nil {
+		perm = stat.Mode().Perm()
+	}
+
+	if err := file.WriteAtomic(s.Destination, content, perm, -1, -1); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	return status, nil
+}
+
+// load the destination. A file that does not exist is an empty document.
+func (s *Structured) load() (document, error) {
+	content, err := ioutil.ReadFile(s.Destination)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "cannot read %q", s.Destination)
+	}
+
+	doc, err := parse(s.Format, content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse %q as %s", s.Destination, s.Format)
+	}
+	return doc, nil
+}
+
+// edit the document, adding a difference to the status for every key that
+// changes
+func (s *Structured) edit(doc document, status *resource.Status) error {
+	for _, set := range s.sets {
+		desired, err := doc.Convert(s.Set[set.key])
+		if err != nil {
+			return fmt.Errorf("cannot set %q: %s", set.key, err)
+		}
+
+		current, ok := doc.Get(set.path)
+		if ok && equal(current, desired) {
+			continue
+		}
+
+		original, updated := absent, display(desired)
+		if ok {
+			original = display(current)
+		}
+
+		// a string and a number can look the same, like "8080" and 8080
+		if original == updated {
+			original, updated = quoted(current), quoted(desired)
+		}
+
+		if err := doc.Set(set.path, desired); err != nil {
+			return err
+		}
+		status.AddDifference(set.key, original, updated, absent)
+	}
+
+	for _, remove := range s.removes {
+		current, ok := doc.Get(remove.path)
+		if !ok {
+			continue
+		}
+
+		doc.Delete(remove.path)
+		status.AddDifference(remove.key, display(current), absent, absent)
+	}
+
+	return nil
+}
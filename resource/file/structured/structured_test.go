@@ -0,0 +1,250 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/structured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestStructuredInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(structured.Structured))
+}
+
+func TestStructured(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-structured")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, test := range []struct {
+		name     string
+		file     string
+		prep     *structured.Preparer
+		content  string
+		expected string
+	}{
+		{
+			"json",
+			"daemon.json",
+			&structured.Preparer{
+				Set:    map[string]interface{}{"log-opts.max-size": "10m", "live-restore": true},
+				Remove: []string{"debug"},
+			},
+			"{\n    \"storage-driver\": \"overlay2\",\n    \"debug\": true,\n    \"log-opts\": {\"max-file\": \"5\"}\n}\n",
+			"{\n    \"storage-driver\": \"overlay2\",\n    \"log-opts\": {\n        \"max-file\": \"5\",\n        \"max-size\": \"10m\"\n    },\n    \"live-restore\": true\n}\n",
+		},
+		{
+			"json unchanged",
+			"unchanged.json",
+			&structured.Preparer{Set: map[string]interface{}{"a.b": 1.0}, Remove: []string{"c"}},
+			"{\"a\": {\"b\": 1}}\n",
+			"{\"a\": {\"b\": 1}}\n",
+		},
+		{
+			"json missing",
+			"missing.json",
+			&structured.Preparer{Set: map[string]interface{}{"a.b": []interface{}{1, "x"}}},
+			"",
+			"{\n  \"a\": {\n    \"b\": [\n      1,\n      \"x\"\n    ]\n  }\n}\n",
+		},
+		{
+			"json escaped key",
+			"escaped.json",
+			&structured.Preparer{Set: map[string]interface{}{`labels.com\.example\.team`: "ops"}},
+			"{}\n",
+			"{\n  \"labels\": {\n    \"com.example.team\": \"ops\"\n  }\n}\n",
+		},
+		{
+			"yaml",
+			"config.yml",
+			&structured.Preparer{
+				Set:    map[string]interface{}{"server.port": 8080, "server.host": "0.0.0.0"},
+				Remove: []string{"debug"},
+			},
+			"server:\n  port: 80\n  tls: false\ndebug: true\nname: app\n",
+			"server:\n  port: 8080\n  tls: false\n  host: 0.0.0.0\nname: app\n",
+		},
+		{
+			"toml",
+			"config.toml",
+			&structured.Preparer{
+				Set:    map[string]interface{}{"server.port": 8080, "title": "app"},
+				Remove: []string{"database.user"},
+			},
+			"title = \"old\"\n\n[server]\nhost = \"0.0.0.0\"\nport = 80\n\n[database]\nuser = \"root\"\nmax = 1.5\n",
+			"title = \"app\"\n\n[server]\nhost = \"0.0.0.0\"\nport = 8080\n\n[database]\nmax = 1.5\n",
+		},
+		{
+			"toml table arrays",
+			"arrays.toml",
+			&structured.Preparer{Set: map[string]interface{}{"owner": "ops"}},
+			"[[servers]]\nname = \"a\"\n\n[[servers]]\nname = \"b\"\n",
+			"owner = \"ops\"\n\n[[servers]]\nname = \"a\"\n\n[[servers]]\nname = \"b\"\n",
+		},
+		{
+			"ini",
+			"php.ini",
+			&structured.Preparer{
+				Set:    map[string]interface{}{"PHP.memory_limit": "256M", "Date.date\\.timezone": "UTC", "global": 1},
+				Remove: []string{"PHP.expose_php"},
+			},
+			"; defaults\n[PHP]\n; memory\nmemory_limit=128M\nexpose_php = On\n\n[Session]\nsave_path = /tmp\n",
+			"; defaults\nglobal = 1\n[PHP]\n; memory\nmemory_limit=256M\n\n[Session]\nsave_path = /tmp\n\n[Date]\ndate.timezone = UTC\n",
+		},
+		{
+			"ini section",
+			"section.ini",
+			&structured.Preparer{Remove: []string{"old"}},
+			"[keep]\na = 1\n\n[old]\nb = 2\n",
+			"[keep]\na = 1\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dest := filepath.Join(dir, test.file)
+			if test.content != "" {
+				require.NoError(t, ioutil.WriteFile(dest, []byte(test.content), 0600))
+			}
+
+			test.prep.Destination = dest
+			task, err := test.prep.Prepare(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+
+			status, err := task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.Equal(t, test.content != test.expected, status.HasChanges())
+
+			_, err = task.Apply(context.Background())
+			require.NoError(t, err)
+
+			actual, err := ioutil.ReadFile(dest)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+
+			status, err = task.Check(context.Background(), fakerenderer.New())
+			require.NoError(t, err)
+			assert.False(t, status.HasChanges(), "not idempotent")
+		})
+	}
+}
+
+func TestStructuredDiffs(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-structured")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dest := filepath.Join(dir, "daemon.json")
+	require.NoError(t, ioutil.WriteFile(dest, []byte(`{"port": "8080", "debug": true, "log-opts": {"max-file": "5"}}`), 0600))
+
+	prep := &structured.Preparer{
+		Destination: dest,
+		Set:         map[string]interface{}{"port": 8080, "log-opts.max-size": "10m", "log-opts.max-file": "5"},
+		Remove:      []string{"debug", "missing"},
+	}
+	task, err := prep.Prepare(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+
+	status, err := task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, resource.StatusWillChange, status.StatusCode())
+
+	diffs := status.Diffs()
+	require.Len(t, diffs, 3)
+
+	assert.Equal(t, `"8080"`, diffs["port"].Original())
+	assert.Equal(t, "8080", diffs["port"].Current())
+	assert.Equal(t, "<absent>", diffs["log-opts.max-size"].Original())
+	assert.Equal(t, "10m", diffs["log-opts.max-size"].Current())
+	assert.Equal(t, "true", diffs["debug"].Original())
+	assert.Equal(t, "<absent>", diffs["debug"].Current())
+}
+
+func TestStructuredErrors(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-structured")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("invalid content", func(t *testing.T) {
+		dest := filepath.Join(dir, "invalid.json")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("{"), 0600))
+
+		task, err := (&structured.Preparer{Destination: dest, Remove: []string{"a"}}).Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		status, err := task.Check(context.Background(), fakerenderer.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot parse")
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		dest := filepath.Join(dir, "scalar.json")
+		require.NoError(t, ioutil.WriteFile(dest, []byte(`{"a": 1}`), 0600))
+
+		task, err := (&structured.Preparer{Destination: dest, Set: map[string]interface{}{"a.b": 2}}).Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		_, err = task.Check(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, `cannot set "a.b": "a" is not an object`)
+	})
+
+	t.Run("map in ini", func(t *testing.T) {
+		dest := filepath.Join(dir, "map.ini")
+
+		task, err := (&structured.Preparer{Destination: dest, Set: map[string]interface{}{"a.b": map[string]interface{}{"c": 1}}}).Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		_, err = task.Check(context.Background(), fakerenderer.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only strings, numbers, and booleans can be set in INI files")
+	})
+}
+
+func TestSplitPath(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		path     string
+		expected []string
+	}{
+		{"a", []string{"a"}},
+		{"log-opts.max-size", []string{"log-opts", "max-size"}},
+		{`labels.com\.example`, []string{"labels", "com.example"}},
+		{`a\b.c`, []string{`a\b`, "c"}},
+	} {
+		actual, err := structured.SplitPath(test.path)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, actual, test.path)
+	}
+
+	_, err := structured.SplitPath("a.")
+	assert.Error(t, err)
+}
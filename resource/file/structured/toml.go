@@ -0,0 +1,251 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML parses a TOML document, keeping the order of its keys. Comments
+// are not kept when the file is rewritten.
+func parseTOML(content []byte) (document, error) {
+	values := map[string]interface{}{}
+	meta, err := toml.Decode(string(content), &values)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys are listed in the order they appear in the document
+	order := map[string]int{}
+	for i, key := range meta.Keys() {
+		if _, ok := order[key.String()]; !ok {
+			order[key.String()] = i
+		}
+	}
+
+	return &tree{root: fromTOML(values, nil, order).(*object), encode: encodeTOML}, nil
+}
+
+// fromTOML converts decoded TOML to objects, ordering keys by where they were
+// in the document
+func fromTOML(value interface{}, path []string, order map[string]int) interface{} {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+
+		position := func(key string) int {
+			if pos, ok := order[toml.Key(append(path, key)).String()]; ok {
+				return pos
+			}
+			return math.MaxInt32
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if position(keys[i]) != position(keys[j]) {
+				return position(keys[i]) < position(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+
+		obj := newObject()
+		for _, key := range keys {
+			obj.set(key, fromTOML(val[key], append(append([]string(nil), path...), key), order))
+		}
+		return obj
+
+	case []map[string]interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = fromTOML(item, path, order)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = fromTOML(item, path, order)
+		}
+		return out
+	}
+	return value
+}
+
+func encodeTOML(root *object) ([]byte, error) {
+	var buf bytes.Buffer
+	err := writeTOMLTable(&buf, root, nil)
+	return buf.Bytes(), err
+}
+
+// writeTOMLTable writes the keys of a table, followed by the tables in it.
+// Plain keys come first, since every key after a table header belongs to that
+// table.
+func writeTOMLTable(buf *bytes.Buffer, table *object, path []string) error {
+	for _, key := range table.keys {
+		val := table.values[key]
+		if isTOMLTable(val) || isTOMLTableArray(val) {
+			continue
+		}
+
+		encoded, err := tomlValue(val)
+		if err != nil {
+			return fmt.Errorf("cannot write %q: %s", strings.Join(append(path, key), "."), err)
+		}
+		fmt.Fprintf(buf, "%s = %s\n", tomlKey(key), encoded)
+	}
+
+	for _, key := range table.keys {
+		val := table.values[key]
+		sub := append(append([]string(nil), path...), key)
+
+		switch {
+		case isTOMLTable(val):
+			writeTOMLHeader(buf, "["+tomlPath(sub)+"]")
+			if err := writeTOMLTable(buf, val.(*object), sub); err != nil {
+				return err
+			}
+
+		case isTOMLTableArray(val):
+			for _, item := range val.([]interface{}) {
+				writeTOMLHeader(buf, "[["+tomlPath(sub)+"]]")
+				if err := writeTOMLTable(buf, item.(*object), sub); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTOMLHeader writes a table header, separated from what came before by a
+// blank line
+func writeTOMLHeader(buf *bytes.Buffer, header string) {
+	if buf.Len() > 0 {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(header + "\n")
+}
+
+func isTOMLTable(value interface{}) bool {
+	_, ok := value.(*object)
+	return ok
+}
+
+func isTOMLTableArray(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(*object); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// tomlValue encodes a value that is written inline
+func tomlValue(value interface{}) (string, error) {
+	switch val := value.(type) {
+	case nil:
+		return "", fmt.Errorf("TOML has no null value")
+	case string:
+		var buf bytes.Buffer
+		if err := writeJSONScalar(&buf, val); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case time.Time:
+		return val.Format(time.RFC3339Nano), nil
+	case *object:
+		parts := make([]string, len(val.keys))
+		for i, key := range val.keys {
+			encoded, err := tomlValue(val.values[key])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = tomlKey(key) + " = " + encoded
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	case []interface{}:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			encoded, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = encoded
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		case math.IsNaN(f):
+			return "nan", nil
+		}
+
+		// TOML floats must have a fractional part or an exponent
+		out := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(out, ".eE") {
+			out += ".0"
+		}
+		return out, nil
+	}
+
+	return "", fmt.Errorf("cannot write %T", value)
+}
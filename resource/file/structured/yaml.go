@@ -0,0 +1,84 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// parseYAML parses a YAML mapping, keeping the order of its keys. Comments are
+// not kept when the file is rewritten.
+func parseYAML(content []byte) (document, error) {
+	var slice yaml.MapSlice
+	if err := yaml.Unmarshal(content, &slice); err != nil {
+		return nil, err
+	}
+
+	return &tree{root: fromYAML(slice).(*object), encode: encodeYAML}, nil
+}
+
+func encodeYAML(root *object) ([]byte, error) {
+	return yaml.Marshal(toYAML(root))
+}
+
+// fromYAML converts decoded YAML to objects
+func fromYAML(value interface{}) interface{} {
+	switch val := value.(type) {
+	case yaml.MapSlice:
+		obj := newObject()
+		for _, item := range val {
+			obj.set(fmt.Sprintf("%v", item.Key), fromYAML(item.Value))
+		}
+		return obj
+	case map[interface{}]interface{}:
+		slice := yaml.MapSlice{}
+		for key, item := range val {
+			slice = append(slice, yaml.MapItem{Key: key, Value: item})
+		}
+		sort.Slice(slice, func(i, j int) bool {
+			return fmt.Sprintf("%v", slice[i].Key) < fmt.Sprintf("%v", slice[j].Key)
+		})
+		return fromYAML(slice)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = fromYAML(item)
+		}
+		return out
+	}
+	return value
+}
+
+// toYAML converts objects to ordered YAML mappings
+func toYAML(value interface{}) interface{} {
+	switch val := value.(type) {
+	case *object:
+		slice := yaml.MapSlice{}
+		for _, key := range val.keys {
+			slice = append(slice, yaml.MapItem{Key: key, Value: toYAML(val.values[key])})
+		}
+		return slice
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = toYAML(item)
+		}
+		return out
+	}
+	return value
+}
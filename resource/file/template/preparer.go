@@ -0,0 +1,82 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"os"
+
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Preparer for Template
+//
+// Template renders a template from the module to disk. The template is fetched
+// when the module is loaded, so it is cached by `converge get` and checked with
+// `--verify-modules` just like a module.
+type Preparer struct {
+	// Source is the location of the template, relative to the file the
+	// resource is in. It can be any location a module can be loaded from, but
+	// cannot itself be templated.
+	Source string `hcl:"source" required:"true" nonempty:"true"`
+
+	// Destination is the location on disk where the template will be rendered.
+	Destination string `hcl:"destination" required:"true" nonempty:"true"`
+
+	// Template is the content of Source, which is rendered with the same
+	// functions as the rest of the module. It is set when the module is loaded
+	// and cannot be set directly.
+	Template string `hcl:"template"`
+
+	// Mode is the mode of the file, specified in octal. When it is not set,
+	// existing files keep their mode and new files are created with 0600.
+	Mode *uint32 `hcl:"mode" base:"8"`
+
+	// User is the name or ID of the user that owns the file. When it is not
+	// set, existing files keep their owner.
+	User string `hcl:"user"`
+
+	// Group is the name or ID of the group that owns the file. When it is not
+	// set, existing files keep their group.
+	Group string `hcl:"group"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	var mode *os.FileMode
+	if p.Mode != nil {
+		if *p.Mode > uint32(os.ModePerm) {
+			return nil, errors.Errorf("invalid mode %o: only permission bits can be set", *p.Mode)
+		}
+
+		m := os.FileMode(*p.Mode)
+		mode = &m
+	}
+
+	return &Template{
+		Source:      p.Source,
+		Destination: p.Destination,
+		Content:     p.Template,
+		Mode:        mode,
+		User:        p.User,
+		Group:       p.Group,
+	}, nil
+}
+
+func init() {
+	registry.Register("file.template", (*Preparer)(nil), (*Template)(nil))
+}
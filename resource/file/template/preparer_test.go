@@ -0,0 +1,66 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(template.Preparer))
+}
+
+func TestPreparerMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		prep := &template.Preparer{Source: "x.tmpl", Destination: "/tmp/x", Template: "x"}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.Nil(t, task.(*template.Template).Mode)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mode := uint32(0644)
+		prep := &template.Preparer{Source: "x.tmpl", Destination: "/tmp/x", Template: "x", Mode: &mode}
+
+		task, err := prep.Prepare(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+
+		tmpl := task.(*template.Template)
+		require.NotNil(t, tmpl.Mode)
+		assert.Equal(t, os.FileMode(0644), *tmpl.Mode)
+		assert.Equal(t, "x", tmpl.Content)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		mode := uint32(01777)
+		prep := &template.Preparer{Source: "x.tmpl", Destination: "/tmp/x", Mode: &mode}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.New())
+		assert.EqualError(t, err, "invalid mode 1777: only permission bits can be set")
+	})
+}
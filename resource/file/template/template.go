@@ -0,0 +1,253 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// defaultMode is the mode of new files when none is set
+const defaultMode os.FileMode = 0600
+
+// Template renders a template to disk
+type Template struct {
+	// location the template was loaded from
+	Source string `export:"source"`
+
+	// configured destination of the file
+	Destination string `export:"destination"`
+
+	// rendered content of the file
+	Content string `export:"content"`
+
+	// configured mode of the file, if any
+	Mode *os.FileMode
+
+	// configured owner of the file, if any
+	User string `export:"user"`
+
+	// configured group of the file, if any
+	Group string `export:"group"`
+
+	// the file as it was before Apply, for Rollback
+	previous *previousFile
+}
+
+type previousFile struct {
+	existed  bool
+	content  []byte
+	perm     os.FileMode
+	uid, gid int
+}
+
+// Check if the template needs to be rendered
+func (t *Template) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	stat, err := os.Stat(t.Destination)
+	if os.IsNotExist(err) {
+		status.AddMessage(t.Destination + ": File is missing")
+		status.AddDifference(t.Destination, "<file-missing>", t.Content, "")
+		if t.Mode != nil {
+			status.AddDifference("mode", "<file-missing>", fmt.Sprintf("%04o", *t.Mode), "")
+		}
+		if t.User != "" {
+			status.AddDifference("user", "<file-missing>", t.User, "")
+		}
+		if t.Group != "" {
+			status.AddDifference("group", "<file-missing>", t.Group, "")
+		}
+		status.RaiseLevelForDiffs()
+		return status, nil
+	} else if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrapf(err, "cannot read %q", t.Destination)
+	} else if stat.IsDir() {
+		status.RaiseLevel(resource.StatusCantChange)
+		return status, fmt.Errorf("cannot render template to %q, it is a directory", t.Destination)
+	}
+
+	actual, err := ioutil.ReadFile(t.Destination)
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrapf(err, "cannot read %q", t.Destination)
+	}
+
+	if string(actual) != t.Content {
+		status.AddMessage("contents differ")
+		status.AddDifference(t.Destination, string(actual), t.Content, "")
+	}
+
+	if t.Mode != nil && stat.Mode().Perm() != *t.Mode {
+		status.AddDifference("mode", fmt.Sprintf("%04o", stat.Mode().Perm()), fmt.Sprintf("%04o", *t.Mode), "")
+	}
+
+	uid, gid, _ := file.Owner(stat)
+
+	if t.User != "" {
+		if name, id := userName(uid), strconv.Itoa(uid); t.User != name && t.User != id {
+			status.AddDifference("user", name, t.User, "")
+		}
+	}
+
+	if t.Group != "" {
+		if name, id := groupName(gid), strconv.Itoa(gid); t.Group != name && t.Group != id {
+			status.AddDifference("group", name, t.Group, "")
+		}
+	}
+
+	status.RaiseLevelForDiffs()
+	if !status.HasChanges() {
+		status.AddMessage("OK")
+	}
+
+	return status, nil
+}
+
+// Apply renders the template to disk, replacing the file atomically
+func (t *Template) Apply(context.Context) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	uid, gid, err := t.owner()
+	if err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	previous := &previousFile{uid: -1, gid: -1}
+	perm := defaultMode
+	original := "<file-missing>"
+
+	stat, err := os.Stat(t.Destination)
+	if err == nil {
+		content, readErr := ioutil.ReadFile(t.Destination)
+		if readErr != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(readErr, "cannot read %q", t.Destination)
+		}
+
+		perm = stat.Mode().Perm()
+		original = string(content)
+
+		previous.existed = true
+		previous.content = content
+		previous.perm = perm
+		previous.uid, previous.gid, _ = file.Owner(stat)
+	} else if !os.IsNotExist(err) {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, errors.Wrapf(err, "cannot read %q", t.Destination)
+	}
+
+	if t.Mode != nil {
+		perm = *t.Mode
+	}
+
+	status.AddDifference(t.Destination, original, t.Content, "")
+
+	if err := file.WriteAtomic(t.Destination, []byte(t.Content), perm, uid, gid); err != nil {
+		status.RaiseLevel(resource.StatusFatal)
+		return status, err
+	}
+
+	t.previous = previous
+
+	return status, nil
+}
+
+// Rollback restores the file as it was before Apply, or removes it if Apply
+// created it
+func (t *Template) Rollback(context.Context) (resource.TaskStatus, error) {
+	if t.previous == nil {
+		return &resource.Status{Output: []string{"nothing to roll back"}}, nil
+	}
+
+	status := resource.NewStatus()
+	if t.previous.existed {
+		if err := file.WriteAtomic(t.Destination, t.previous.content, t.previous.perm, t.previous.uid, t.previous.gid); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not restore %s", t.Destination)
+		}
+		status.AddDifference(t.Destination, t.Content, string(t.previous.content), "")
+	} else {
+		if err := os.Remove(t.Destination); err != nil && !os.IsNotExist(err) {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrapf(err, "could not remove %s", t.Destination)
+		}
+		status.AddDifference(t.Destination, t.Content, "<file-missing>", "")
+	}
+
+	t.previous = nil
+
+	return status, nil
+}
+
+// owner looks up the configured user and group, returning -1 for either if it
+// is not set
+func (t *Template) owner() (uid, gid int, err error) {
+	uid, gid = -1, -1
+
+	if t.User != "" {
+		u, err := user.Lookup(t.User)
+		if err != nil {
+			if u, err = user.LookupId(t.User); err != nil {
+				return -1, -1, fmt.Errorf("user %q does not exist", t.User)
+			}
+		}
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return -1, -1, errors.Wrapf(err, "invalid ID for user %q", t.User)
+		}
+	}
+
+	if t.Group != "" {
+		g, err := user.LookupGroup(t.Group)
+		if err != nil {
+			if g, err = user.LookupGroupId(t.Group); err != nil {
+				return -1, -1, fmt.Errorf("group %q does not exist", t.Group)
+			}
+		}
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return -1, -1, errors.Wrapf(err, "invalid ID for group %q", t.Group)
+		}
+	}
+
+	return uid, gid, nil
+}
+
+// userName returns the name of the user with an ID, or the ID if it has none
+func userName(uid int) string {
+	id := strconv.Itoa(uid)
+	if u, err := user.LookupId(id); err == nil {
+		return u.Username
+	}
+	return id
+}
+
+// groupName returns the name of the group with an ID, or the ID if it has none
+func groupName(gid int) string {
+	id := strconv.Itoa(gid)
+	if g, err := user.LookupGroupId(id); err == nil {
+		return g.Name
+	}
+	return id
+}
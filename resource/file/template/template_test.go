@@ -0,0 +1,187 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/file/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestTemplateInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(template.Template))
+	assert.Implements(t, (*resource.Rollbacker)(nil), new(template.Template))
+}
+
+func TestTemplateCheck(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("missing", func(t *testing.T) {
+		mode := os.FileMode(0644)
+		tmpl := &template.Template{
+			Destination: filepath.Join(dir, "missing"),
+			Content:     "a\nb\n",
+			Mode:        &mode,
+		}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.True(t, status.HasChanges())
+		assert.Equal(t, "<file-missing>", status.Diffs()[tmpl.Destination].Original())
+		assert.Equal(t, "a\nb\n", status.Diffs()[tmpl.Destination].Current())
+		assert.Equal(t, "0644", status.Diffs()["mode"].Current())
+	})
+
+	t.Run("same", func(t *testing.T) {
+		dest := filepath.Join(dir, "same")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("a\nb\n"), 0600))
+		require.NoError(t, os.Chmod(dest, 0600))
+
+		mode := os.FileMode(0600)
+		tmpl := &template.Template{Destination: dest, Content: "a\nb\n", Mode: &mode}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges())
+		assert.Equal(t, []string{"OK"}, status.Messages())
+	})
+
+	t.Run("different", func(t *testing.T) {
+		dest := filepath.Join(dir, "different")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("a\nb\n"), 0600))
+		require.NoError(t, os.Chmod(dest, 0600))
+
+		mode := os.FileMode(0640)
+		tmpl := &template.Template{Destination: dest, Content: "a\nc\n", Mode: &mode}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.True(t, status.HasChanges())
+		assert.Equal(t, "a\nb\n", status.Diffs()[dest].Original())
+		assert.Equal(t, "0600", status.Diffs()["mode"].Original())
+		assert.Equal(t, "0640", status.Diffs()["mode"].Current())
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		current, err := user.Current()
+		require.NoError(t, err)
+
+		dest := filepath.Join(dir, "owner")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("a"), 0600))
+
+		tmpl := &template.Template{Destination: dest, Content: "a", User: current.Uid}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.False(t, status.HasChanges())
+
+		tmpl.User = "converge-no-such-user"
+
+		status, err = tmpl.Check(context.Background(), fakerenderer.New())
+		require.NoError(t, err)
+		assert.True(t, status.HasChanges())
+		assert.Equal(t, "converge-no-such-user", status.Diffs()["user"].Current())
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		tmpl := &template.Template{Destination: dir, Content: "a"}
+
+		status, err := tmpl.Check(context.Background(), fakerenderer.New())
+		assert.Error(t, err)
+		assert.Equal(t, resource.StatusCantChange, status.StatusCode())
+	})
+}
+
+func TestTemplateApply(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("new", func(t *testing.T) {
+		dest := filepath.Join(dir, "new")
+		tmpl := &template.Template{Destination: dest, Content: "a\n"}
+
+		_, err := tmpl.Apply(context.Background())
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "a\n", string(content))
+
+		stat, err := os.Stat(dest)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+		_, err = tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+
+		_, err = os.Stat(dest)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		dest := filepath.Join(dir, "existing")
+		require.NoError(t, ioutil.WriteFile(dest, []byte("a\n"), 0600))
+		require.NoError(t, os.Chmod(dest, 0640))
+
+		tmpl := &template.Template{Destination: dest, Content: "b\n"}
+
+		_, err := tmpl.Apply(context.Background())
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "b\n", string(content))
+
+		stat, err := os.Stat(dest)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), stat.Mode().Perm(), "mode was not kept")
+
+		_, err = tmpl.Rollback(context.Background())
+		require.NoError(t, err)
+
+		content, err = ioutil.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "a\n", string(content))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		dest := filepath.Join(dir, "unknown-user")
+		tmpl := &template.Template{Destination: dest, Content: "a", User: "converge-no-such-user"}
+
+		_, err := tmpl.Apply(context.Background())
+		assert.EqualError(t, err, `user "converge-no-such-user" does not exist`)
+
+		_, err = os.Stat(dest)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// WriteAtomic writes content to path through a temporary file in the same
+// directory, which is renamed over path once it is complete, so the file is
+// never seen half-written. The file gets perm and is owned by uid and gid. An
+// ID of -1 keeps the owner of the file being replaced, or of the user running
+// converge for a new file. If path is a symbolic link, the file it points to is
+// written instead.
+func WriteAtomic(path string, content []byte, perm os.FileMode, uid, gid int) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	if stat, err := os.Stat(path); err == nil {
+		if stat.IsDir() {
+			return errors.Errorf("cannot write %q, it is a directory", path)
+		}
+
+		if currentUID, currentGID, ok := Owner(stat); ok {
+			if uid == -1 {
+				uid = currentUID
+			}
+			if gid == -1 {
+				gid = currentGID
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+base+".converge-")
+	if err != nil {
+		return errors.Wrapf(err, "could not write %q", path)
+	}
+	name := tmp.Name()
+
+	// the temporary file is only left behind if something went wrong
+	done := false
+	defer func() {
+		if !done {
+			os.Remove(name)
+		}
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "could not write %q", path)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "could not write %q", path)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "could not write %q", path)
+	}
+
+	if err := os.Chmod(name, perm); err != nil {
+		return errors.Wrapf(err, "could not set mode of %q", path)
+	}
+
+	if err := chownIfChanged(name, uid, gid); err != nil {
+		return errors.Wrapf(err, "could not set owner of %q", path)
+	}
+
+	if err := os.Rename(name, path); err != nil {
+		return errors.Wrapf(err, "could not write %q", path)
+	}
+
+	done = true
+	return nil
+}
+
+// Owner returns the user and group IDs that own a file
+func Owner(info os.FileInfo) (uid, gid int, ok bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || stat == nil {
+		return -1, -1, false
+	}
+	return int(stat.Uid), int(stat.Gid), true
+}
+
+// chownIfChanged changes the owner of path only when it would change, so that
+// users other than root can write files they already own
+func chownIfChanged(path string, uid, gid int) error {
+	if uid == -1 && gid == -1 {
+		return nil
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if currentUID, currentGID, ok := Owner(stat); ok {
+		if (uid == -1 || uid == currentUID) && (gid == -1 || gid == currentGID) {
+			return nil
+		}
+	}
+
+	return os.Chown(path, uid, gid)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asteris-llc/converge/resource/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteAtomic tests replacing files through a temporary file
+func TestWriteAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "converge-write")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("new", func(t *testing.T) {
+		path := filepath.Join(dir, "new")
+
+		require.NoError(t, file.WriteAtomic(path, []byte("x"), 0640, -1, -1))
+
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "x", string(content))
+
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), stat.Mode().Perm())
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		path := filepath.Join(dir, "existing")
+		require.NoError(t, ioutil.WriteFile(path, []byte("x"), 0600))
+
+		require.NoError(t, file.WriteAtomic(path, []byte("y"), 0600, -1, -1))
+
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "y", string(content))
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		target := filepath.Join(dir, "target")
+		link := filepath.Join(dir, "link")
+		require.NoError(t, ioutil.WriteFile(target, []byte("x"), 0600))
+		require.NoError(t, os.Symlink(target, link))
+
+		require.NoError(t, file.WriteAtomic(link, []byte("y"), 0600, -1, -1))
+
+		stat, err := os.Lstat(link)
+		require.NoError(t, err)
+		assert.True(t, stat.Mode()&os.ModeSymlink != 0, "link was replaced")
+
+		content, err := ioutil.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, "y", string(content))
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		sub := filepath.Join(dir, "directory")
+		require.NoError(t, os.Mkdir(sub, 0700))
+
+		assert.EqualError(t, file.WriteAtomic(sub, []byte("x"), 0600, -1, -1), `cannot write "`+sub+`", it is a directory`)
+	})
+
+	t.Run("no temporary files", func(t *testing.T) {
+		entries, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			assert.NotContains(t, entry.Name(), ".converge-")
+		}
+	})
+}
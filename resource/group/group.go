@@ -60,9 +60,9 @@ type ModGroupOptions struct {
 
 // SystemUtils provides system utilities for group
 type SystemUtils interface {
-	AddGroup(groupName, groupID string) error
-	DelGroup(groupName string) error
-	ModGroup(groupName string, options *ModGroupOptions) error
+	AddGroup(ctx context.Context, groupName, groupID string) error
+	DelGroup(ctx context.Context, groupName string) error
+	ModGroup(ctx context.Context, groupName string, options *ModGroupOptions) error
 	LookupGroup(groupName string) (*user.Group, error)
 	LookupGroupID(groupID string) (*user.Group, error)
 }
@@ -228,7 +228,7 @@ func (g *Group) Check(context.Context, resource.Renderer) (resource.TaskStatus,
 }
 
 // Apply changes for group
-func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
+func (g *Group) Apply(ctx context.Context) (resource.TaskStatus, error) {
 	var (
 		groupByGid *user.Group
 		gidErr     error
@@ -264,7 +264,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 			case g.NewName == "":
 				switch {
 				case nameNotFound:
-					err := g.system.AddGroup(g.Name, g.GID)
+					err := g.system.AddGroup(ctx, g.Name, g.GID)
 					if err != nil {
 						status.RaiseLevel(resource.StatusFatal)
 						status.Output = append(status.Output, fmt.Sprintf("error adding group %s", g.Name))
@@ -281,7 +281,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 				switch {
 				case groupByName != nil && newNameNotFound:
 					options := SetModGroupOptions(g)
-					err := g.system.ModGroup(g.Name, options)
+					err := g.system.ModGroup(ctx, g.Name, options)
 					if err != nil {
 						status.RaiseLevel(resource.StatusFatal)
 						status.Output = append(status.Output, fmt.Sprintf("error modifying group %s", g.Name))
@@ -301,7 +301,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 			case g.NewName == "":
 				switch {
 				case nameNotFound && gidNotFound:
-					err := g.system.AddGroup(g.Name, g.GID)
+					err := g.system.AddGroup(ctx, g.Name, g.GID)
 					if err != nil {
 						status.RaiseLevel(resource.StatusFatal)
 						status.Output = append(status.Output, fmt.Sprintf("error adding group %s with gid %s", g.Name, g.GID))
@@ -310,7 +310,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 					status.Output = append(status.Output, fmt.Sprintf("added group %s with gid %s", g.Name, g.GID))
 				case gidNotFound:
 					options := SetModGroupOptions(g)
-					err := g.system.ModGroup(g.Name, options)
+					err := g.system.ModGroup(ctx, g.Name, options)
 					if err != nil {
 						status.RaiseLevel(resource.StatusFatal)
 						status.Output = append(status.Output, fmt.Sprintf("error modifying group %s with new gid %s", g.Name, g.GID))
@@ -327,7 +327,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 				switch {
 				case groupByName != nil && newNameNotFound && gidNotFound:
 					options := SetModGroupOptions(g)
-					err := g.system.ModGroup(g.Name, options)
+					err := g.system.ModGroup(ctx, g.Name, options)
 					if err != nil {
 						status.RaiseLevel(resource.StatusFatal)
 						status.Output = append(status.Output, fmt.Sprintf("error modifying group %s with new name %s and new gid %s", g.Name, g.NewName, g.GID))
@@ -347,7 +347,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 
 			switch {
 			case !nameNotFound && groupByName != nil:
-				err := g.system.DelGroup(g.Name)
+				err := g.system.DelGroup(ctx, g.Name)
 				if err != nil {
 					status.RaiseLevel(resource.StatusFatal)
 					status.Output = append(status.Output, fmt.Sprintf("error deleting group %s", g.Name))
@@ -364,7 +364,7 @@ func (g *Group) Apply(context.Context) (resource.TaskStatus, error) {
 
 			switch {
 			case !nameNotFound && !gidNotFound && groupByName != nil && groupByGid != nil && *groupByName == *groupByGid:
-				err := g.system.DelGroup(g.Name)
+				err := g.system.DelGroup(ctx, g.Name)
 				if err != nil {
 					status.RaiseLevel(resource.StatusFatal)
 					status.Output = append(status.Output, fmt.Sprintf("error deleting group %s with gid %s", g.Name, g.GID))
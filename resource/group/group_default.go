@@ -18,23 +18,25 @@ package group
 
 import (
 	"os/user"
+
+	"golang.org/x/net/context"
 )
 
 // System implements SystemUtils
 type System struct{}
 
 // AddGroup implementation for systems which are not supported
-func (s *System) AddGroup(groupName, groupID string) error {
+func (s *System) AddGroup(ctx context.Context, groupName, groupID string) error {
 	return ErrUnsupported
 }
 
 // DelGroup implementation for systems which are not supported
-func (s *System) DelGroup(groupName string) error {
+func (s *System) DelGroup(ctx context.Context, groupName string) error {
 	return ErrUnsupported
 }
 
 // ModGroup implementation for systems which are not supported
-func (s *System) ModGroup(groupName string, options *ModGroupOptions) error {
+func (s *System) ModGroup(ctx context.Context, groupName string, options *ModGroupOptions) error {
 	return ErrUnsupported
 }
 
@@ -20,18 +20,20 @@ import (
 	"fmt"
 	"os/exec"
 	"os/user"
+
+	"golang.org/x/net/context"
 )
 
 // System implements SystemUtils
 type System struct{}
 
 // AddGroup adds a group
-func (s *System) AddGroup(groupName, groupID string) error {
+func (s *System) AddGroup(ctx context.Context, groupName, groupID string) error {
 	args := []string{groupName}
 	if groupID != "" {
 		args = append(args, "-g", groupID)
 	}
-	cmd := exec.Command("groupadd", args...)
+	cmd := exec.CommandContext(ctx, "groupadd", args...)
 	err := cmd.Run()
 	if err != nil {
 		return fmt.Errorf("groupadd: %s", err)
@@ -40,8 +42,8 @@ func (s *System) AddGroup(groupName, groupID string) error {
 }
 
 // DelGroup deletes a group
-func (s *System) DelGroup(groupName string) error {
-	cmd := exec.Command("groupdel", groupName)
+func (s *System) DelGroup(ctx context.Context, groupName string) error {
+	cmd := exec.CommandContext(ctx, "groupdel", groupName)
 	err := cmd.Run()
 	if err != nil {
 		return fmt.Errorf("groupdel: %s", err)
@@ -50,7 +52,7 @@ func (s *System) DelGroup(groupName string) error {
 }
 
 // ModGroup modifies a group
-func (s *System) ModGroup(groupName string, options *ModGroupOptions) error {
+func (s *System) ModGroup(ctx context.Context, groupName string, options *ModGroupOptions) error {
 	args := []string{groupName}
 	if options.GID != "" {
 		args = append(args, "-g", options.GID)
@@ -58,7 +60,7 @@ func (s *System) ModGroup(groupName string, options *ModGroupOptions) error {
 	if options.NewName != "" {
 		args = append(args, "-n", options.NewName)
 	}
-	cmd := exec.Command("groupmod", args...)
+	cmd := exec.CommandContext(ctx, "groupmod", args...)
 	err := cmd.Run()
 	if err != nil {
 		return fmt.Errorf("groupmod: %s", err)
@@ -1012,19 +1012,19 @@ type MockSystem struct {
 }
 
 // AddGroup for MockSystem
-func (m *MockSystem) AddGroup(name, gid string) error {
+func (m *MockSystem) AddGroup(ctx context.Context, name, gid string) error {
 	args := m.Called(name, gid)
 	return args.Error(0)
 }
 
 // DelGroup for MockSystem
-func (m *MockSystem) DelGroup(name string) error {
+func (m *MockSystem) DelGroup(ctx context.Context, name string) error {
 	args := m.Called(name)
 	return args.Error(0)
 }
 
 // ModGroup for MockSystem
-func (m *MockSystem) ModGroup(name string, options *group.ModGroupOptions) error {
+func (m *MockSystem) ModGroup(ctx context.Context, name string, options *group.ModGroupOptions) error {
 	args := m.Called(name, options)
 	return args.Error(0)
 }
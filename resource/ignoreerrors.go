@@ -0,0 +1,46 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// IgnoreErrorsField is the field that lets the dependents of a resource run
+// even if the resource fails
+const IgnoreErrorsField = "ignore_errors"
+
+// ParseIgnoreErrors reads ignore_errors from the source of a resource. It
+// returns false if the field is not set.
+func ParseIgnoreErrors(source map[string]interface{}) (bool, error) {
+	raw, ok := source[IgnoreErrorsField]
+	if !ok {
+		return false, nil
+	}
+
+	switch val := raw.(type) {
+	case bool:
+		return val, nil
+	case string:
+		parsed, err := strconv.ParseBool(val)
+		if err != nil {
+			return false, fmt.Errorf("%s must be a boolean, got %q", IgnoreErrorsField, val)
+		}
+		return parsed, nil
+	default:
+		return false, fmt.Errorf("%s must be a boolean, got %T", IgnoreErrorsField, raw)
+	}
+}
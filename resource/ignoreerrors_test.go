@@ -0,0 +1,50 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseIgnoreErrors tests reading ignore_errors from resource sources
+func TestParseIgnoreErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		ignore, err := resource.ParseIgnoreErrors(map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.False(t, ignore)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		ignore, err := resource.ParseIgnoreErrors(map[string]interface{}{"ignore_errors": true})
+		assert.NoError(t, err)
+		assert.True(t, ignore)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		ignore, err := resource.ParseIgnoreErrors(map[string]interface{}{"ignore_errors": "false"})
+		assert.NoError(t, err)
+		assert.False(t, ignore)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := resource.ParseIgnoreErrors(map[string]interface{}{"ignore_errors": "sometimes"})
+		assert.EqualError(t, err, `ignore_errors must be a boolean, got "sometimes"`)
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/value"
+	"golang.org/x/net/context"
+)
+
+// Preparer for locals
+//
+// Local computes a value once so that it can be used by many nodes in the same
+// module. Use the `{{local "name"}}` template call anywhere you need the value
+// inside the current module.
+type Preparer struct {
+	// Value is the value of the local. It will usually be a template that
+	// combines params or the exported fields of other nodes.
+	Value string `hcl:"value" required:"true"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	return &Local{Rendered: value.Rendered{Value: p.Value}}, nil
+}
+
+func init() {
+	registry.Register("local", (*Preparer)(nil), (*Local)(nil))
+}
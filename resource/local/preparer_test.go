@@ -0,0 +1,48 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/local"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPreparerInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Resource)(nil), new(local.Preparer))
+}
+
+func TestPreparerPrepare(t *testing.T) {
+	t.Parallel()
+
+	task, err := (&local.Preparer{Value: "x"}).Prepare(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	require.IsType(t, new(local.Local), task)
+
+	// locals are read by the renderer from the task, before they are checked
+	assert.Equal(t, "x", task.(*local.Local).String())
+
+	status, err := task.Check(context.Background(), fakerenderer.New())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, status.Messages())
+	assert.False(t, status.HasChanges())
+}
@@ -61,7 +61,9 @@ Type={{.Type}}
 WantedBy=local-fs.target {{.WantedBy}}
 RequiredBy={{.RequiredBy}}`
 
-func (r *resourceFS) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+func (r *resourceFS) Check(ctx context.Context, _ resource.Renderer) (resource.TaskStatus, error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	status := &resource.Status{}
 
 	if err := r.lvm.CheckFilesystemTools(r.mount.Type); err != nil {
@@ -85,7 +87,9 @@ func (r *resourceFS) Check(context.Context, resource.Renderer) (resource.TaskSta
 	return status, nil
 }
 
-func (r *resourceFS) Apply(context.Context) (resource.TaskStatus, error) {
+func (r *resourceFS) Apply(ctx context.Context) (resource.TaskStatus, error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	if r.needMkfs {
 		if err := r.lvm.Mkfs(r.mount.What, r.mount.Type); err != nil {
 			return nil, errors.Wrapf(err, "mkfs")
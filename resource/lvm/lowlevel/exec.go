@@ -25,6 +25,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 )
 
 // Exec is interface to `real system` also used for test injections
@@ -48,9 +49,14 @@ type Exec interface {
 
 	// Local Filesystem Functions
 	EvalSymlinks(string) (string, error)
+
+	// WithContext returns an Exec that kills the processes it runs when the
+	// context is cancelled
+	WithContext(ctx context.Context) Exec
 }
 
 type osExec struct {
+	ctx context.Context
 }
 
 // MakeOsExec create Exec backend
@@ -58,13 +64,24 @@ func MakeOsExec() Exec {
 	return &osExec{}
 }
 
+func (*osExec) WithContext(ctx context.Context) Exec {
+	return &osExec{ctx: ctx}
+}
+
+func (e *osExec) context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
 func (*osExec) EvalSymlinks(path string) (string, error) {
 	return filepath.EvalSymlinks(path)
 }
 
-func (*osExec) Run(prog string, args []string) error {
+func (ex *osExec) Run(prog string, args []string) error {
 	log.WithField("module", "lvm").Infof("Executing %s: %v", prog, args)
-	e := exec.Command(prog, args...).Run()
+	e := exec.CommandContext(ex.context(), prog, args...).Run()
 	if e == nil {
 		log.WithField("module", "lvm").Debugf("%s: no error", prog)
 	} else {
@@ -78,10 +95,10 @@ func (e *osExec) RunWithExitCode(prog string, args []string) (int, error) {
 	return exitStatus(err)
 }
 
-func (*osExec) ReadWithExitCode(prog string, args []string) (stdout string, rc int, err error) {
+func (e *osExec) ReadWithExitCode(prog string, args []string) (stdout string, rc int, err error) {
 	rc = 0
 	log.WithField("module", "lvm").Infof("Executing (read) %s: %v", prog, args)
-	out, err := exec.Command(prog, args...).Output()
+	out, err := exec.CommandContext(e.context(), prog, args...).Output()
 	strOut := strings.Trim(string(out), "\n ")
 	if err != nil {
 		rc, err = exitStatus(err)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/asteris-llc/converge/resource/wait"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 )
 
 // LVM is a public interface to LVM guts for converge highlevel modules
@@ -47,6 +48,10 @@ type LVM interface {
 	CheckUnit(filename string, content string) (bool, error)
 	UpdateUnit(filename string, content string) error
 	StartUnit(filename string) error
+
+	// WithContext returns an LVM that kills the processes it runs when the
+	// context is cancelled
+	WithContext(ctx context.Context) LVM
 }
 
 type realLVM struct {
@@ -64,6 +69,10 @@ func MakeRealLVM(backend Exec) LVM {
 	return &realLVM{backend: backend}
 }
 
+func (lvm *realLVM) WithContext(ctx context.Context) LVM {
+	return &realLVM{backend: lvm.backend.WithContext(ctx)}
+}
+
 func (lvm *realLVM) CreateVolumeGroup(vg string, devs []string) error {
 	args := []string{vg}
 	var canonicalDevs []string
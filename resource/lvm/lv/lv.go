@@ -37,7 +37,9 @@ type Status struct {
 	DevicePath string
 }
 
-func (r *resourceLV) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+func (r *resourceLV) Check(ctx context.Context, _ resource.Renderer) (resource.TaskStatus, error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	status := &Status{}
 
 	// Check for LVM prerequizites
@@ -72,7 +74,9 @@ func (r *resourceLV) Check(context.Context, resource.Renderer) (resource.TaskSta
 	return status, nil
 }
 
-func (r *resourceLV) Apply(context.Context) (resource.TaskStatus, error) {
+func (r *resourceLV) Apply(ctx context.Context) (resource.TaskStatus, error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	status := &Status{}
 	if _, err := r.checkVG(true); err != nil {
 		return nil, err
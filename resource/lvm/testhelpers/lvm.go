@@ -19,6 +19,7 @@ import (
 
 	"github.com/asteris-llc/converge/resource/lvm/lowlevel"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
 )
 
 // FakeLVM is mock object implementing lowlevel.LVM
@@ -33,6 +34,11 @@ func MakeFakeLvm() (lowlevel.LVM, *FakeLVM) {
 	return lvm, lvm
 }
 
+// WithContext is mock for LVM.WithContext(). The context is ignored.
+func (f *FakeLVM) WithContext(context.Context) lowlevel.LVM {
+	return f
+}
+
 // EvalSymlinks mocks symlink evaluation
 func (*FakeLVM) EvalSymlinks(s string) (string, error) {
 	fmt.Println("calling fakelvm eval symlinks with ", s)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/asteris-llc/converge/resource/lvm/lowlevel"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
 )
 
 // MockExecutor is a lowlevel.Exec impleentation for faking system interoperation
@@ -37,6 +38,11 @@ func MakeLvmWithMockExec() (lowlevel.LVM, *MockExecutor) {
 	return lvm, me
 }
 
+// WithContext is mock for Exec.WithContext(). The context is ignored.
+func (mex *MockExecutor) WithContext(context.Context) lowlevel.Exec {
+	return mex
+}
+
 // Run is mock for Exec.Run()
 func (mex *MockExecutor) Run(prog string, args []string) error {
 	c := mex.Called(prog, args)
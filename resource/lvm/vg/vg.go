@@ -36,7 +36,9 @@ type resourceVG struct {
 	devicesToRemove []string
 }
 
-func (r *resourceVG) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+func (r *resourceVG) Check(ctx context.Context, _ resource.Renderer) (resource.TaskStatus, error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	status := &resource.Status{}
 
 	if err := r.lvm.Check(); err != nil {
@@ -96,7 +98,9 @@ func (r *resourceVG) Check(context.Context, resource.Renderer) (resource.TaskSta
 	return status, nil
 }
 
-func (r *resourceVG) Apply(context.Context) (status resource.TaskStatus, err error) {
+func (r *resourceVG) Apply(ctx context.Context) (status resource.TaskStatus, err error) {
+	r.lvm = r.lvm.WithContext(ctx)
+
 	if r.exists {
 		for _, d := range r.devicesToAdd {
 			if err := r.lvm.ExtendVolumeGroup(r.name, d); err != nil {
@@ -0,0 +1,43 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"github.com/asteris-llc/converge/load/registry"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/value"
+	"golang.org/x/net/context"
+)
+
+// Preparer for outputs
+//
+// Output exposes a value from a module to the module that calls it. Use
+// `{{lookup "module.name.output.value-name"}}` or `{{output "name"
+// "value-name"}}` in the calling module to read it. Outputs are the public
+// interface of a module, so callers don't have to reach into its internals.
+type Preparer struct {
+	// Value is the value of the output. It will usually be a template that
+	// refers to params or to the exported fields of other nodes in the module.
+	Value string `hcl:"value" required:"true"`
+}
+
+// Prepare a new task
+func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
+	return &Output{Rendered: value.Rendered{Value: p.Value}}, nil
+}
+
+func init() {
+	registry.Register("output", (*Preparer)(nil), (*Output)(nil))
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/asteris-llc/converge/resource/package"
+	"golang.org/x/net/context"
 )
 
 // Outputs from dpkg-query
@@ -43,11 +44,11 @@ type Manager struct {
 }
 
 // InstalledVersion gets the installed version of package, if available
-func (a *Manager) InstalledVersion(p string) (pkg.PackageVersion, bool) {
+func (a *Manager) InstalledVersion(ctx context.Context, p string) (pkg.PackageVersion, bool) {
 	var version string
 	var installed bool
 
-	result, err := a.Sys.Run(fmt.Sprintf("dpkg-query -W -f'${Package},${Status},${Version}\n' %s", p))
+	result, err := a.Sys.Run(ctx, fmt.Sprintf("dpkg-query -W -f'${Package},${Status},${Version}\n' %s", p))
 	exitCode, _ := pkg.GetExitCode(err)
 	if exitCode != 0 {
 		return "", false
@@ -72,19 +73,19 @@ func (a *Manager) InstalledVersion(p string) (pkg.PackageVersion, bool) {
 }
 
 // InstallPackage installs a package, returning an error if something went wrong
-func (a *Manager) InstallPackage(p string) (string, error) {
-	if _, isInstalled := a.InstalledVersion(p); isInstalled {
+func (a *Manager) InstallPackage(ctx context.Context, p string) (string, error) {
+	if _, isInstalled := a.InstalledVersion(ctx, p); isInstalled {
 		return "already installed", nil
 	}
-	res, err := a.Sys.Run(fmt.Sprintf("apt-get install -y %s", p))
+	res, err := a.Sys.Run(ctx, fmt.Sprintf("apt-get install -y %s", p))
 	return string(res), err
 }
 
 // RemovePackage removes a package, returning an error if something went wrong
-func (a *Manager) RemovePackage(p string) (string, error) {
-	switch _, isInstalled := a.InstalledVersion(p); isInstalled {
+func (a *Manager) RemovePackage(ctx context.Context, p string) (string, error) {
+	switch _, isInstalled := a.InstalledVersion(ctx, p); isInstalled {
 	case true:
-		res, err := a.Sys.Run(fmt.Sprintf("apt-get purge -y %s", p))
+		res, err := a.Sys.Run(ctx, fmt.Sprintf("apt-get purge -y %s", p))
 		return string(res), err
 	default:
 		return "package is not installed ", nil
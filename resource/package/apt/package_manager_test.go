@@ -22,6 +22,7 @@ import (
 	"github.com/asteris-llc/converge/resource/package/apt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
 )
 
 // TestAptInstalledVersion validates that installation status is successfully
@@ -34,7 +35,7 @@ func TestAptInstalledVersion(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgInstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		result, found := a.InstalledVersion("foo")
+		result, found := a.InstalledVersion(context.Background(), "foo")
 		assert.True(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -42,7 +43,7 @@ func TestAptInstalledVersion(t *testing.T) {
 	t.Run("when not installed", func(t *testing.T) {
 		expected := ""
 		a := &apt.Manager{Sys: newRunner("", makeExitError("", 1))}
-		result, found := a.InstalledVersion("foo1")
+		result, found := a.InstalledVersion(context.Background(), "foo1")
 		assert.False(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -52,7 +53,7 @@ func TestAptInstalledVersion(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgHold)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		result, found := a.InstalledVersion("foo")
+		result, found := a.InstalledVersion(context.Background(), "foo")
 		assert.True(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -62,7 +63,7 @@ func TestAptInstalledVersion(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgRemoved)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		result, found := a.InstalledVersion("foo")
+		result, found := a.InstalledVersion(context.Background(), "foo")
 		assert.False(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -72,7 +73,7 @@ func TestAptInstalledVersion(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgUninstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		result, found := a.InstalledVersion("foo")
+		result, found := a.InstalledVersion(context.Background(), "foo")
 		assert.False(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -89,7 +90,7 @@ func TestAptInstallPackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgInstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.InstallPackage(pkg)
+		_, err := a.InstallPackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -98,7 +99,7 @@ func TestAptInstallPackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", makeExitError("", 1))
 		a := &apt.Manager{Sys: runner}
-		a.InstallPackage(pkg)
+		a.InstallPackage(context.Background(), pkg)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
 
@@ -107,7 +108,7 @@ func TestAptInstallPackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgRemoved)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.InstallPackage(pkg)
+		_, err := a.InstallPackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -117,7 +118,7 @@ func TestAptInstallPackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgUninstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.InstallPackage(pkg)
+		_, err := a.InstallPackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -127,7 +128,7 @@ func TestAptInstallPackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgHold)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.InstallPackage(pkg)
+		_, err := a.InstallPackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -136,7 +137,7 @@ func TestAptInstallPackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", makeExitError("", 1))
 		a := &apt.Manager{Sys: runner}
-		_, err := a.InstallPackage(pkg)
+		_, err := a.InstallPackage(context.Background(), pkg)
 		assert.Error(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -152,7 +153,7 @@ func TestAptRemovePackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgInstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.RemovePackage(pkg)
+		_, err := a.RemovePackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -162,7 +163,7 @@ func TestAptRemovePackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgHold)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.RemovePackage(pkg)
+		_, err := a.RemovePackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -172,7 +173,7 @@ func TestAptRemovePackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgUninstalled)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.RemovePackage(pkg)
+		_, err := a.RemovePackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -182,7 +183,7 @@ func TestAptRemovePackage(t *testing.T) {
 		out := fmt.Sprintf("foo,%s,0.1.2.3", apt.PkgRemoved)
 		runner := newRunner(out, nil)
 		a := &apt.Manager{Sys: runner}
-		_, err := a.RemovePackage(pkg)
+		_, err := a.RemovePackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -191,7 +192,7 @@ func TestAptRemovePackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", makeExitError("", 1))
 		a := &apt.Manager{Sys: runner}
-		_, err := a.RemovePackage(pkg)
+		_, err := a.RemovePackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -204,7 +205,7 @@ type MockRunner struct {
 }
 
 // Run mocks out Run
-func (m *MockRunner) Run(cmd string) ([]byte, error) {
+func (m *MockRunner) Run(ctx context.Context, cmd string) ([]byte, error) {
 	args := m.Called(1)
 	return args.Get(0).([]byte), args.Error(1)
 }
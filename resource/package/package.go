@@ -43,13 +43,13 @@ const (
 type PackageManager interface {
 	// If the package is installed, returns the version and true, otherwise
 	// returns an empty string and false.
-	InstalledVersion(string) (PackageVersion, bool)
+	InstalledVersion(context.Context, string) (PackageVersion, bool)
 
 	// Installs a package, returning an error if something went wrong
-	InstallPackage(string) (string, error)
+	InstallPackage(context.Context, string) (string, error)
 
 	// Removes a package, returning an error if something went wrong
-	RemovePackage(string) (string, error)
+	RemovePackage(context.Context, string) (string, error)
 }
 
 // Package is an API for package state
@@ -62,9 +62,10 @@ type Package struct {
 	PkgMgr PackageManager
 }
 
-// SysCaller allows us to mock exec.Command
+// SysCaller allows us to mock exec.Command. Commands are killed when the
+// context is cancelled.
 type SysCaller interface {
-	Run(string) ([]byte, error)
+	Run(context.Context, string) ([]byte, error)
 }
 
 // ExecCaller is a dummy struct to handle wrapping exec.Command in the SysCaller
@@ -72,8 +73,8 @@ type SysCaller interface {
 type ExecCaller struct{}
 
 // Run executes `cmd` as a /bin/sh script and returns the output and error
-func (e ExecCaller) Run(cmd string) ([]byte, error) {
-	return exec.Command("sh", "-c", cmd).Output()
+func (e ExecCaller) Run(ctx context.Context, cmd string) ([]byte, error) {
+	return exec.CommandContext(ctx, "sh", "-c", cmd).Output()
 }
 
 // GetExitCode returns the exit code of an error
@@ -93,30 +94,30 @@ func GetExitCode(err error) (uint32, error) {
 }
 
 // Check if the package has to be 'present', or 'absent'
-func (p *Package) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+func (p *Package) Check(ctx context.Context, _ resource.Renderer) (resource.TaskStatus, error) {
 	status := resource.NewStatus()
-	if p.State == p.PackageState() {
+	if p.State == p.PackageState(ctx) {
 		return status, nil
 	}
-	status.AddDifference(p.Name, string(p.PackageState()), string(p.State), "")
+	status.AddDifference(p.Name, string(p.PackageState(ctx)), string(p.State), "")
 	status.RaiseLevel(resource.StatusWillChange)
 	return status, nil
 }
 
 // Apply desired package state
-func (p *Package) Apply(context.Context) (resource.TaskStatus, error) {
+func (p *Package) Apply(ctx context.Context) (resource.TaskStatus, error) {
 	var err error
 	status := resource.NewStatus()
-	if p.State == p.PackageState() {
+	if p.State == p.PackageState(ctx) {
 		return status, nil
 	}
 
 	var results string
 	if p.State == StatePresent {
-		results, err = p.PkgMgr.InstallPackage(p.Name)
+		results, err = p.PkgMgr.InstallPackage(ctx, p.Name)
 		status.AddMessage("installed " + p.Name)
 	} else {
-		results, err = p.PkgMgr.RemovePackage(p.Name)
+		results, err = p.PkgMgr.RemovePackage(ctx, p.Name)
 		status.AddMessage("removed  " + p.Name)
 	}
 
@@ -124,15 +125,15 @@ func (p *Package) Apply(context.Context) (resource.TaskStatus, error) {
 	if err != nil {
 		return status, err
 	}
-	status.AddDifference(p.Name, string(p.PackageState()), string(p.State), "")
+	status.AddDifference(p.Name, string(p.PackageState(ctx)), string(p.State), "")
 	status.RaiseLevel(resource.StatusWillChange)
 	return status, nil
 }
 
 // PackageState returns a State ("present","absent") based on whether a package
 // is installed or not.
-func (p *Package) PackageState() State {
-	if _, installed := p.PkgMgr.InstalledVersion(p.Name); installed {
+func (p *Package) PackageState(ctx context.Context) State {
+	if _, installed := p.PkgMgr.InstalledVersion(ctx, p.Name); installed {
 		return StatePresent
 	}
 	return StateAbsent
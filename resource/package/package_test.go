@@ -41,11 +41,11 @@ func TestPackageState(t *testing.T) {
 	p := &pkg.Package{Name: "foo"}
 	t.Run("when installed", func(t *testing.T) {
 		p.PkgMgr = &rpm.YumManager{Sys: newRunner("", makeExitError("", 0))}
-		assert.Equal(t, pkg.StatePresent, p.PackageState())
+		assert.Equal(t, pkg.StatePresent, p.PackageState(context.Background()))
 	})
 	t.Run("when not installed", func(t *testing.T) {
 		p.PkgMgr = &rpm.YumManager{Sys: newRunner("", makeExitError("", 1))}
-		assert.Equal(t, pkg.StateAbsent, p.PackageState())
+		assert.Equal(t, pkg.StateAbsent, p.PackageState(context.Background()))
 	})
 }
 
@@ -117,7 +117,7 @@ type MockRunner struct {
 }
 
 // Run mocks out Run
-func (m *MockRunner) Run(cmd string) ([]byte, error) {
+func (m *MockRunner) Run(ctx context.Context, cmd string) ([]byte, error) {
 	args := m.Called(1)
 	return args.Get(0).([]byte), args.Error(1)
 }
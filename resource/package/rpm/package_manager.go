@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/asteris-llc/converge/resource/package"
+	"golang.org/x/net/context"
 )
 
 // YumManager provides a concrete implementation of PackageManager for yum
@@ -27,8 +28,8 @@ type YumManager struct {
 }
 
 // InstalledVersion gets the installed version of package, if available
-func (y *YumManager) InstalledVersion(p string) (pkg.PackageVersion, bool) {
-	result, err := y.Sys.Run(fmt.Sprintf("rpm -q %s", p))
+func (y *YumManager) InstalledVersion(ctx context.Context, p string) (pkg.PackageVersion, bool) {
+	result, err := y.Sys.Run(ctx, fmt.Sprintf("rpm -q %s", p))
 	exitCode, _ := pkg.GetExitCode(err)
 	if exitCode != 0 {
 		return "", false
@@ -37,16 +38,16 @@ func (y *YumManager) InstalledVersion(p string) (pkg.PackageVersion, bool) {
 }
 
 // InstallPackage installs a package, returning an error if something went wrong
-func (y *YumManager) InstallPackage(pkg string) (string, error) {
-	if _, isInstalled := y.InstalledVersion(pkg); isInstalled {
+func (y *YumManager) InstallPackage(ctx context.Context, pkg string) (string, error) {
+	if _, isInstalled := y.InstalledVersion(ctx, pkg); isInstalled {
 		return "already installed", nil
 	}
-	res, err := y.Sys.Run(fmt.Sprintf("yum install -y %s", pkg))
+	res, err := y.Sys.Run(ctx, fmt.Sprintf("yum install -y %s", pkg))
 	return string(res), err
 }
 
 // RemovePackage removes a package, returning an error if something went wrong
-func (y *YumManager) RemovePackage(pkg string) (string, error) {
-	res, err := y.Sys.Run(fmt.Sprintf("yum remove -y %s", pkg))
+func (y *YumManager) RemovePackage(ctx context.Context, pkg string) (string, error) {
+	res, err := y.Sys.Run(ctx, fmt.Sprintf("yum remove -y %s", pkg))
 	return string(res), err
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/asteris-llc/converge/resource/package/rpm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
 )
 
 // TestYumInstalledVersion validates that installation status is successfully
@@ -33,7 +34,7 @@ func TestYumInstalledVersion(t *testing.T) {
 		expected := "foo-0.1.2.3"
 		runner := newRunner(expected, nil)
 		y := &rpm.YumManager{Sys: runner}
-		result, found := y.InstalledVersion("foo1")
+		result, found := y.InstalledVersion(context.Background(), "foo1")
 		assert.True(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -41,7 +42,7 @@ func TestYumInstalledVersion(t *testing.T) {
 	t.Run("when not installed", func(t *testing.T) {
 		expected := ""
 		y := &rpm.YumManager{Sys: newRunner("", makeExitError("", 1))}
-		result, found := y.InstalledVersion("foo1")
+		result, found := y.InstalledVersion(context.Background(), "foo1")
 		assert.False(t, found)
 		assert.Equal(t, expected, string(result))
 	})
@@ -56,7 +57,7 @@ func TestYumInstallPackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", nil)
 		y := &rpm.YumManager{Sys: runner}
-		_, err := y.InstallPackage(pkg)
+		_, err := y.InstallPackage(context.Background(), pkg)
 		assert.NoError(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 1)
 	})
@@ -65,7 +66,7 @@ func TestYumInstallPackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", makeExitError("", 1))
 		y := &rpm.YumManager{Sys: runner}
-		y.InstallPackage(pkg)
+		y.InstallPackage(context.Background(), pkg)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
 
@@ -73,7 +74,7 @@ func TestYumInstallPackage(t *testing.T) {
 		pkg := "foo1"
 		runner := newRunner("", makeExitError("", 1))
 		y := &rpm.YumManager{Sys: runner}
-		_, err := y.InstallPackage(pkg)
+		_, err := y.InstallPackage(context.Background(), pkg)
 		assert.Error(t, err)
 		runner.AssertNumberOfCalls(t, "Run", 2)
 	})
@@ -85,7 +86,7 @@ type MockRunner struct {
 }
 
 // Run mocks out Run
-func (m *MockRunner) Run(cmd string) ([]byte, error) {
+func (m *MockRunner) Run(ctx context.Context, cmd string) ([]byte, error) {
 	args := m.Called(1)
 	return args.Get(0).([]byte), args.Error(1)
 }
@@ -27,6 +27,12 @@ type Param struct {
 
 	// the value of the parameter
 	Val interface{} `export:"val"`
+
+	// the declared type of the parameter, if any
+	Type string
+
+	// the description of the parameter, if any
+	Description string
 }
 
 // Check just returns the current value of the parameter. It should never have to change.
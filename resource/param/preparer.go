@@ -34,20 +34,52 @@ type Preparer struct {
 	// provided to this parameter. If this field is not set, this param will be
 	// treated as required.
 	Default interface{} `hcl:"default"`
+
+	// Type is the type of the param's value. Values given as strings (for
+	// example on the command line) are converted to this type, and values that
+	// cannot be converted are an error. Lists and maps can be given as JSON. If
+	// not set, values are used as given.
+	Type string `hcl:"type" valid_values:"string,int,bool,list,map"`
+
+	// Description explains what the param is for. It is shown in the output of
+	// `converge graph --show-params`.
+	Description string `hcl:"description"`
+
+	// ValidValues restricts the param to one of the given values. For list
+	// params, every element must be one of the values.
+	ValidValues []interface{} `hcl:"valid_values"`
+
+	// Pattern is a regular expression that the value must match. For list
+	// params, every element must match.
+	Pattern string `hcl:"pattern"`
+
+	// Min is the smallest allowed value of an int param, or the shortest allowed
+	// length of a string, list, or map param.
+	Min *int `hcl:"min"`
+
+	// Max is the largest allowed value of an int param, or the longest allowed
+	// length of a string, list, or map param.
+	Max *int `hcl:"max"`
 }
 
 // Prepare a new task
 func (p *Preparer) Prepare(ctx context.Context, render resource.Renderer) (resource.Task, error) {
 	paramName := strings.TrimPrefix(graph.BaseID(render.GetID()), "param.")
-	if val, present := render.Value(); present {
-		return &Param{Val: val}, nil
+
+	val, present := render.Value()
+	if !present {
+		if p.Default == nil {
+			return nil, fmt.Errorf("%s param is required", paramName)
+		}
+		val = p.Default
 	}
 
-	if p.Default == nil {
-		return nil, fmt.Errorf("%s param is required", paramName)
+	val, err := p.Check(val)
+	if err != nil {
+		return nil, fmt.Errorf("%s param %s", paramName, err)
 	}
 
-	return &Param{Val: p.Default}, nil
+	return &Param{Val: val, Type: p.Type, Description: p.Description}, nil
 }
 
 func init() {
@@ -77,3 +77,112 @@ func TestPreparerRequired(t *testing.T) {
 		assert.EqualError(t, err, fmt.Sprintf("%s param is required", name))
 	}
 }
+
+func TestPreparerType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts", func(t *testing.T) {
+		cases := []struct {
+			typ      string
+			in       interface{}
+			expected interface{}
+		}{
+			{"string", 1, "1"},
+			{"int", "80", 80},
+			{"int", 80.0, 80},
+			{"bool", "true", true},
+			{"list", `["a", "b"]`, []interface{}{"a", "b"}},
+			{"list", []string{"a"}, []interface{}{"a"}},
+			{"map", `{"a": 1}`, map[string]interface{}{"a": 1.0}},
+			{"map", []map[string]interface{}{{"a": 1}}, map[string]interface{}{"a": 1}},
+		}
+
+		for _, c := range cases {
+			prep := &param.Preparer{Type: c.typ, Default: c.in}
+
+			result, err := prep.Prepare(context.Background(), fakerenderer.New())
+			require.NoError(t, err, "%s %v", c.typ, c.in)
+			assert.Equal(t, c.expected, result.(*param.Param).Val)
+		}
+	})
+
+	t.Run("provided", func(t *testing.T) {
+		prep := &param.Preparer{Type: "int", Default: 80}
+
+		result, err := prep.Prepare(context.Background(), fakerenderer.NewWithValue("8080"))
+		require.NoError(t, err)
+		assert.Equal(t, 8080, result.(*param.Param).Val)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		prep := &param.Preparer{Type: "int"}
+
+		_, err := prep.Prepare(context.Background(), fakerenderer.NewWithID("root/param.port"))
+		assert.EqualError(t, err, "port param is required")
+
+		prep.Default = "eighty"
+		_, err = prep.Prepare(context.Background(), fakerenderer.NewWithID("root/param.port"))
+		assert.EqualError(t, err, `port param must be an int, got "eighty"`)
+	})
+}
+
+func TestPreparerConstraints(t *testing.T) {
+	t.Parallel()
+
+	one, three := 1, 3
+
+	cases := []struct {
+		name string
+		prep *param.Preparer
+		val  interface{}
+		err  string
+	}{
+		{"valid value", &param.Preparer{ValidValues: []interface{}{"a", "b"}}, "a", ""},
+		{"invalid value", &param.Preparer{ValidValues: []interface{}{"a", "b"}}, "c", `must be one of [a b], got "c"`},
+		{"invalid list element", &param.Preparer{Type: "list", ValidValues: []interface{}{"a"}}, `["a", "c"]`, `must be one of [a], got "c"`},
+		{"pattern", &param.Preparer{Pattern: "^a+$"}, "aaa", ""},
+		{"pattern mismatch", &param.Preparer{Pattern: "^a+$"}, "b", `must match "^a+$", got "b"`},
+		{"int below min", &param.Preparer{Type: "int", Min: &one}, 0, "must be at least 1, got 0"},
+		{"int above max", &param.Preparer{Type: "int", Max: &three}, 4, "must be at most 3, got 4"},
+		{"string length", &param.Preparer{Max: &three}, "abcd", "length must be at most 3, got 4"},
+		{"list length", &param.Preparer{Type: "list", Min: &one}, "[]", "length must be at least 1, got 0"},
+	}
+
+	for _, c := range cases {
+		_, err := c.prep.Check(c.val)
+		if c.err == "" {
+			assert.NoError(t, err, c.name)
+		} else {
+			assert.EqualError(t, err, c.err, c.name)
+		}
+	}
+}
+
+func TestPreparerValidate(t *testing.T) {
+	t.Parallel()
+
+	one, three := 1, 3
+
+	cases := []struct {
+		name string
+		prep *param.Preparer
+		err  string
+	}{
+		{"empty", &param.Preparer{}, ""},
+		{"unknown type", &param.Preparer{Type: "float"}, `type must be one of string, int, bool, list, or map, got "float"`},
+		{"bool bounds", &param.Preparer{Type: "bool", Min: &one}, "min and max cannot be used with bool params"},
+		{"min above max", &param.Preparer{Min: &three, Max: &one}, "min (3) is greater than max (1)"},
+		{"bad pattern", &param.Preparer{Pattern: "("}, "pattern is invalid: error parsing regexp: missing closing ): `(`"},
+		{"bad default", &param.Preparer{Type: "int", Default: "x"}, `default must be an int, got "x"`},
+		{"template default", &param.Preparer{Type: "int", Default: "{{param `x`}}"}, ""},
+	}
+
+	for _, c := range cases {
+		err := c.prep.Validate()
+		if c.err == "" {
+			assert.NoError(t, err, c.name)
+		} else {
+			assert.EqualError(t, err, c.err, c.name)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate checks that the declaration of a param makes sense, and that its
+// default satisfies it. It is used to check params when a module is loaded,
+// before any values are known. Defaults that are templates are not checked.
+func (p *Preparer) Validate() error {
+	switch p.Type {
+	case "", "string", "int", "bool", "list", "map":
+	default:
+		return fmt.Errorf("type must be one of string, int, bool, list, or map, got %q", p.Type)
+	}
+
+	if p.Type == "bool" && (p.Min != nil || p.Max != nil) {
+		return errors.New("min and max cannot be used with bool params")
+	}
+
+	if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+		return fmt.Errorf("min (%d) is greater than max (%d)", *p.Min, *p.Max)
+	}
+
+	if _, err := p.compilePattern(); err != nil {
+		return err
+	}
+
+	if str, ok := p.Default.(string); p.Default == nil || ok && strings.Contains(str, "{{") {
+		return nil
+	}
+
+	if _, err := p.Check(p.Default); err != nil {
+		return fmt.Errorf("default %s", err)
+	}
+
+	return nil
+}
+
+// Check converts a value to the type of the param, and checks it against the
+// constraints of the param. The error describes how the value is wrong, in a
+// way that reads after the name of the param.
+func (p *Preparer) Check(val interface{}) (interface{}, error) {
+	val, err := convert(p.Type, val)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := []interface{}{val}
+	if list, ok := val.([]interface{}); ok {
+		elems = list
+	}
+
+	if len(p.ValidValues) > 0 {
+		for _, elem := range elems {
+			if !p.isValid(elem) {
+				return nil, fmt.Errorf("must be one of %v, got %q", p.ValidValues, fmt.Sprint(elem))
+			}
+		}
+	}
+
+	pattern, err := p.compilePattern()
+	if err != nil {
+		return nil, err
+	}
+	if pattern != nil {
+		for _, elem := range elems {
+			if str := fmt.Sprint(elem); !pattern.MatchString(str) {
+				return nil, fmt.Errorf("must match %q, got %q", p.Pattern, str)
+			}
+		}
+	}
+
+	if err := p.checkBounds(val); err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
+
+func (p *Preparer) isValid(val interface{}) bool {
+	str := fmt.Sprint(val)
+	for _, valid := range p.ValidValues {
+		if fmt.Sprint(valid) == str {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Preparer) compilePattern() (*regexp.Regexp, error) {
+	if p.Pattern == "" {
+		return nil, nil
+	}
+
+	pattern, err := regexp.Compile(p.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern is invalid: %s", err)
+	}
+	return pattern, nil
+}
+
+// checkBounds checks an int against min and max, or the length of anything
+// else
+func (p *Preparer) checkBounds(val interface{}) error {
+	if p.Min == nil && p.Max == nil {
+		return nil
+	}
+
+	var (
+		size int
+		what string
+	)
+	switch v := val.(type) {
+	case int:
+		size, what = v, ""
+	case string:
+		size, what = utf8.RuneCountInString(v), "length "
+	case []interface{}:
+		size, what = len(v), "length "
+	case map[string]interface{}:
+		size, what = len(v), "length "
+	default:
+		return fmt.Errorf("cannot be checked against min or max, it is a %T", val)
+	}
+
+	if p.Min != nil && size < *p.Min {
+		return fmt.Errorf("%smust be at least %d, got %d", what, *p.Min, size)
+	}
+	if p.Max != nil && size > *p.Max {
+		return fmt.Errorf("%smust be at most %d, got %d", what, *p.Max, size)
+	}
+
+	return nil
+}
+
+// convert a value to the given type
+func convert(typ string, val interface{}) (interface{}, error) {
+	val = unwrapMap(val)
+
+	switch typ {
+	case "string":
+		switch v := val.(type) {
+		case string:
+			return v, nil
+		case int, int64, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+		return nil, fmt.Errorf("must be a string, got %s", describe(val))
+
+	case "int":
+		switch v := val.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return i, nil
+			}
+		}
+		return nil, fmt.Errorf("must be an int, got %s", describe(val))
+
+	case "bool":
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("must be a bool, got %s", describe(val))
+
+	case "list":
+		if str, ok := val.(string); ok {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(str), &decoded); err == nil {
+				val = decoded
+			}
+		}
+
+		list := reflect.ValueOf(val)
+		if val == nil || list.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("must be a list, got %s", describe(val))
+		}
+
+		out := make([]interface{}, list.Len())
+		for i := range out {
+			out[i] = unwrapMap(list.Index(i).Interface())
+		}
+		return out, nil
+
+	case "map":
+		if str, ok := val.(string); ok {
+			var decoded map[string]interface{}
+			if err := json.Unmarshal([]byte(str), &decoded); err == nil {
+				val = decoded
+			}
+		}
+
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("must be a map, got %s", describe(val))
+		}
+		return m, nil
+	}
+
+	return val, nil
+}
+
+// unwrapMap turns the single-element list of maps that HCL decodes objects into
+// back into a map
+func unwrapMap(val interface{}) interface{} {
+	if maps, ok := val.([]map[string]interface{}); ok && len(maps) == 1 {
+		return maps[0]
+	}
+	return val
+}
+
+func describe(val interface{}) string {
+	if str, ok := val.(string); ok {
+		return strconv.Quote(str)
+	}
+	return fmt.Sprintf("%T", val)
+}
@@ -112,6 +112,25 @@ func (p *Preparer) Prepare(ctx context.Context, r Renderer) (Task, error) {
 	return resource.Prepare(ctx, r)
 }
 
+// HasField returns true if the wrapped resource has a field with the given
+// name
+func (p *Preparer) HasField(name string) bool {
+	typ := reflect.TypeOf(p.Destination)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if p.getFieldName(typ.Field(i)) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Preparer) validateExtra(typ reflect.Type) error {
 	if typ.Kind() != reflect.Struct {
 		return errors.New("can't validate extra on a non-struct type")
@@ -125,6 +144,13 @@ func (p *Preparer) validateExtra(typ reflect.Type) error {
 	// add special fields
 	fieldNames["depends"] = struct{}{}
 	fieldNames["group"] = struct{}{}
+	fieldNames["notify"] = struct{}{}
+	fieldNames["subscribes"] = struct{}{}
+	fieldNames[RetriesField] = struct{}{}
+	fieldNames[RetryIntervalField] = struct{}{}
+	fieldNames[RetryBackoffField] = struct{}{}
+	fieldNames[TimeoutField] = struct{}{}
+	fieldNames[IgnoreErrorsField] = struct{}{}
 
 	var err error
 	for key := range p.Source {
@@ -32,6 +32,13 @@ type Task interface {
 	Apply(context.Context) (TaskStatus, error)
 }
 
+// Rollbacker is optionally implemented by tasks that can undo their last
+// Apply. Rollback will only be called after a successful Apply, and should
+// restore whatever state the resource had before it.
+type Rollbacker interface {
+	Rollback(context.Context) (TaskStatus, error)
+}
+
 // Resource adds metadata about the executed tasks
 type Resource interface {
 	Prepare(context.Context, Renderer) (Task, error)
@@ -0,0 +1,132 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// The fields that configure retries. They are accepted on any resource.
+const (
+	RetriesField       = "retries"
+	RetryIntervalField = "retry_interval"
+	RetryBackoffField  = "retry_backoff"
+)
+
+// DefaultRetryInterval is the time to wait before the first retry when
+// retry_interval is not set
+const DefaultRetryInterval = 5 * time.Second
+
+// RetryPolicy describes how the application of a failing task is retried
+type RetryPolicy struct {
+	// Retries is the number of times to retry after the first attempt fails
+	Retries int
+
+	// Interval is the time to wait before the first retry
+	Interval time.Duration
+
+	// Backoff multiplies the interval after every retry. A backoff of 1 waits
+	// the same interval between every attempt.
+	Backoff float64
+}
+
+// NewRetryPolicy reads a retry policy from the source of a resource. It
+// returns nil if the source does not set any retries, or sets them to 0.
+func NewRetryPolicy(source map[string]interface{}) (*RetryPolicy, error) {
+	raw, ok := source[RetriesField]
+	if !ok {
+		if _, ok := source[RetryIntervalField]; ok {
+			return nil, fmt.Errorf("%s is set without %s", RetryIntervalField, RetriesField)
+		}
+		if _, ok := source[RetryBackoffField]; ok {
+			return nil, fmt.Errorf("%s is set without %s", RetryBackoffField, RetriesField)
+		}
+		return nil, nil
+	}
+
+	policy := &RetryPolicy{Interval: DefaultRetryInterval, Backoff: 1}
+
+	retries, err := strconv.Atoi(fmt.Sprintf("%v", raw))
+	if err != nil || retries < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer, got %v", RetriesField, raw)
+	}
+	policy.Retries = retries
+
+	if raw, ok := source[RetryIntervalField]; ok {
+		interval, err := parseDuration(RetryIntervalField, raw)
+		if err != nil {
+			return nil, err
+		}
+		if interval < 0 {
+			return nil, fmt.Errorf("%s must not be negative, got %v", RetryIntervalField, raw)
+		}
+		policy.Interval = interval
+	}
+
+	if raw, ok := source[RetryBackoffField]; ok {
+		backoff, err := strconv.ParseFloat(fmt.Sprintf("%v", raw), 64)
+		if err != nil || backoff < 1 {
+			return nil, fmt.Errorf("%s must be a number no less than 1, got %v", RetryBackoffField, raw)
+		}
+		policy.Backoff = backoff
+	}
+
+	if policy.Retries == 0 {
+		return nil, nil
+	}
+
+	return policy, nil
+}
+
+// Attempts is the maximum number of times the task will be applied
+func (p *RetryPolicy) Attempts() int {
+	return p.Retries + 1
+}
+
+// Delay is the time to wait before the given retry. Retries are numbered from
+// 1.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	if retry < 1 {
+		return 0
+	}
+	return time.Duration(float64(p.Interval) * math.Pow(p.Backoff, float64(retry-1)))
+}
+
+// parseDuration accepts a number of seconds or a duration string like "1m30s",
+// in the same way as duration fields on resources
+func parseDuration(field string, raw interface{}) (time.Duration, error) {
+	switch val := raw.(type) {
+	case int:
+		return time.Duration(val) * time.Second, nil
+	case int64:
+		return time.Duration(val) * time.Second, nil
+	case float64:
+		return time.Duration(val * float64(time.Second)), nil
+	case string:
+		if seconds, err := strconv.Atoi(val); err == nil {
+			return time.Duration(seconds) * time.Second, nil
+		}
+		duration, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a duration, got %q", field, val)
+		}
+		return duration, nil
+	default:
+		return 0, fmt.Errorf("%s must be a duration, got %v", field, raw)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewRetryPolicy tests reading retry policies from resource sources
+func TestNewRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		policy, err := resource.NewRetryPolicy(map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		policy, err := resource.NewRetryPolicy(map[string]interface{}{"retries": 0})
+		assert.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		policy, err := resource.NewRetryPolicy(map[string]interface{}{"retries": 3})
+		require.NoError(t, err)
+		assert.Equal(t, &resource.RetryPolicy{Retries: 3, Interval: resource.DefaultRetryInterval, Backoff: 1}, policy)
+		assert.Equal(t, 4, policy.Attempts())
+	})
+
+	t.Run("all", func(t *testing.T) {
+		policy, err := resource.NewRetryPolicy(map[string]interface{}{
+			"retries":        "2",
+			"retry_interval": "1m30s",
+			"retry_backoff":  1.5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &resource.RetryPolicy{Retries: 2, Interval: 90 * time.Second, Backoff: 1.5}, policy)
+	})
+
+	t.Run("interval in seconds", func(t *testing.T) {
+		policy, err := resource.NewRetryPolicy(map[string]interface{}{"retries": 1, "retry_interval": 10})
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, policy.Interval)
+	})
+
+	errorCases := []struct {
+		name   string
+		source map[string]interface{}
+		err    string
+	}{
+		{"bad retries", map[string]interface{}{"retries": "x"}, "retries must be a non-negative integer, got x"},
+		{"negative retries", map[string]interface{}{"retries": -1}, "retries must be a non-negative integer, got -1"},
+		{"bad interval", map[string]interface{}{"retries": 1, "retry_interval": "x"}, `retry_interval must be a duration, got "x"`},
+		{"negative interval", map[string]interface{}{"retries": 1, "retry_interval": "-1s"}, "retry_interval must not be negative, got -1s"},
+		{"bad backoff", map[string]interface{}{"retries": 1, "retry_backoff": 0.5}, "retry_backoff must be a number no less than 1, got 0.5"},
+		{"interval without retries", map[string]interface{}{"retry_interval": 1}, "retry_interval is set without retries"},
+		{"backoff without retries", map[string]interface{}{"retry_backoff": 2}, "retry_backoff is set without retries"},
+	}
+	for _, tc := range errorCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resource.NewRetryPolicy(tc.source)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// TestRetryPolicyDelay tests the delay between retries
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("constant", func(t *testing.T) {
+		policy := &resource.RetryPolicy{Retries: 3, Interval: time.Second, Backoff: 1}
+		assert.Equal(t, time.Second, policy.Delay(1))
+		assert.Equal(t, time.Second, policy.Delay(3))
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		policy := &resource.RetryPolicy{Retries: 3, Interval: time.Second, Backoff: 2}
+		assert.Equal(t, time.Second, policy.Delay(1))
+		assert.Equal(t, 2*time.Second, policy.Delay(2))
+		assert.Equal(t, 4*time.Second, policy.Delay(3))
+	})
+}
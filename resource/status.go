@@ -17,6 +17,8 @@ package resource
 import (
 	"errors"
 	"fmt"
+
+	"github.com/asteris-llc/converge/secret"
 )
 
 // StatusLevel will be used as a level in Status. It indicates if a resource
@@ -214,9 +216,9 @@ func (t *Status) StatusCode() StatusLevel {
 	return t.Level
 }
 
-// Messages returns the current output slice
+// Messages returns the current output slice, with sensitive values masked
 func (t *Status) Messages() []string {
-	return t.Output
+	return secret.RedactAll(t.Output)
 }
 
 // HasChanges returns the WillChange value
@@ -301,20 +303,21 @@ type TextDiff struct {
 	Values  [2]string
 }
 
-// Original returns the unmodified value of the diff
+// Original returns the unmodified value of the diff, with sensitive values
+// masked
 func (t TextDiff) Original() string {
 	if t.Values[0] == "" {
 		return t.Default
 	}
-	return t.Values[0]
+	return secret.Redact(t.Values[0])
 }
 
-// Current returns the modified value of the diff
+// Current returns the modified value of the diff, with sensitive values masked
 func (t TextDiff) Current() string {
 	if t.Values[1] == "" {
 		return t.Default
 	}
-	return t.Values[1]
+	return secret.Redact(t.Values[1])
 }
 
 // Changes is true if the Original and Current values differ
@@ -21,6 +21,7 @@ import (
 
 	"github.com/asteris-llc/converge/healthcheck"
 	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/secret"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -139,3 +140,20 @@ func TestStatusError(t *testing.T) {
 		})
 	}
 }
+
+// TestStatusMasksSecrets makes sure decrypted values are never shown
+func TestStatusMasksSecrets(t *testing.T) {
+	t.Parallel()
+
+	secret.Register("status-test-secret")
+
+	status := resource.NewStatus()
+	status.AddDifference("password", "old", "status-test-secret", "")
+	status.AddMessage("set password to status-test-secret")
+
+	diff := status.Diffs()["password"]
+	assert.Equal(t, "old", diff.Original())
+	assert.Equal(t, secret.Mask, diff.Current())
+	assert.True(t, diff.Changes())
+	assert.Equal(t, []string{"set password to <sensitive>"}, status.Messages())
+}
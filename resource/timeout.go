@@ -0,0 +1,106 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// TimeoutField is the field that sets a timeout on any resource. Resources
+// that have their own timeout field handle it themselves instead.
+const TimeoutField = "timeout"
+
+// TimeoutError is returned when a stage of a resource does not finish in time
+type TimeoutError struct {
+	Stage   string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s", e.Stage, e.Timeout)
+}
+
+// ParseTimeout reads the timeout from the source of a resource. It returns 0
+// if no timeout is set.
+func ParseTimeout(source map[string]interface{}) (time.Duration, error) {
+	raw, ok := source[TimeoutField]
+	if !ok {
+		return 0, nil
+	}
+
+	timeout, err := parseDuration(TimeoutField, raw)
+	if err != nil {
+		return 0, err
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %v", TimeoutField, raw)
+	}
+
+	return timeout, nil
+}
+
+// TimeoutGracePeriod is how long RunWithTimeout waits for a function to
+// return after its context is cancelled
+var TimeoutGracePeriod = 10 * time.Second
+
+// RunWithTimeout runs fn with a context that is cancelled after the timeout.
+// Once the context is cancelled, fn has TimeoutGracePeriod to return, so that a
+// resource that is still changing the system is not reported as finished while
+// it runs alongside a retry or rollback. A resource that ignores its context
+// and does not return by then is left running in the background, since there is
+// no way to stop it, and a *TimeoutError is returned anyway so it can not block
+// the run. A timeout of 0 runs fn with the given context.
+func RunWithTimeout(ctx context.Context, stage string, timeout time.Duration, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn(ctx)
+		done <- result{value, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil && ctx.Err() == context.DeadlineExceeded {
+			return res.value, &TimeoutError{Stage: stage, Timeout: timeout}
+		}
+		return res.value, res.err
+
+	case <-ctx.Done():
+		var value interface{}
+		select {
+		case res := <-done:
+			value = res.value
+		case <-time.After(TimeoutGracePeriod):
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return value, &TimeoutError{Stage: stage, Timeout: timeout}
+		}
+		return value, ctx.Err()
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asteris-llc/converge/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// TestParseTimeout tests reading timeouts from resource sources
+func TestParseTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		timeout, err := resource.ParseTimeout(map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), timeout)
+	})
+
+	t.Run("seconds", func(t *testing.T) {
+		timeout, err := resource.ParseTimeout(map[string]interface{}{"timeout": 30})
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, timeout)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		timeout, err := resource.ParseTimeout(map[string]interface{}{"timeout": "2m"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, timeout)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := resource.ParseTimeout(map[string]interface{}{"timeout": "soon"})
+		assert.EqualError(t, err, `timeout must be a duration, got "soon"`)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := resource.ParseTimeout(map[string]interface{}{"timeout": "-1s"})
+		assert.EqualError(t, err, "timeout must not be negative, got -1s")
+	})
+}
+
+// TestRunWithTimeout tests running functions with a deadline
+func TestRunWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no timeout", func(t *testing.T) {
+		value, err := resource.RunWithTimeout(context.Background(), "check", 0, func(ctx context.Context) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+			return 1, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("finishes in time", func(t *testing.T) {
+		value, err := resource.RunWithTimeout(context.Background(), "check", time.Minute, func(ctx context.Context) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			return 1, errors.New("failed")
+		})
+		assert.EqualError(t, err, "failed")
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("waits after cancelling", func(t *testing.T) {
+		finished := false
+		_, err := resource.RunWithTimeout(context.Background(), "apply", 10*time.Millisecond, func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			finished = true
+			return nil, ctx.Err()
+		})
+		assert.EqualError(t, err, "apply timed out after 10ms")
+		assert.True(t, finished, "returned before fn")
+	})
+
+	t.Run("ignores context", func(t *testing.T) {
+		defer func(grace time.Duration) { resource.TimeoutGracePeriod = grace }(resource.TimeoutGracePeriod)
+		resource.TimeoutGracePeriod = 10 * time.Millisecond
+
+		block := make(chan struct{})
+		defer close(block)
+
+		value, err := resource.RunWithTimeout(context.Background(), "apply", 10*time.Millisecond, func(ctx context.Context) (interface{}, error) {
+			<-block
+			return 1, nil
+		})
+		require.Error(t, err)
+		assert.IsType(t, &resource.TimeoutError{}, err)
+		assert.EqualError(t, err, "apply timed out after 10ms")
+		assert.Nil(t, value)
+	})
+
+	t.Run("respects context", func(t *testing.T) {
+		_, err := resource.RunWithTimeout(context.Background(), "apply", 10*time.Millisecond, func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		assert.EqualError(t, err, "apply timed out after 10ms")
+	})
+}
@@ -81,6 +81,21 @@ type User struct {
 	State State `export:"state"`
 
 	system SystemUtils
+
+	// how to undo the last Apply
+	rollback *userRollback
+}
+
+// userRollback records how to undo an Apply. Only one of the fields is set.
+type userRollback struct {
+	// the user was added and should be deleted
+	del bool
+
+	// the user was deleted and should be added again with these options
+	add *AddUserOptions
+
+	// the user was modified and should be modified back with these options
+	mod *ModUserOptions
 }
 
 // AddUserOptions are the options specified in the configuration to be used
@@ -109,9 +124,9 @@ type ModUserOptions struct {
 
 // SystemUtils provides system utilities for user
 type SystemUtils interface {
-	AddUser(userName string, options *AddUserOptions) error
-	DelUser(userName string) error
-	ModUser(userName string, options *ModUserOptions) error
+	AddUser(ctx context.Context, userName string, options *AddUserOptions) error
+	DelUser(ctx context.Context, userName string) error
+	ModUser(ctx context.Context, userName string, options *ModUserOptions) error
 	LookupUserExpiry(userName string) (time.Time, error)
 	Lookup(userName string) (*user.User, error)
 	LookupID(userID string) (*user.User, error)
@@ -182,7 +197,7 @@ func (u *User) Check(context.Context, resource.Renderer) (resource.TaskStatus, e
 }
 
 // Apply changes for user
-func (u *User) Apply(context.Context) (resource.TaskStatus, error) {
+func (u *User) Apply(ctx context.Context) (resource.TaskStatus, error) {
 	// lookup the user by name
 	// ErrUnsupported is returned if the system is not supported
 	// Lookup returns user.UnknownUserError if the user is not found
@@ -206,13 +221,14 @@ func (u *User) Apply(context.Context) (resource.TaskStatus, error) {
 				return status, errors.Wrapf(err, "will not attempt to add user %s", u.Username)
 			}
 			if resource.AnyChanges(status.Differences) {
-				err = u.system.AddUser(u.Username, options)
+				err = u.system.AddUser(ctx, u.Username, options)
 				if err != nil {
 					status.RaiseLevel(resource.StatusFatal)
 					status.AddMessage(fmt.Sprintf("error adding user %s", u.Username))
 					return status, errors.Wrap(err, "user add")
 				}
 				status.AddMessage(fmt.Sprintf("added user %s", u.Username))
+				u.rollback = &userRollback{del: true}
 				if u.CreateHome {
 					u.createHomeDiffs(status)
 				}
@@ -223,13 +239,18 @@ func (u *User) Apply(context.Context) (resource.TaskStatus, error) {
 				return status, errors.Wrapf(err, "will not attempt to modify user %s", u.Username)
 			}
 			if resource.AnyChanges(status.Differences) {
-				err = u.system.ModUser(u.Username, options)
+				undo, err := u.undoMod(userByName, options)
+				if err != nil {
+					return status, errors.Wrapf(err, "will not attempt to modify user %s", u.Username)
+				}
+				err = u.system.ModUser(ctx, u.Username, options)
 				if err != nil {
 					status.RaiseLevel(resource.StatusFatal)
 					status.AddMessage(fmt.Sprintf("error modifying user %s", u.Username))
 					return status, errors.Wrap(err, "user modify")
 				}
 				status.AddMessage(fmt.Sprintf("modified user %s", u.Username))
+				u.rollback = &userRollback{mod: undo}
 			}
 		}
 	case StateAbsent:
@@ -238,13 +259,14 @@ func (u *User) Apply(context.Context) (resource.TaskStatus, error) {
 			return status, errors.Wrapf(err, "will not attempt to delete user %s", u.Username)
 		}
 		if resource.AnyChanges(status.Differences) {
-			err = u.system.DelUser(u.Username)
+			err = u.system.DelUser(ctx, u.Username)
 			if err != nil {
 				status.RaiseLevel(resource.StatusFatal)
 				status.AddMessage(fmt.Sprintf("error deleting user %s", u.Username))
 				return status, errors.Wrap(err, "user delete")
 			}
 			status.AddMessage(fmt.Sprintf("deleted user %s", u.Username))
+			u.rollback = &userRollback{add: undoDel(userByName)}
 		}
 	default:
 		status.RaiseLevel(resource.StatusFatal)
@@ -254,6 +276,92 @@ func (u *User) Apply(context.Context) (resource.TaskStatus, error) {
 	return status, nil
 }
 
+// Rollback undoes the last Apply. Users that were added are deleted, users
+// that were deleted are added back with their previous uid, group, name and
+// home directory, and users that were modified are modified back.
+func (u *User) Rollback(ctx context.Context) (resource.TaskStatus, error) {
+	status := resource.NewStatus()
+
+	switch {
+	case u.rollback == nil:
+		status.AddMessage("nothing to roll back")
+		return status, nil
+
+	case u.rollback.del:
+		if err := u.system.DelUser(ctx, u.Username); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrap(err, "user delete")
+		}
+		status.AddDifference("user", u.Username, fmt.Sprintf("<%s>", string(StateAbsent)), "")
+
+	case u.rollback.add != nil:
+		if err := u.system.AddUser(ctx, u.Username, u.rollback.add); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrap(err, "user add")
+		}
+		status.AddDifference("user", fmt.Sprintf("<%s>", string(StateAbsent)), u.Username, "")
+
+	case u.rollback.mod != nil:
+		name := u.Username
+		if u.NewUsername != "" {
+			name = u.NewUsername
+		}
+		if err := u.system.ModUser(ctx, name, u.rollback.mod); err != nil {
+			status.RaiseLevel(resource.StatusFatal)
+			return status, errors.Wrap(err, "user modify")
+		}
+		status.AddMessage(fmt.Sprintf("restored user %s", u.Username))
+	}
+
+	u.rollback = nil
+
+	return status, nil
+}
+
+// undoMod builds the options to restore a user that is about to be modified
+// with the given options
+func (u *User) undoMod(prev *user.User, applied *ModUserOptions) (*ModUserOptions, error) {
+	options := new(ModUserOptions)
+	if applied.Username != "" {
+		options.Username = u.Username
+	}
+	if applied.UID != "" {
+		options.UID = prev.Uid
+	}
+	if applied.Group != "" {
+		options.Group = prev.Gid
+	}
+	if applied.Comment != "" {
+		options.Comment = prev.Name
+	}
+	if applied.Directory != "" {
+		options.Directory = prev.HomeDir
+		options.MoveDir = applied.MoveDir
+	}
+	if applied.Expiry != "" {
+		expiry, err := u.system.LookupUserExpiry(u.Username)
+		if err != nil {
+			return nil, fmt.Errorf("could not acquire current expiry for %s: %s", u.Username, err)
+		}
+		options.Expiry = expiry.Format(ShortForm)
+		if options.Expiry == MaxTime {
+			// usermod removes the expiry when given -1
+			options.Expiry = "-1"
+		}
+	}
+	return options, nil
+}
+
+// undoDel builds the options to add back a user that was deleted
+func undoDel(prev *user.User) *AddUserOptions {
+	return &AddUserOptions{
+		UID:       prev.Uid,
+		Group:     prev.Gid,
+		Comment:   prev.Name,
+		Directory: prev.HomeDir,
+	}
+}
+
 // DiffAdd checks for differences between the current and desired state for the
 // user to be added indicated by the User fields. The options to be used for the
 // add command are set.
@@ -19,23 +19,25 @@ package user
 import (
 	"os/user"
 	"time"
+
+	"golang.org/x/net/context"
 )
 
 // System implements SystemUtils
 type System struct{}
 
 // AddUser implementation for systems which are not supported
-func (s *System) AddUser(userName string, options *AddUserOptions) error {
+func (s *System) AddUser(ctx context.Context, userName string, options *AddUserOptions) error {
 	return ErrUnsupported
 }
 
 // DelUser implementation for systems which are not supported
-func (s *System) DelUser(userName string) error {
+func (s *System) DelUser(ctx context.Context, userName string) error {
 	return ErrUnsupported
 }
 
 // ModUser implementation for systems which are not supported
-func (s *System) ModUser(userName string, options *ModUserOptions) error {
+func (s *System) ModUser(ctx context.Context, userName string, options *ModUserOptions) error {
 	return ErrUnsupported
 }
 
@@ -19,6 +19,7 @@ package user
 import (
 	"bytes"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 	"os/exec"
 	"os/user"
 	"strings"
@@ -29,7 +30,7 @@ import (
 type System struct{}
 
 // AddUser adds a user
-func (s *System) AddUser(userName string, options *AddUserOptions) error {
+func (s *System) AddUser(ctx context.Context, userName string, options *AddUserOptions) error {
 	args := []string{userName}
 	if options.UID != "" {
 		args = append(args, "-u", options.UID)
@@ -53,7 +54,7 @@ func (s *System) AddUser(userName string, options *AddUserOptions) error {
 		args = append(args, "-e", options.Expiry)
 	}
 
-	cmd := exec.Command("useradd", args...)
+	cmd := exec.CommandContext(ctx, "useradd", args...)
 	err := cmd.Run()
 	if err != nil {
 		return errors.Wrap(err, "useradd")
@@ -62,8 +63,8 @@ func (s *System) AddUser(userName string, options *AddUserOptions) error {
 }
 
 // DelUser deletes a user
-func (s *System) DelUser(userName string) error {
-	cmd := exec.Command("userdel", userName)
+func (s *System) DelUser(ctx context.Context, userName string) error {
+	cmd := exec.CommandContext(ctx, "userdel", userName)
 	err := cmd.Run()
 	if err != nil {
 		return errors.Wrap(err, "userdel")
@@ -72,7 +73,7 @@ func (s *System) DelUser(userName string) error {
 }
 
 // ModUser modifies a user
-func (s *System) ModUser(userName string, options *ModUserOptions) error {
+func (s *System) ModUser(ctx context.Context, userName string, options *ModUserOptions) error {
 	args := []string{userName}
 	if options.Username != "" {
 		args = append(args, "-l", options.Username)
@@ -96,7 +97,7 @@ func (s *System) ModUser(userName string, options *ModUserOptions) error {
 		args = append(args, "-e", options.Expiry)
 	}
 
-	cmd := exec.Command("usermod", args...)
+	cmd := exec.CommandContext(ctx, "usermod", args...)
 	err := cmd.Run()
 	if err != nil {
 		return errors.Wrap(err, "usermod")
@@ -561,6 +561,91 @@ func TestApply(t *testing.T) {
 	})
 }
 
+// TestRollback tests Rollback for user
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not applied", func(t *testing.T) {
+		u := user.NewUser(&MockSystem{})
+		u.Username = fakeUsername
+
+		status, err := u.Rollback(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"nothing to roll back"}, status.Messages())
+	})
+
+	t.Run("added", func(t *testing.T) {
+		usr := &os.User{
+			Username: fakeUsername,
+		}
+		m := &MockSystem{}
+		u := user.NewUser(m)
+		u.Username = usr.Username
+		u.State = user.StatePresent
+		options := user.AddUserOptions{}
+
+		m.On("Lookup", u.Username).Return(usr, os.UnknownUserError(""))
+		m.On("AddUser", u.Username, &options).Return(nil)
+		m.On("DelUser", u.Username).Return(nil)
+		_, err := u.Apply(context.Background())
+		require.NoError(t, err)
+
+		status, err := u.Rollback(context.Background())
+		assert.NoError(t, err)
+		m.AssertCalled(t, "DelUser", u.Username)
+		assert.Equal(t, fmt.Sprintf("<%s>", string(user.StateAbsent)), status.Diffs()["user"].Current())
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		usr := &os.User{
+			Username: currUsername,
+			Name:     "before",
+		}
+		m := &MockSystem{}
+		u := user.NewUser(m)
+		u.Username = usr.Username
+		u.Name = "after"
+		u.State = user.StatePresent
+		options := user.ModUserOptions{Comment: u.Name}
+		undo := user.ModUserOptions{Comment: usr.Name}
+
+		m.On("Lookup", u.Username).Return(usr, nil)
+		m.On("ModUser", u.Username, &options).Return(nil)
+		m.On("ModUser", u.Username, &undo).Return(nil)
+		_, err := u.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = u.Rollback(context.Background())
+		assert.NoError(t, err)
+		m.AssertCalled(t, "ModUser", u.Username, &undo)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		usr := &os.User{
+			Username: fakeUsername,
+			Uid:      fakeUID,
+			Gid:      fakeGID,
+			Name:     "test",
+			HomeDir:  "/home/test",
+		}
+		m := &MockSystem{}
+		u := user.NewUser(m)
+		u.Username = usr.Username
+		u.State = user.StateAbsent
+		undo := user.AddUserOptions{UID: usr.Uid, Group: usr.Gid, Comment: usr.Name, Directory: usr.HomeDir}
+
+		m.On("Lookup", u.Username).Return(usr, nil)
+		m.On("DelUser", u.Username).Return(nil)
+		m.On("AddUser", u.Username, &undo).Return(nil)
+		_, err := u.Apply(context.Background())
+		require.NoError(t, err)
+
+		_, err = u.Rollback(context.Background())
+		assert.NoError(t, err)
+		m.AssertCalled(t, "AddUser", u.Username, &undo)
+	})
+}
+
 // TestDiffAdd tests DiffAdd for user
 func TestDiffAdd(t *testing.T) {
 	t.Parallel()
@@ -1449,19 +1534,19 @@ type MockSystem struct {
 }
 
 // AddUser adds a user
-func (m *MockSystem) AddUser(name string, options *user.AddUserOptions) error {
+func (m *MockSystem) AddUser(ctx context.Context, name string, options *user.AddUserOptions) error {
 	args := m.Called(name, options)
 	return args.Error(0)
 }
 
 // DelUser deletes a user
-func (m *MockSystem) DelUser(name string) error {
+func (m *MockSystem) DelUser(ctx context.Context, name string) error {
 	args := m.Called(name)
 	return args.Error(0)
 }
 
 // ModUser modifies a user
-func (m *MockSystem) ModUser(name string, options *user.ModUserOptions) error {
+func (m *MockSystem) ModUser(ctx context.Context, name string, options *user.ModUserOptions) error {
 	args := m.Called(name, options)
 	return args.Error(0)
 }
@@ -1517,7 +1602,7 @@ func (m *MockSystem2) Lookup(name string) (*os.User, error) {
 }
 
 // AddUser adds a user
-func (m *MockSystem2) AddUser(name string, options *user.AddUserOptions) error {
+func (m *MockSystem2) AddUser(ctx context.Context, name string, options *user.AddUserOptions) error {
 	args := m.Called(name, options)
 	return args.Error(0)
 }
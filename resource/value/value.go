@@ -0,0 +1,46 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"github.com/asteris-llc/converge/resource"
+	"golang.org/x/net/context"
+)
+
+// Rendered is a string value that is final once it is rendered. It is the task
+// behind nodes that only carry values, like outputs and locals.
+type Rendered struct {
+	resource.Status
+
+	// the rendered value
+	Value string `export:"value"`
+}
+
+// Check just returns the current value. It should never have to change.
+func (r *Rendered) Check(context.Context, resource.Renderer) (resource.TaskStatus, error) {
+	r.Status = resource.Status{Output: []string{r.Value}}
+
+	return r, nil
+}
+
+// Apply doesn't do anything since rendered values are final
+func (r *Rendered) Apply(context.Context) (resource.TaskStatus, error) {
+	return r, nil
+}
+
+// String is the rendered value
+func (r *Rendered) String() string {
+	return r.Value
+}
@@ -0,0 +1,49 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/fakerenderer"
+	"github.com/asteris-llc/converge/resource"
+	"github.com/asteris-llc/converge/resource/value"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestRenderedInterface(t *testing.T) {
+	t.Parallel()
+
+	assert.Implements(t, (*resource.Task)(nil), new(value.Rendered))
+}
+
+func TestRenderedCheck(t *testing.T) {
+	t.Parallel()
+
+	r := &value.Rendered{Value: "test"}
+
+	status, err := r.Check(context.Background(), fakerenderer.New())
+	assert.NoError(t, err)
+	assert.Contains(t, status.Messages(), "test")
+	assert.False(t, status.HasChanges())
+}
+
+func TestRenderedApply(t *testing.T) {
+	t.Parallel()
+
+	_, err := new(value.Rendered).Apply(context.Background())
+	assert.NoError(t, err)
+}
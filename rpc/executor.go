@@ -15,29 +15,41 @@
 package rpc
 
 import (
+	"bytes"
 	"encoding/json"
 
+	"github.com/Sirupsen/logrus"
+
 	"google.golang.org/grpc/metadata"
 
 	"github.com/asteris-llc/converge/apply"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/graph/node"
 	"github.com/asteris-llc/converge/healthcheck"
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/load"
+	"github.com/asteris-llc/converge/lock"
 	"github.com/asteris-llc/converge/plan"
 	"github.com/asteris-llc/converge/prettyprinters/human"
 	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/fgrid/uuid"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
-type executor struct{}
+type executor struct {
+	history      *history.Store
+	lockFile     string
+	lockRequired bool
+	root         string
+}
 
 type statusResponseStream interface {
 	Send(*pb.StatusResponse) error
 	SendHeader(metadata.MD) error
 }
 
-func (e *executor) edgeMeta(ctx context.Context, g *graph.Graph) (metadata.MD, error) {
+func (e *executor) edgeMeta(ctx context.Context, g *graph.Graph, params map[string]string) (metadata.MD, error) {
 	logger := getLogger(ctx).WithField("function", "executor.edgeMeta")
 
 	edges, err := json.Marshal(g.Edges())
@@ -46,14 +58,30 @@ func (e *executor) edgeMeta(ctx context.Context, g *graph.Graph) (metadata.MD, e
 		return nil, errors.Wrapf(err, "serializing edges")
 	}
 
-	return metadata.New(map[string]string{"edges": string(edges)}), nil
+	checksums, err := json.Marshal(load.Checksums(g))
+	if err != nil {
+		logger.WithError(err).Error("could not serialize checksums")
+		return nil, errors.Wrapf(err, "serializing checksums")
+	}
+
+	parameters, err := json.Marshal(params)
+	if err != nil {
+		logger.WithError(err).Error("could not serialize parameters")
+		return nil, errors.Wrapf(err, "serializing parameters")
+	}
+
+	return metadata.New(map[string]string{
+		"edges":      string(edges),
+		"checksums":  string(checksums),
+		"parameters": string(parameters),
+	}), nil
 }
 
-func (e *executor) sendMeta(ctx context.Context, g *graph.Graph, stream statusResponseStream) error {
+func (e *executor) sendMeta(ctx context.Context, g *graph.Graph, in *pb.LoadRequest, stream statusResponseStream) error {
 	logger := getLogger(ctx).WithField("function", "executor.sendMeta")
 
 	// dehydrate graph edges and send them in the header metadata
-	meta, err := e.edgeMeta(ctx, g)
+	meta, err := e.edgeMeta(ctx, g, in.Parameters)
 	if err != nil {
 		// already logged, don't log here
 		return errors.Wrap(err, "preparing metadata")
@@ -67,9 +95,71 @@ func (e *executor) sendMeta(ctx context.Context, g *graph.Graph, stream statusRe
 	return nil
 }
 
-func (e *executor) stageNotifier(stage pb.StatusResponse_Stage, stream statusResponseStream) *graph.Notifier {
+// startRun sets up logging for a run and starts recording it
+func (e *executor) startRun(ctx context.Context, command string, in *pb.LoadRequest) (*history.Run, *logrus.Entry, context.Context) {
+	id := uuid.NewV4().String()
+	logger, ctx := setRunIDLogger(ctx, id)
+
+	return history.NewRun(id, command, in.Location, in.Parameters), logger, ctx
+}
+
+// resolveParamsFiles merges the params files in a request into its params,
+// and records the merged params on the run
+func (e *executor) resolveParamsFiles(run *history.Run, in *pb.LoadRequest) error {
+	if err := in.ResolveParamsFiles(e.root); err != nil {
+		return err
+	}
+
+	run.Parameters = in.Parameters
+	return nil
+}
+
+// finishRun records the end of a run and saves it, if history is enabled.
+// Failing to save is logged but does not fail the run.
+func (e *executor) finishRun(ctx context.Context, run *history.Run, err error) {
+	run.Finish(err)
+
+	if e.history == nil {
+		return
+	}
+
+	if err := e.history.Save(run); err != nil {
+		getLogger(ctx).WithError(err).WithField("function", "executor.finishRun").Warn("could not save run history")
+	}
+}
+
+// acquireLock takes the apply lock, if locking is enabled. The returned
+// function releases it.
+func (e *executor) acquireLock(ctx context.Context, in *pb.LoadRequest) (func(), error) {
+	if e.lockFile == "" {
+		return func() {}, nil
+	}
+
+	timeout, err := in.LockDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := lock.Acquire(ctx, e.lockFile, lock.NewHolder(in.Location), timeout)
+	if _, unavailable := err.(*lock.UnavailableError); unavailable && !e.lockRequired {
+		getLogger(ctx).WithError(err).WithField("function", "executor.acquireLock").Warn("could not open apply lock, applying without it")
+		return func() {}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not acquire apply lock")
+	}
+
+	return func() {
+		if err := held.Release(); err != nil {
+			getLogger(ctx).WithError(err).WithField("function", "executor.acquireLock").Warn("could not release apply lock")
+		}
+	}, nil
+}
+
+func (e *executor) stageNotifier(run *history.Run, stage pb.StatusResponse_Stage, stream statusResponseStream) *graph.Notifier {
 	return &graph.Notifier{
 		Pre: func(meta *node.Node) error {
+			run.NodeStarted(meta.ID, stage.String())
+
 			return stream.Send(&pb.StatusResponse{
 				Id:    meta.ID, // TODO: deprecated, remove in 0.4.0
 				Stage: stage,
@@ -78,41 +168,53 @@ func (e *executor) stageNotifier(stage pb.StatusResponse_Stage, stream statusRes
 			})
 		},
 		Post: func(meta *node.Node) error {
+			printable := meta.Value().(human.Printable)
 			response := statusResponseFromPrintable(
 				meta,
-				meta.Value().(human.Printable),
+				printable,
 				stage,
 				pb.StatusResponse_FINISHED,
 			)
 
+			run.NodeFinished(meta.ID, stage.String(), statusLevel(printable), response.Details)
+
 			return stream.Send(response)
 		},
 	}
 }
 
-func (e *executor) sendPlan(ctx context.Context, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
-	out, err := plan.WithNotify(ctx, in, e.stageNotifier(pb.StatusResponse_PLAN, stream))
+func (e *executor) sendPlan(ctx context.Context, run *history.Run, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
+	out, err := plan.WithNotify(ctx, in, e.stageNotifier(run, pb.StatusResponse_PLAN, stream))
 	if err != nil && err != plan.ErrTreeContainsErrors {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (e *executor) Plan(in *pb.LoadRequest, stream pb.Executor_PlanServer) error {
-	logger, ctx := setIDLogger(stream.Context())
+func (e *executor) Plan(in *pb.LoadRequest, stream pb.Executor_PlanServer) (err error) {
+	run, logger, ctx := e.startRun(stream.Context(), "plan", in)
+	defer func() { e.finishRun(ctx, run, err) }()
 	logger = logger.WithField("function", "executor.Plan")
 
+	if err = e.resolveParamsFiles(run, in); err != nil {
+		return err
+	}
+
 	loaded, err := in.Load(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = e.sendMeta(ctx, loaded, stream); err != nil {
+	if err = e.sendMeta(ctx, loaded, in, stream); err != nil {
 		return err
 	}
+	run.Edges = loaded.Edges()
+
+	ctx = in.WithParallelism(ctx)
+	ctx = in.WithFailFast(ctx)
 
 	// send the plan
-	_, err = e.sendPlan(ctx, stream, loaded)
+	_, err = e.sendPlan(ctx, run, stream, loaded)
 	if err != nil {
 		logger.WithError(err).WithField("location", in.Location).Error("planning failed")
 		return errors.Wrapf(err, "planning %s", in.Location)
@@ -121,35 +223,44 @@ func (e *executor) Plan(in *pb.LoadRequest, stream pb.Executor_PlanServer) error
 	return nil
 }
 
-func (e *executor) sendHealthCheck(ctx context.Context, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
-	out, err := healthcheck.WithNotify(ctx, in, e.stageNotifier(pb.StatusResponse_PLAN, stream))
+func (e *executor) sendHealthCheck(ctx context.Context, run *history.Run, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
+	out, err := healthcheck.WithNotify(ctx, in, e.stageNotifier(run, pb.StatusResponse_PLAN, stream))
 	if err != nil && err != plan.ErrTreeContainsErrors {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (e *executor) HealthCheck(in *pb.LoadRequest, stream pb.Executor_HealthCheckServer) error {
-	logger, ctx := setIDLogger(stream.Context())
+func (e *executor) HealthCheck(in *pb.LoadRequest, stream pb.Executor_HealthCheckServer) (err error) {
+	run, logger, ctx := e.startRun(stream.Context(), "healthcheck", in)
+	defer func() { e.finishRun(ctx, run, err) }()
 	logger = logger.WithField("function", "executor.Plan")
 
+	if err = e.resolveParamsFiles(run, in); err != nil {
+		return err
+	}
+
 	loaded, err := in.Load(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = e.sendMeta(ctx, loaded, stream); err != nil {
+	if err = e.sendMeta(ctx, loaded, in, stream); err != nil {
 		return err
 	}
+	run.Edges = loaded.Edges()
+
+	ctx = in.WithParallelism(ctx)
+	ctx = in.WithFailFast(ctx)
 
 	// send the plan
-	planned, err := e.sendPlan(ctx, stream, loaded)
+	planned, err := e.sendPlan(ctx, run, stream, loaded)
 	if err != nil {
 		logger.WithError(err).WithField("location", in.Location).Error("planning failed")
 		return errors.Wrapf(err, "planning %s", in.Location)
 	}
 
-	_, err = e.sendHealthCheck(ctx, stream, planned)
+	_, err = e.sendHealthCheck(ctx, run, stream, planned)
 	if err != nil {
 		logger.WithError(err).WithField("location", in.Location).Error("health check failed")
 		return errors.Wrapf(err, "health check %s", in.Location)
@@ -158,28 +269,90 @@ func (e *executor) HealthCheck(in *pb.LoadRequest, stream pb.Executor_HealthChec
 	return nil
 }
 
-func (e *executor) sendApply(ctx context.Context, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
-	out, err := apply.WithNotify(ctx, in, e.stageNotifier(pb.StatusResponse_APPLY, stream))
+func (e *executor) sendApply(ctx context.Context, run *history.Run, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
+	out, err := apply.WithNotify(ctx, in, e.stageNotifier(run, pb.StatusResponse_APPLY, stream))
+	if err != nil && err != apply.ErrTreeContainsErrors {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (e *executor) sendApplyPlanned(ctx context.Context, run *history.Run, stream statusResponseStream, in *graph.Graph) (*graph.Graph, error) {
+	out, err := apply.ApplyWithNotify(ctx, in, e.stageNotifier(run, pb.StatusResponse_APPLY, stream))
 	if err != nil && err != apply.ErrTreeContainsErrors {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (e *executor) Apply(in *pb.LoadRequest, stream pb.Executor_ApplyServer) error {
-	logger, ctx := setIDLogger(stream.Context())
+func (e *executor) Apply(in *pb.LoadRequest, stream pb.Executor_ApplyServer) (err error) {
+	run, logger, ctx := e.startRun(stream.Context(), "apply", in)
+	defer func() { e.finishRun(ctx, run, err) }()
 	logger = logger.WithField("function", "executor.Apply")
 
+	if err = e.resolveParamsFiles(run, in); err != nil {
+		return err
+	}
+
+	release, err := e.acquireLock(ctx, in)
+	if err != nil {
+		logger.WithError(err).WithField("location", in.Location).Error("not applying")
+		return err
+	}
+	defer release()
+
+	var saved *plan.Saved
+	if len(in.Plan) > 0 {
+		saved, err = plan.ReadSaved(bytes.NewReader(in.Plan))
+		if err != nil {
+			return errors.Wrap(err, "reading saved plan")
+		}
+	}
+
 	loaded, err := in.Load(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = e.sendMeta(ctx, loaded, stream); err != nil {
+	if saved != nil {
+		if err = saved.VerifyChecksums(load.Checksums(loaded)); err != nil {
+			logger.WithError(err).WithField("location", in.Location).Error("modules do not match saved plan")
+			return errors.Wrapf(err, "refusing to apply saved plan for %s", in.Location)
+		}
+	}
+
+	if err = e.sendMeta(ctx, loaded, in, stream); err != nil {
 		return err
 	}
+	run.Edges = loaded.Edges()
+
+	ctx = in.WithParallelism(ctx)
+	ctx = in.WithFailFast(ctx)
+	if in.RollbackOnFailure {
+		ctx = apply.WithRollbackOnFailure(ctx)
+	}
+
+	if saved == nil {
+		_, err = e.sendApply(ctx, run, stream, loaded)
+		if err != nil {
+			return errors.Wrapf(err, "applying %s", in.Location)
+		}
+
+		return nil
+	}
+
+	planned, err := e.sendPlan(ctx, run, stream, loaded)
+	if err != nil {
+		logger.WithError(err).WithField("location", in.Location).Error("planning failed")
+		return errors.Wrapf(err, "planning %s", in.Location)
+	}
+
+	if err = saved.Verify(planned); err != nil {
+		logger.WithError(err).WithField("location", in.Location).Error("plan does not match saved plan")
+		return errors.Wrapf(err, "refusing to apply saved plan for %s", in.Location)
+	}
 
-	_, err = e.sendApply(ctx, stream, loaded)
+	_, err = e.sendApplyPlanned(ctx, run, stream, planned)
 	if err != nil {
 		return errors.Wrapf(err, "applying %s", in.Location)
 	}
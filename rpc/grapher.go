@@ -22,16 +22,24 @@ import (
 	"github.com/asteris-llc/converge/render"
 	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/asteris-llc/converge/secret"
 	"github.com/pkg/errors"
 )
 
-type grapher struct{}
+type grapher struct {
+	root string
+}
 
 // Graph returns the information about a graph
 func (g *grapher) Graph(in *pb.LoadRequest, stream pb.Grapher_GraphServer) error {
 	logger, ctx := setIDLogger(stream.Context())
 	logger = logger.WithField("function", "grapher.Graph")
 
+	if err := in.ResolveParamsFiles(g.root); err != nil {
+		logger.WithError(err).Error("loading failed")
+		return errors.Wrap(err, "loading failed")
+	}
+
 	loaded, err := in.Load(ctx)
 	if err != nil {
 		logger.WithError(err).Error("loading failed")
@@ -58,6 +66,7 @@ func (g *grapher) Graph(in *pb.LoadRequest, stream pb.Grapher_GraphServer) error
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("could not marshal vertex for type: %T ", node))
 		}
+		vbytes = []byte(secret.Redact(string(vbytes)))
 
 		err = stream.Send(
 			pb.NewGraphComponent(&pb.GraphComponent_Vertex{
@@ -17,15 +17,20 @@ package rpc
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/asteris-llc/converge/helpers/logging"
 	"github.com/asteris-llc/converge/rpc/pb"
 	"github.com/asteris-llc/converge/rpc/pb/mocks"
+	"github.com/asteris-llc/converge/secret"
 	"github.com/fgrid/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 )
 
@@ -66,6 +71,28 @@ func TestGrapherGraph(t *testing.T) {
 		)
 	})
 
+	t.Run("sensitive values", func(t *testing.T) {
+		secret.Register("grapher-test-secret")
+
+		dir, err := ioutil.TempDir("", "converge-grapher")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		module := filepath.Join(dir, "secret.hcl")
+		require.NoError(t, ioutil.WriteFile(module, []byte(`param "password" { default = "grapher-test-secret" }`), 0600))
+
+		stream := new(mocks.GrapherGraphServer)
+		stream.On("Context").Return(ctx)
+		stream.On("Send", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			component := args.Get(0).(*pb.GraphComponent)
+			if vertex := component.GetVertex(); vertex != nil {
+				assert.NotContains(t, string(vertex.Details), "grapher-test-secret")
+			}
+		})
+
+		assert.NoError(t, g.Graph(&pb.LoadRequest{Location: module}, stream))
+	})
+
 	t.Run("stream error", func(t *testing.T) {
 		stream := new(mocks.GrapherGraphServer)
 		stream.On("Context").Return(ctx)
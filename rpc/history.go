@@ -0,0 +1,70 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+type historyServer struct {
+	store *history.Store
+}
+
+// Runs returns recorded runs
+func (h *historyServer) Runs(ctx context.Context, in *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	logger := getLogger(ctx).WithField("function", "historyServer.Runs")
+
+	if h.store == nil {
+		logger.Debug("got request for history, but history not enabled")
+		return nil, errors.New("history not enabled")
+	}
+
+	var runs []*history.Run
+	if in.Id != "" {
+		run, err := h.store.Get(in.Id)
+		if err != nil {
+			logger.WithError(err).WithField("id", in.Id).Error("could not get run")
+			return nil, errors.Wrapf(err, "could not get %s", in.Id)
+		}
+		runs = append(runs, run)
+	} else {
+		var err error
+		runs, err = h.store.List(int(in.Limit))
+		if err != nil {
+			logger.WithError(err).Error("could not list runs")
+			return nil, errors.Wrap(err, "could not list runs")
+		}
+	}
+
+	resp := new(pb.HistoryResponse)
+	for _, run := range runs {
+		details, err := json.Marshal(run)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not serialize %s", run.ID)
+		}
+
+		resp.Runs = append(resp.Runs, &pb.HistoryResponse_Run{
+			Id:      run.ID,
+			Details: details,
+		})
+	}
+
+	return resp, nil
+}
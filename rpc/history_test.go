@@ -0,0 +1,80 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// TestHistoryServer tests the operation of historyServer
+func TestHistoryServer(t *testing.T) {
+	defer logging.HideLogs(t)()
+
+	t.Run("interfaces", func(t *testing.T) {
+		assert.Implements(t, (*pb.HistoryServer)(nil), new(historyServer))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := new(historyServer).Runs(context.Background(), new(pb.HistoryRequest))
+		assert.EqualError(t, err, "history not enabled")
+	})
+
+	dir, err := ioutil.TempDir("", "converge-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := history.NewStore(dir)
+	for _, id := range []string{"a", "b"} {
+		run := history.NewRun(id, "plan", "test.hcl", nil)
+		run.Finish(nil)
+		require.NoError(t, store.Save(run))
+	}
+
+	server := &historyServer{store: store}
+
+	t.Run("list", func(t *testing.T) {
+		resp, err := server.Runs(context.Background(), &pb.HistoryRequest{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Runs, 1)
+		assert.Equal(t, "b", resp.Runs[0].Id)
+
+		var run history.Run
+		require.NoError(t, json.Unmarshal(resp.Runs[0].Details, &run))
+		assert.Equal(t, "b", run.ID)
+		assert.Equal(t, history.StatusSucceeded, run.Status)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		resp, err := server.Runs(context.Background(), &pb.HistoryRequest{Id: "a"})
+		require.NoError(t, err)
+		require.Len(t, resp.Runs, 1)
+		assert.Equal(t, "a", resp.Runs[0].Id)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := server.Runs(context.Background(), &pb.HistoryRequest{Id: "c"})
+		assert.EqualError(t, err, "could not get c: run not found")
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/asteris-llc/converge/history"
+	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// NewHistoryClient returns a client for a server that implements History
+func NewHistoryClient(ctx context.Context, addr string, security *Security) (*HistoryClient, error) {
+	opts, err := security.Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get client options")
+	}
+
+	cc, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoryClient{pb.NewHistoryClient(cc)}, nil
+}
+
+// HistoryClient is a wrapper around a pb.HistoryClient
+type HistoryClient struct {
+	client pb.HistoryClient
+}
+
+// List gets up to limit runs, newest first. A limit of zero or less gets all
+// runs.
+func (h *HistoryClient) List(ctx context.Context, limit int) ([]*history.Run, error) {
+	return h.runs(ctx, &pb.HistoryRequest{Limit: int32(limit)})
+}
+
+// Get a single run by ID or unique prefix of an ID
+func (h *HistoryClient) Get(ctx context.Context, id string) (*history.Run, error) {
+	runs, err := h.runs(ctx, &pb.HistoryRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs) != 1 {
+		return nil, history.ErrNotFound
+	}
+
+	return runs[0], nil
+}
+
+func (h *HistoryClient) runs(ctx context.Context, req *pb.HistoryRequest) ([]*history.Run, error) {
+	resp, err := h.client.Runs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*history.Run
+	for _, raw := range resp.Runs {
+		run := new(history.Run)
+		if err := json.Unmarshal(raw.Details, run); err != nil {
+			return nil, errors.Wrapf(err, "could not deserialize %s", raw.Id)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
@@ -26,7 +26,11 @@ func getLogger(ctx context.Context) *logrus.Entry {
 }
 
 func setIDLogger(ctx context.Context) (*logrus.Entry, context.Context) {
-	logger := getLogger(ctx).WithField("runID", uuid.NewV4().String())
+	return setRunIDLogger(ctx, uuid.NewV4().String())
+}
+
+func setRunIDLogger(ctx context.Context, id string) (*logrus.Entry, context.Context) {
+	logger := getLogger(ctx).WithField("runID", id)
 
 	return logger, logging.WithLogger(ctx, logger)
 }
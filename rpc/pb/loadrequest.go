@@ -15,6 +15,12 @@
 package pb
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asteris-llc/converge/executor"
 	"github.com/asteris-llc/converge/graph"
 	"github.com/asteris-llc/converge/helpers/logging"
 	"github.com/asteris-llc/converge/load"
@@ -27,12 +33,18 @@ import (
 func (lr *LoadRequest) Load(ctx context.Context) (*graph.Graph, error) {
 	logger := logging.GetLogger(ctx).WithField("location", lr.Location)
 
-	loaded, err := load.Load(ctx, lr.Location, lr.Verify)
+	loaded, err := load.Load(load.WithParams(ctx, lr.Parameters), lr.Location, lr.Verify)
 	if err != nil {
 		logger.WithError(err).Error("could not load")
 		return nil, errors.Wrapf(err, "loading %s", lr.Location)
 	}
 
+	loaded, err = lr.prune(ctx, loaded)
+	if err != nil {
+		logger.WithError(err).Error("could not prune")
+		return nil, errors.Wrapf(err, "pruning %s", lr.Location)
+	}
+
 	values := render.Values{}
 	for k, v := range lr.Parameters {
 		values[k] = v
@@ -51,3 +63,91 @@ func (lr *LoadRequest) Load(ctx context.Context) (*graph.Graph, error) {
 
 	return merged, nil
 }
+
+// ResolveParamsFiles reads the params files in the request and merges them
+// into its params. Files are resolved relative to root, if it is set, and may
+// not leave it. Params given directly in the request take precedence over
+// params from files.
+func (lr *LoadRequest) ResolveParamsFiles(root string) error {
+	if len(lr.ParamsFile) == 0 {
+		return nil
+	}
+
+	var filenames []string
+	for _, name := range lr.ParamsFile {
+		filename := filepath.Join(root, name)
+		if root != "" && !insideRoot(root, filename) {
+			return fmt.Errorf("params file %s: must be inside the server root", name)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	params, err := load.ReadParamsFiles(filenames)
+	if err != nil {
+		return errors.Wrap(err, "reading params files")
+	}
+
+	for k, v := range lr.Parameters {
+		params[k] = v
+	}
+	lr.Parameters = params
+
+	return nil
+}
+
+// insideRoot returns whether a path is root or somewhere below it
+func insideRoot(root, name string) bool {
+	rel, err := filepath.Rel(root, name)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// prune the loaded graph to the targets and excludes in the request. This
+// happens before rendering so that nodes outside the targets are never
+// evaluated.
+func (lr *LoadRequest) prune(ctx context.Context, g *graph.Graph) (*graph.Graph, error) {
+	targeted, err := graph.Target(ctx, g, lr.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	return graph.Exclude(ctx, targeted, lr.Excludes)
+}
+
+// WithParallelism sets the parallelism limits from a LoadRequest on a context
+func (lr *LoadRequest) WithParallelism(ctx context.Context) context.Context {
+	p := graph.Parallelism{
+		Max:     int(lr.Parallelism),
+		PerKind: map[string]int{},
+	}
+	for kind, limit := range lr.KindParallelism {
+		p.PerKind[kind] = int(limit)
+	}
+
+	return graph.WithParallelism(ctx, p)
+}
+
+// WithFailFast enables fail-fast on a context if the LoadRequest asks for it
+func (lr *LoadRequest) WithFailFast(ctx context.Context) context.Context {
+	if !lr.FailFast {
+		return ctx
+	}
+	return executor.WithFailFast(ctx)
+}
+
+// LockDuration parses how long to wait for the apply lock. An empty timeout
+// means not waiting at all.
+func (lr *LoadRequest) LockDuration() (time.Duration, error) {
+	if lr.LockTimeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(lr.LockTimeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid lock timeout")
+	}
+	return timeout, nil
+}
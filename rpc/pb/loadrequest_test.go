@@ -0,0 +1,82 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRequestResolveParamsFiles(t *testing.T) {
+	t.Parallel()
+
+	root, err := ioutil.TempDir("", "converge-loadrequest")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "prod.yaml"), []byte("name: web\ncount: 3\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "prod..yaml"), []byte("name: api\n"), 0600))
+
+	t.Run("merges", func(t *testing.T) {
+		lr := &LoadRequest{
+			Parameters: map[string]string{"count": "5"},
+			ParamsFile: []string{"prod.yaml"},
+		}
+
+		require.NoError(t, lr.ResolveParamsFiles(root))
+		assert.Equal(t, map[string]string{"name": "web", "count": "5"}, lr.Parameters)
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		lr := &LoadRequest{}
+
+		assert.NoError(t, lr.ResolveParamsFiles(root))
+		assert.Nil(t, lr.Parameters)
+	})
+
+	t.Run("dots in name", func(t *testing.T) {
+		lr := &LoadRequest{ParamsFile: []string{"prod..yaml"}}
+
+		require.NoError(t, lr.ResolveParamsFiles(root))
+		assert.Equal(t, map[string]string{"name": "api"}, lr.Parameters)
+	})
+
+	t.Run("current directory as root", func(t *testing.T) {
+		name := "loadrequest-test-params.yaml"
+		require.NoError(t, ioutil.WriteFile(name, []byte("name: local\n"), 0600))
+		defer os.Remove(name)
+
+		lr := &LoadRequest{ParamsFile: []string{name}}
+
+		require.NoError(t, lr.ResolveParamsFiles("."))
+		assert.Equal(t, map[string]string{"name": "local"}, lr.Parameters)
+
+		lr = &LoadRequest{ParamsFile: []string{"../prod.yaml"}}
+		assert.EqualError(t, lr.ResolveParamsFiles("."), "params file ../prod.yaml: must be inside the server root")
+	})
+
+	t.Run("outside root", func(t *testing.T) {
+		for _, name := range []string{"../prod.yaml", "sub/../../prod.yaml", "../" + filepath.Base(root) + "-other/prod.yaml"} {
+			lr := &LoadRequest{ParamsFile: []string{name}}
+
+			assert.EqualError(t, lr.ResolveParamsFiles(root), "params file "+name+": must be inside the server root")
+		}
+	})
+}
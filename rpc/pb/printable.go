@@ -27,10 +27,15 @@ import (
 // ToPrintable returns a view that can be used in a human printer
 func (sr *StatusResponse_Details) ToPrintable() human.Printable {
 	psr := &printableStatusResponse{
-		changes:    map[string]resource.Diff{},
-		messages:   sr.Messages,
-		hasChanges: sr.HasChanges,
-		error:      nil,
+		changes:     map[string]resource.Diff{},
+		messages:    sr.Messages,
+		hasChanges:  sr.HasChanges,
+		error:       nil,
+		handler:     sr.Handler,
+		triggeredBy: sr.TriggeredBy,
+
+		ignoreErrors: sr.IgnoreErrors,
+		skipped:      sr.Skipped,
 	}
 
 	// set up changes
@@ -57,6 +62,12 @@ type printableStatusResponse struct {
 	hasChanges bool
 	error      error
 	warning    string
+
+	handler     bool
+	triggeredBy []string
+
+	ignoreErrors bool
+	skipped      bool
 }
 
 func (psr *printableStatusResponse) Changes() map[string]resource.Diff { return psr.changes }
@@ -65,6 +76,16 @@ func (psr *printableStatusResponse) HasChanges() bool                  { return
 func (psr *printableStatusResponse) Error() error                      { return psr.error }
 func (psr *printableStatusResponse) Warning() string                   { return psr.warning }
 
+// IsHandler and TriggeredBy implement human.Handler
+func (psr *printableStatusResponse) IsHandler() bool       { return psr.handler }
+func (psr *printableStatusResponse) TriggeredBy() []string { return psr.triggeredBy }
+
+// IgnoresErrors implements human.ErrorIgnorer
+func (psr *printableStatusResponse) IgnoresErrors() bool { return psr.ignoreErrors }
+
+// Skipped implements human.Skipper
+func (psr *printableStatusResponse) Skipped() bool { return psr.skipped }
+
 // ToPrintable returns a view that can be used in a human printer
 func (d *DiffResponse) ToPrintable() resource.Diff {
 	return &printableDiff{
@@ -6,14 +6,18 @@
 Package pb is a generated protocol buffer package.
 
 It is generated from these files:
+
 	root.proto
 
 It has these top-level messages:
+
 	LoadRequest
 	ContentResponse
 	StatusResponse
 	DiffResponse
 	GraphComponent
+	HistoryRequest
+	HistoryResponse
 */
 package pb
 
@@ -90,9 +94,18 @@ func (x StatusResponse_Run) String() string {
 func (StatusResponse_Run) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 1} }
 
 type LoadRequest struct {
-	Location   string            `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
-	Parameters map[string]string `protobuf:"bytes,2,rep,name=parameters" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Verify     bool              `protobuf:"varint,3,opt,name=verify" json:"verify,omitempty"`
+	Location          string            `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+	Parameters        map[string]string `protobuf:"bytes,2,rep,name=parameters" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Verify            bool              `protobuf:"varint,3,opt,name=verify" json:"verify,omitempty"`
+	Parallelism       int32             `protobuf:"varint,4,opt,name=parallelism" json:"parallelism,omitempty"`
+	KindParallelism   map[string]int32  `protobuf:"bytes,5,rep,name=kindParallelism" json:"kindParallelism,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Plan              []byte            `protobuf:"bytes,6,opt,name=plan,proto3" json:"plan,omitempty"`
+	Targets           []string          `protobuf:"bytes,7,rep,name=targets" json:"targets,omitempty"`
+	Excludes          []string          `protobuf:"bytes,8,rep,name=excludes" json:"excludes,omitempty"`
+	FailFast          bool              `protobuf:"varint,9,opt,name=failFast" json:"failFast,omitempty"`
+	RollbackOnFailure bool              `protobuf:"varint,10,opt,name=rollbackOnFailure" json:"rollbackOnFailure,omitempty"`
+	LockTimeout       string            `protobuf:"bytes,11,opt,name=lockTimeout" json:"lockTimeout,omitempty"`
+	ParamsFile        []string          `protobuf:"bytes,12,rep,name=params_file,json=paramsFile" json:"params_file,omitempty"`
 }
 
 func (m *LoadRequest) Reset()                    { *m = LoadRequest{} }
@@ -121,6 +134,69 @@ func (m *LoadRequest) GetVerify() bool {
 	return false
 }
 
+func (m *LoadRequest) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetKindParallelism() map[string]int32 {
+	if m != nil {
+		return m.KindParallelism
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetPlan() []byte {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetExcludes() []string {
+	if m != nil {
+		return m.Excludes
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetFailFast() bool {
+	if m != nil {
+		return m.FailFast
+	}
+	return false
+}
+
+func (m *LoadRequest) GetRollbackOnFailure() bool {
+	if m != nil {
+		return m.RollbackOnFailure
+	}
+	return false
+}
+
+func (m *LoadRequest) GetLockTimeout() string {
+	if m != nil {
+		return m.LockTimeout
+	}
+	return ""
+}
+
+func (m *LoadRequest) GetParamsFile() []string {
+	if m != nil {
+		return m.ParamsFile
+	}
+	return nil
+}
+
 type ContentResponse struct {
 	Content string `protobuf:"bytes,1,opt,name=content" json:"content,omitempty"`
 }
@@ -189,11 +265,16 @@ func (m *StatusResponse) GetMeta() *StatusResponse_Meta {
 
 // the informational message, if present
 type StatusResponse_Details struct {
-	Messages   []string                 `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
-	Changes    map[string]*DiffResponse `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	HasChanges bool                     `protobuf:"varint,3,opt,name=hasChanges" json:"hasChanges,omitempty"`
-	Error      string                   `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
-	Warning    string                   `protobuf:"bytes,5,opt,name=warning" json:"warning,omitempty"`
+	Messages     []string                 `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	Changes      map[string]*DiffResponse `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	HasChanges   bool                     `protobuf:"varint,3,opt,name=hasChanges" json:"hasChanges,omitempty"`
+	Error        string                   `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	Warning      string                   `protobuf:"bytes,5,opt,name=warning" json:"warning,omitempty"`
+	Fields       map[string]string        `protobuf:"bytes,6,rep,name=fields" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Handler      bool                     `protobuf:"varint,7,opt,name=handler" json:"handler,omitempty"`
+	TriggeredBy  []string                 `protobuf:"bytes,8,rep,name=triggeredBy" json:"triggeredBy,omitempty"`
+	IgnoreErrors bool                     `protobuf:"varint,9,opt,name=ignoreErrors" json:"ignoreErrors,omitempty"`
+	Skipped      bool                     `protobuf:"varint,10,opt,name=skipped" json:"skipped,omitempty"`
 }
 
 func (m *StatusResponse_Details) Reset()                    { *m = StatusResponse_Details{} }
@@ -229,6 +310,48 @@ func (m *StatusResponse_Details) GetError() string {
 	return ""
 }
 
+func (m *StatusResponse_Details) GetWarning() string {
+	if m != nil {
+		return m.Warning
+	}
+	return ""
+}
+
+func (m *StatusResponse_Details) GetFields() map[string]string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *StatusResponse_Details) GetHandler() bool {
+	if m != nil {
+		return m.Handler
+	}
+	return false
+}
+
+func (m *StatusResponse_Details) GetTriggeredBy() []string {
+	if m != nil {
+		return m.TriggeredBy
+	}
+	return nil
+}
+
+func (m *StatusResponse_Details) GetIgnoreErrors() bool {
+	if m != nil {
+		return m.IgnoreErrors
+	}
+	return false
+}
+
+func (m *StatusResponse_Details) GetSkipped() bool {
+	if m != nil {
+		return m.Skipped
+	}
+	return false
+}
+
 type StatusResponse_Meta struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
@@ -465,6 +588,70 @@ func (m *GraphComponent_Edge) GetAttributes() []string {
 	return nil
 }
 
+type HistoryRequest struct {
+	Id    string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *HistoryRequest) Reset()                    { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()               {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *HistoryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	Runs []*HistoryResponse_Run `protobuf:"bytes,1,rep,name=runs" json:"runs,omitempty"`
+}
+
+func (m *HistoryResponse) Reset()                    { *m = HistoryResponse{} }
+func (m *HistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*HistoryResponse) ProtoMessage()               {}
+func (*HistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *HistoryResponse) GetRuns() []*HistoryResponse_Run {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type HistoryResponse_Run struct {
+	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Details []byte `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+}
+
+func (m *HistoryResponse_Run) Reset()                    { *m = HistoryResponse_Run{} }
+func (m *HistoryResponse_Run) String() string            { return proto.CompactTextString(m) }
+func (*HistoryResponse_Run) ProtoMessage()               {}
+func (*HistoryResponse_Run) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6, 0} }
+
+func (m *HistoryResponse_Run) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *HistoryResponse_Run) GetDetails() []byte {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*LoadRequest)(nil), "pb.LoadRequest")
 	proto.RegisterType((*ContentResponse)(nil), "pb.ContentResponse")
@@ -475,6 +662,9 @@ func init() {
 	proto.RegisterType((*GraphComponent)(nil), "pb.GraphComponent")
 	proto.RegisterType((*GraphComponent_Vertex)(nil), "pb.GraphComponent.Vertex")
 	proto.RegisterType((*GraphComponent_Edge)(nil), "pb.GraphComponent.Edge")
+	proto.RegisterType((*HistoryRequest)(nil), "pb.HistoryRequest")
+	proto.RegisterType((*HistoryResponse)(nil), "pb.HistoryResponse")
+	proto.RegisterType((*HistoryResponse_Run)(nil), "pb.HistoryResponse.Run")
 	proto.RegisterEnum("pb.StatusResponse_Stage", StatusResponse_Stage_name, StatusResponse_Stage_value)
 	proto.RegisterEnum("pb.StatusResponse_Run", StatusResponse_Run_name, StatusResponse_Run_value)
 }
@@ -960,68 +1150,152 @@ var _Info_serviceDesc = grpc.ServiceDesc{
 	Metadata: fileDescriptor0,
 }
 
+// Client API for History service
+
+type HistoryClient interface {
+	Runs(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+}
+
+type historyClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHistoryClient(cc *grpc.ClientConn) HistoryClient {
+	return &historyClient{cc}
+}
+
+func (c *historyClient) Runs(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := grpc.Invoke(ctx, "/pb.History/Runs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for History service
+
+type HistoryServer interface {
+	Runs(context.Context, *HistoryRequest) (*HistoryResponse, error)
+}
+
+func RegisterHistoryServer(s *grpc.Server, srv HistoryServer) {
+	s.RegisterService(&_History_serviceDesc, srv)
+}
+
+func _History_Runs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServer).Runs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.History/Runs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServer).Runs(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _History_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.History",
+	HandlerType: (*HistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Runs",
+			Handler:    _History_Runs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: fileDescriptor0,
+}
+
 func init() { proto.RegisterFile("root.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 957 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0xaf, 0x1d, 0xa7, 0x69, 0x5e, 0xa2, 0x34, 0xcc, 0x76, 0xbb, 0x5e, 0x2f, 0x62, 0x23, 0x1f,
-	0x76, 0x4b, 0x57, 0x38, 0x90, 0x82, 0x84, 0x56, 0x5a, 0xa1, 0xb4, 0x4d, 0x9b, 0x48, 0xd9, 0x28,
-	0x9a, 0x74, 0x91, 0xf8, 0x23, 0xd0, 0x24, 0x99, 0x38, 0x56, 0x9d, 0x19, 0x33, 0x1e, 0x97, 0x8d,
-	0x10, 0x17, 0x0e, 0x1c, 0xb8, 0x72, 0xe6, 0xc3, 0xf0, 0x05, 0xb8, 0xf0, 0x15, 0x38, 0xf3, 0x19,
-	0xd0, 0x8c, 0xed, 0x92, 0xa6, 0x89, 0xb4, 0x37, 0xff, 0x66, 0x7e, 0xef, 0xf7, 0xde, 0xfc, 0xde,
-	0xf3, 0x0c, 0x80, 0xe0, 0x5c, 0x7a, 0x91, 0xe0, 0x92, 0x23, 0x33, 0x1a, 0x3b, 0xef, 0xfb, 0x9c,
-	0xfb, 0x21, 0x6d, 0x92, 0x28, 0x68, 0x12, 0xc6, 0xb8, 0x24, 0x32, 0xe0, 0x2c, 0x4e, 0x19, 0xce,
-	0x93, 0x6c, 0x57, 0xa3, 0x71, 0x32, 0x6b, 0xd2, 0x45, 0x24, 0x97, 0xe9, 0xa6, 0xfb, 0xa7, 0x01,
-	0x95, 0x3e, 0x27, 0x53, 0x4c, 0x7f, 0x48, 0x68, 0x2c, 0x91, 0x03, 0x7b, 0x21, 0x9f, 0xe8, 0x78,
-	0xdb, 0x68, 0x18, 0x47, 0x65, 0x7c, 0x8b, 0xd1, 0x17, 0x00, 0x11, 0x11, 0x64, 0x41, 0x25, 0x15,
-	0xb1, 0x6d, 0x36, 0x0a, 0x47, 0x95, 0xd6, 0x53, 0x2f, 0x1a, 0x7b, 0x2b, 0x02, 0xde, 0xf0, 0x96,
-	0xd1, 0x61, 0x52, 0x2c, 0xf1, 0x4a, 0x08, 0x3a, 0x84, 0xdd, 0x1b, 0x2a, 0x82, 0xd9, 0xd2, 0x2e,
-	0x34, 0x8c, 0xa3, 0x3d, 0x9c, 0x21, 0xe7, 0x15, 0xec, 0xaf, 0x85, 0xa1, 0x3a, 0x14, 0xae, 0xe9,
-	0x32, 0x2b, 0x41, 0x7d, 0xa2, 0x03, 0x28, 0xde, 0x90, 0x30, 0xa1, 0xb6, 0xa9, 0xd7, 0x52, 0xf0,
-	0xd2, 0xfc, 0xdc, 0x70, 0x5f, 0xc0, 0xfe, 0x19, 0x67, 0x92, 0x32, 0x89, 0x69, 0x1c, 0x71, 0x16,
-	0x53, 0x64, 0x43, 0x69, 0x92, 0x2e, 0x65, 0x12, 0x39, 0x74, 0xff, 0xb5, 0xa0, 0x36, 0x92, 0x44,
-	0x26, 0xf1, 0x2d, 0x19, 0x81, 0x19, 0x4c, 0x53, 0xde, 0xa9, 0x69, 0x1b, 0xd8, 0x0c, 0xa6, 0xc8,
-	0x83, 0x62, 0x2c, 0x89, 0x9f, 0x66, 0xab, 0xb5, 0x6c, 0x75, 0xcc, 0xbb, 0x61, 0x0a, 0xfa, 0x14,
-	0xa7, 0x34, 0x74, 0x04, 0x05, 0x91, 0x30, 0x7d, 0xae, 0x5a, 0xeb, 0x70, 0x03, 0x1b, 0x27, 0x0c,
-	0x2b, 0x0a, 0xfa, 0x14, 0x4a, 0x53, 0x2a, 0x49, 0x10, 0xc6, 0xb6, 0xd5, 0x30, 0x8e, 0x2a, 0x2d,
-	0x67, 0x03, 0xfb, 0x3c, 0x65, 0xe0, 0x9c, 0x8a, 0x5e, 0x80, 0xb5, 0xa0, 0x92, 0xd8, 0x45, 0x1d,
-	0xf2, 0x68, 0x43, 0xc8, 0x6b, 0x2a, 0x09, 0xd6, 0x24, 0xe7, 0x57, 0x13, 0x4a, 0x99, 0x82, 0x6a,
-	0xe8, 0x82, 0xc6, 0x31, 0xf1, 0x69, 0x6c, 0x1b, 0x8d, 0x82, 0x6a, 0x68, 0x8e, 0x51, 0x1b, 0x4a,
-	0x93, 0x39, 0x61, 0x6a, 0x2b, 0xed, 0xe6, 0xf3, 0xed, 0xa5, 0x78, 0x67, 0x29, 0x33, 0xed, 0x6a,
-	0x1e, 0x87, 0x3e, 0x00, 0x98, 0x93, 0x38, 0xdb, 0xcb, 0xda, 0xba, 0xb2, 0xa2, 0xba, 0x46, 0x85,
-	0xe0, 0x42, 0x9f, 0xb5, 0x8c, 0x53, 0xa0, 0xda, 0xf3, 0x23, 0x11, 0x2c, 0x60, 0xbe, 0x3e, 0x50,
-	0x19, 0xe7, 0xd0, 0xe9, 0x43, 0x75, 0x35, 0xd1, 0x86, 0x39, 0x78, 0xb6, 0x3a, 0x07, 0x95, 0x56,
-	0x5d, 0x95, 0x7c, 0x1e, 0xcc, 0x66, 0x79, 0xc1, 0x2b, 0x93, 0xe1, 0x1c, 0x82, 0xa5, 0x6c, 0x41,
-	0xb5, 0xff, 0x3b, 0xac, 0xba, 0xeb, 0x9e, 0x40, 0x51, 0x77, 0x0f, 0x3d, 0x84, 0xf7, 0xde, 0x0c,
-	0x46, 0xc3, 0xce, 0x59, 0xef, 0xa2, 0xd7, 0x39, 0xff, 0x7e, 0x74, 0xd5, 0xbe, 0xec, 0xd4, 0x77,
-	0xd0, 0x1e, 0x58, 0xc3, 0x7e, 0x7b, 0x50, 0x37, 0x50, 0x19, 0x8a, 0xed, 0xe1, 0xb0, 0xff, 0x55,
-	0xdd, 0x74, 0x3f, 0x83, 0x02, 0x4e, 0x18, 0x7a, 0x00, 0xfb, 0xab, 0x21, 0xf8, 0xcd, 0xa0, 0xbe,
-	0x83, 0x2a, 0x50, 0x1a, 0x5d, 0xb5, 0xf1, 0x55, 0xe7, 0xbc, 0x6e, 0xa0, 0x2a, 0xec, 0x5d, 0xf4,
-	0x06, 0xbd, 0x51, 0xb7, 0x73, 0x5e, 0x37, 0xdd, 0xef, 0xa0, 0xba, 0x5a, 0x9e, 0x6a, 0x08, 0x17,
-	0x81, 0x1f, 0x30, 0x12, 0xe6, 0x7f, 0x58, 0x8e, 0xf5, 0xd8, 0x26, 0x42, 0xa8, 0xb1, 0x35, 0xb3,
-	0xb1, 0x4d, 0xa1, 0xde, 0xb9, 0x63, 0x72, 0x0e, 0xdd, 0x3f, 0x4c, 0xa8, 0x5d, 0x0a, 0x12, 0xcd,
-	0xcf, 0xf8, 0x22, 0xe2, 0x4c, 0x91, 0x4f, 0xf4, 0x7f, 0x26, 0xe9, 0x5b, 0x9d, 0xa0, 0xd2, 0x7a,
-	0xac, 0x3c, 0xba, 0xcb, 0xf1, 0xbe, 0xd4, 0x84, 0xee, 0x0e, 0xce, 0xa8, 0xe8, 0x23, 0xb0, 0xe8,
-	0xd4, 0xcf, 0x6d, 0x7d, 0xb4, 0x21, 0xa4, 0x33, 0xf5, 0x69, 0x77, 0x07, 0x6b, 0x9a, 0x73, 0x01,
-	0xbb, 0xa9, 0xc4, 0xba, 0xb9, 0x08, 0x81, 0x75, 0x1d, 0xb0, 0x69, 0x76, 0x02, 0xfd, 0xad, 0xca,
-	0xcf, 0x87, 0x5e, 0x95, 0x5f, 0xbd, 0x1d, 0x6c, 0x07, 0x83, 0xa5, 0x74, 0xd5, 0xdd, 0x10, 0xf3,
-	0x44, 0x4c, 0x68, 0xa6, 0x94, 0x21, 0xa5, 0x36, 0xa5, 0x71, 0xee, 0x87, 0xfe, 0x56, 0x43, 0x47,
-	0xa4, 0x14, 0xc1, 0x38, 0x91, 0xda, 0x0f, 0x35, 0xd5, 0x2b, 0x2b, 0xa7, 0x15, 0x28, 0x4f, 0xf2,
-	0xaa, 0x5b, 0xbf, 0x99, 0xb0, 0xd7, 0x79, 0x4b, 0x27, 0x89, 0xe4, 0x02, 0x7d, 0x0b, 0x95, 0x2e,
-	0x25, 0xa1, 0x9c, 0x9f, 0xcd, 0xe9, 0xe4, 0x1a, 0xed, 0xaf, 0xdd, 0x5e, 0x0e, 0xba, 0xff, 0x03,
-	0xb8, 0xcf, 0x7e, 0xf9, 0xfb, 0x9f, 0xdf, 0xcd, 0x86, 0xfb, 0x44, 0xdf, 0xaf, 0x37, 0x9f, 0x34,
-	0x17, 0x64, 0x32, 0x0f, 0x18, 0x6d, 0xce, 0xb5, 0xd2, 0x44, 0x29, 0xbd, 0x34, 0x8e, 0x3f, 0x36,
-	0xd0, 0x00, 0xac, 0x61, 0x48, 0xd8, 0xbb, 0xc9, 0x3e, 0xd5, 0xb2, 0x8f, 0xdd, 0x83, 0x75, 0xd9,
-	0x28, 0x24, 0x2c, 0xd5, 0x1b, 0x42, 0xb1, 0x1d, 0x45, 0xe1, 0xf2, 0xdd, 0x04, 0x1b, 0x5a, 0xd0,
-	0x71, 0x1f, 0xae, 0x0b, 0x12, 0xa5, 0xa1, 0x15, 0x5b, 0x7f, 0x19, 0x50, 0xc5, 0x34, 0xb5, 0xb6,
-	0xcb, 0x63, 0x89, 0xbe, 0x86, 0xf2, 0x25, 0x95, 0xa7, 0x01, 0x23, 0x62, 0x89, 0x0e, 0xbd, 0xf4,
-	0xa9, 0xf0, 0xf2, 0xa7, 0xc2, 0xeb, 0xa8, 0xa7, 0xc2, 0x79, 0xa0, 0xb2, 0xad, 0x5d, 0xb1, 0x79,
-	0x3a, 0x64, 0xe7, 0xe9, 0x44, 0xa6, 0x1b, 0x37, 0xc7, 0xa9, 0xdc, 0x58, 0x6b, 0xbf, 0xe6, 0xd3,
-	0x24, 0xa4, 0xf7, 0x8f, 0xb0, 0x51, 0xb4, 0xa9, 0x45, 0x3f, 0x44, 0xcf, 0xef, 0x8b, 0x2e, 0xb4,
-	0x4e, 0xdc, 0xfc, 0x29, 0x7f, 0x8f, 0x5e, 0x1d, 0x1f, 0xff, 0xdc, 0xfa, 0x06, 0x4a, 0x7a, 0x4a,
-	0xa9, 0x50, 0x6e, 0xe9, 0xcf, 0x2d, 0x6e, 0xdd, 0x1d, 0xe6, 0xed, 0x6e, 0xf9, 0x8a, 0x97, 0xba,
-	0x75, 0x05, 0x56, 0x8f, 0xcd, 0x38, 0xea, 0x83, 0x35, 0x0c, 0x98, 0xbf, 0xd5, 0x9f, 0x2d, 0xeb,
-	0xee, 0x81, 0xce, 0x51, 0x43, 0xd5, 0x3c, 0x47, 0x14, 0x30, 0x7f, 0xbc, 0xab, 0x59, 0x27, 0xff,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0x5b, 0x23, 0xe4, 0xb4, 0xc6, 0x07, 0x00, 0x00,
+	// 1280 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5d, 0x8f, 0xdb, 0x44,
+	0x17, 0xde, 0x38, 0x4e, 0xb2, 0x39, 0x89, 0x92, 0x74, 0xba, 0xdd, 0xba, 0xee, 0xab, 0xb7, 0x91,
+	0x85, 0xda, 0xd0, 0x42, 0x02, 0x29, 0x20, 0xa8, 0x54, 0xd0, 0x7e, 0x24, 0xcd, 0x8a, 0xed, 0x12,
+	0x79, 0xb7, 0x48, 0x7c, 0x88, 0x6a, 0x12, 0x4f, 0x9c, 0xd1, 0x3a, 0x1e, 0x33, 0x33, 0x2e, 0x8d,
+	0x10, 0x37, 0x5c, 0x72, 0xcb, 0x35, 0x7f, 0xa9, 0x37, 0xfc, 0x04, 0xf8, 0x17, 0xdc, 0xa0, 0x19,
+	0xdb, 0x5b, 0x27, 0x9b, 0x85, 0x72, 0xe7, 0x33, 0xf3, 0x9c, 0xe7, 0x9c, 0x79, 0xe6, 0x9c, 0x33,
+	0x06, 0xe0, 0x8c, 0xc9, 0x6e, 0xc4, 0x99, 0x64, 0xc8, 0x88, 0x26, 0xf6, 0xff, 0x7c, 0xc6, 0xfc,
+	0x80, 0xf4, 0x70, 0x44, 0x7b, 0x38, 0x0c, 0x99, 0xc4, 0x92, 0xb2, 0x50, 0x24, 0x08, 0xfb, 0x76,
+	0xba, 0xab, 0xad, 0x49, 0x3c, 0xeb, 0x91, 0x45, 0x24, 0x97, 0xc9, 0xa6, 0xf3, 0xca, 0x84, 0xda,
+	0x31, 0xc3, 0x9e, 0x4b, 0xbe, 0x8f, 0x89, 0x90, 0xc8, 0x86, 0xed, 0x80, 0x4d, 0xb5, 0xbf, 0x55,
+	0x68, 0x17, 0x3a, 0x55, 0xf7, 0xc2, 0x46, 0x9f, 0x01, 0x44, 0x98, 0xe3, 0x05, 0x91, 0x84, 0x0b,
+	0xcb, 0x68, 0x17, 0x3b, 0xb5, 0xfe, 0x9d, 0x6e, 0x34, 0xe9, 0xe6, 0x08, 0xba, 0xe3, 0x0b, 0xc4,
+	0x20, 0x94, 0x7c, 0xe9, 0xe6, 0x5c, 0xd0, 0x2e, 0x94, 0x5f, 0x10, 0x4e, 0x67, 0x4b, 0xab, 0xd8,
+	0x2e, 0x74, 0xb6, 0xdd, 0xd4, 0x42, 0x6d, 0xa8, 0x29, 0x54, 0x10, 0x90, 0x80, 0x8a, 0x85, 0x65,
+	0xb6, 0x0b, 0x9d, 0x92, 0x9b, 0x5f, 0x42, 0x27, 0xd0, 0x3c, 0xa7, 0xa1, 0x37, 0xce, 0xa1, 0x4a,
+	0x3a, 0xfe, 0x5b, 0xeb, 0xf1, 0x3f, 0x5f, 0x85, 0x25, 0x49, 0xac, 0x3b, 0x23, 0x04, 0x66, 0x14,
+	0xe0, 0xd0, 0x2a, 0xb7, 0x0b, 0x9d, 0xba, 0xab, 0xbf, 0x91, 0x05, 0x15, 0x89, 0xb9, 0x4f, 0xa4,
+	0xb0, 0x2a, 0xed, 0x62, 0xa7, 0xea, 0x66, 0xa6, 0x12, 0x85, 0xbc, 0x9c, 0x06, 0xb1, 0x47, 0x84,
+	0xb5, 0xad, 0xb7, 0x2e, 0x6c, 0xb5, 0x37, 0xc3, 0x34, 0x18, 0x62, 0x21, 0xad, 0xaa, 0x3e, 0xd5,
+	0x85, 0x8d, 0xde, 0x81, 0x6b, 0x9c, 0x05, 0xc1, 0x04, 0x4f, 0xcf, 0xbf, 0x08, 0x87, 0x98, 0x06,
+	0x31, 0x27, 0x16, 0x68, 0xd0, 0xe5, 0x0d, 0xa5, 0x42, 0xc0, 0xa6, 0xe7, 0x67, 0x74, 0x41, 0x58,
+	0x2c, 0xad, 0x9a, 0x56, 0x3f, 0xbf, 0x84, 0xee, 0x24, 0x3a, 0x2d, 0xc4, 0xf3, 0x19, 0x0d, 0x88,
+	0x55, 0xd7, 0xa9, 0x24, 0x02, 0x8b, 0x21, 0x0d, 0x88, 0xfd, 0x18, 0x9a, 0x6b, 0xfa, 0xa3, 0x16,
+	0x14, 0xcf, 0xc9, 0x32, 0xbd, 0x4b, 0xf5, 0x89, 0x76, 0xa0, 0xf4, 0x02, 0x07, 0x31, 0xb1, 0x0c,
+	0xbd, 0x96, 0x18, 0x8f, 0x8c, 0x8f, 0x0b, 0xf6, 0x3e, 0xec, 0x6c, 0x92, 0xef, 0xdf, 0x38, 0x4a,
+	0x39, 0x0e, 0xe7, 0x01, 0x34, 0x0f, 0x58, 0x28, 0x49, 0x28, 0x5d, 0x22, 0x22, 0x16, 0x0a, 0xa2,
+	0x84, 0x9d, 0x26, 0x4b, 0x29, 0x45, 0x66, 0x3a, 0x7f, 0x94, 0xa1, 0x71, 0x2a, 0xb1, 0x8c, 0xc5,
+	0x05, 0x18, 0x81, 0x41, 0xbd, 0x04, 0xb7, 0x6f, 0x58, 0x05, 0xd7, 0xa0, 0x1e, 0xea, 0x42, 0x49,
+	0x48, 0xec, 0x27, 0xd1, 0x1a, 0x7d, 0x4b, 0xdd, 0xf9, 0xaa, 0x9b, 0x32, 0x7d, 0xe2, 0x26, 0x30,
+	0xd4, 0x81, 0x22, 0x8f, 0x43, 0x5d, 0x64, 0x8d, 0xfe, 0xee, 0x06, 0xb4, 0x1b, 0x87, 0xae, 0x82,
+	0xa0, 0x0f, 0xa0, 0xe2, 0x11, 0x89, 0x69, 0x20, 0x74, 0xd5, 0xd5, 0xfa, 0xf6, 0x06, 0xf4, 0x61,
+	0x82, 0x70, 0x33, 0x28, 0x7a, 0x00, 0xe6, 0x82, 0x48, 0x6c, 0x95, 0xb4, 0xcb, 0xcd, 0x0d, 0x2e,
+	0x4f, 0x89, 0xc4, 0xae, 0x06, 0xd9, 0x7f, 0x15, 0xa1, 0x92, 0x32, 0xa8, 0x62, 0x59, 0x10, 0x21,
+	0xb0, 0x4f, 0x84, 0x55, 0x48, 0x0a, 0x29, 0xb3, 0xd1, 0x1e, 0x54, 0xa6, 0x73, 0x1c, 0xfa, 0x24,
+	0x6b, 0xad, 0x7b, 0x57, 0xa7, 0xd2, 0x3d, 0x48, 0x90, 0x49, 0x75, 0x67, 0x7e, 0xe8, 0xff, 0x00,
+	0x73, 0x2c, 0xd2, 0xbd, 0xb4, 0xc7, 0x72, 0x2b, 0xea, 0xd6, 0x08, 0xe7, 0x8c, 0xeb, 0xb3, 0x56,
+	0xdd, 0xc4, 0x50, 0xd7, 0xf3, 0x03, 0xe6, 0x21, 0x0d, 0x7d, 0x7d, 0xa0, 0xaa, 0x9b, 0x99, 0xe8,
+	0x53, 0x28, 0xcf, 0x28, 0x09, 0x3c, 0x61, 0x95, 0x75, 0x46, 0x77, 0xff, 0x21, 0xa3, 0xa1, 0x06,
+	0x26, 0x09, 0xa5, 0x5e, 0x8a, 0x79, 0x8e, 0x43, 0x2f, 0x20, 0xdc, 0xaa, 0xe8, 0x64, 0x32, 0x53,
+	0xd5, 0xba, 0xe4, 0xd4, 0xf7, 0x09, 0x27, 0xde, 0xfe, 0x32, 0x6d, 0xaa, 0xfc, 0x12, 0x72, 0xa0,
+	0x4e, 0xfd, 0x90, 0x71, 0x32, 0x50, 0x49, 0x8a, 0xb4, 0xb7, 0x56, 0xd6, 0x14, 0xbf, 0x38, 0xa7,
+	0x51, 0x44, 0xbc, 0xb4, 0xab, 0x32, 0xd3, 0x3e, 0x86, 0x7a, 0x5e, 0xa2, 0x0d, 0x15, 0x7c, 0x37,
+	0x5f, 0xc1, 0xb5, 0x7e, 0x4b, 0x1d, 0xed, 0x90, 0xce, 0x66, 0xd9, 0xc1, 0xf2, 0x7d, 0xf1, 0x09,
+	0xd4, 0x72, 0xc7, 0xfb, 0x4f, 0x2d, 0xb5, 0x0b, 0xa6, 0xaa, 0x05, 0xd4, 0x78, 0x5d, 0xd6, 0xaa,
+	0xa4, 0x9d, 0x87, 0x50, 0xd2, 0x25, 0x8b, 0x6e, 0xc0, 0xb5, 0x67, 0x27, 0xa7, 0xe3, 0xc1, 0xc1,
+	0xd1, 0xf0, 0x68, 0x70, 0xf8, 0xfc, 0xf4, 0x6c, 0xef, 0xc9, 0xa0, 0xb5, 0x85, 0xb6, 0xc1, 0x1c,
+	0x1f, 0xef, 0x9d, 0xb4, 0x0a, 0xa8, 0x0a, 0xa5, 0xbd, 0xf1, 0xf8, 0xf8, 0xab, 0x96, 0xe1, 0x7c,
+	0x08, 0x45, 0x37, 0x0e, 0xd1, 0x75, 0x68, 0xe6, 0x5d, 0xdc, 0x67, 0x27, 0xad, 0x2d, 0x54, 0x83,
+	0xca, 0xe9, 0xd9, 0x9e, 0x7b, 0x36, 0x38, 0x6c, 0x15, 0x50, 0x1d, 0xb6, 0x87, 0x47, 0x27, 0x47,
+	0xa7, 0xa3, 0xc1, 0x61, 0xcb, 0x70, 0xbe, 0x83, 0x7a, 0xfe, 0x64, 0xaa, 0x0a, 0x19, 0xa7, 0x3e,
+	0x0d, 0x71, 0x90, 0xcd, 0xf8, 0xcc, 0xd6, 0xbd, 0x1a, 0x73, 0xae, 0x7a, 0xd5, 0x48, 0x7b, 0x35,
+	0x31, 0xf5, 0xce, 0x4a, 0x65, 0x65, 0xa6, 0xf3, 0x9b, 0x01, 0x8d, 0x27, 0x1c, 0x47, 0xf3, 0x03,
+	0xb6, 0x88, 0x58, 0xa8, 0xc0, 0x0f, 0xf5, 0xa4, 0x97, 0xe4, 0xa5, 0x0e, 0x50, 0xeb, 0xdf, 0x52,
+	0xf2, 0xae, 0x62, 0xba, 0x5f, 0x6a, 0xc0, 0x68, 0xcb, 0x4d, 0xa1, 0xe8, 0x5d, 0x30, 0x89, 0xe7,
+	0x67, 0x37, 0x72, 0x73, 0x83, 0xcb, 0xc0, 0xf3, 0xc9, 0x68, 0xcb, 0xd5, 0x30, 0x7b, 0x08, 0xe5,
+	0x84, 0x62, 0x5d, 0x5c, 0x35, 0xdd, 0xd5, 0xc0, 0x4f, 0x4f, 0xa0, 0xbf, 0x55, 0xfa, 0x59, 0xa7,
+	0x17, 0xf5, 0xd0, 0xcf, 0x4c, 0xdb, 0x05, 0x53, 0xf1, 0xaa, 0xd7, 0x49, 0xb0, 0x98, 0x4f, 0x49,
+	0xca, 0x94, 0x5a, 0x8a, 0xcd, 0x23, 0x22, 0xd3, 0x43, 0x7f, 0xab, 0x4e, 0xc3, 0x52, 0x72, 0x3a,
+	0x89, 0xa5, 0xd6, 0x43, 0x0f, 0xe2, 0xd7, 0x2b, 0xfb, 0x35, 0xa8, 0x4e, 0xb3, 0xac, 0x9d, 0x8f,
+	0xa0, 0x31, 0xa2, 0x42, 0x32, 0xbe, 0xcc, 0x5e, 0xd9, 0xf5, 0x84, 0x77, 0xa0, 0x14, 0xd0, 0x05,
+	0x95, 0xd9, 0x38, 0xd5, 0x86, 0xc3, 0xa0, 0x79, 0xe1, 0x97, 0x5e, 0xdd, 0x03, 0x30, 0x79, 0x1c,
+	0x26, 0xc3, 0x23, 0x95, 0x68, 0x0d, 0xa2, 0x67, 0x9b, 0x06, 0xd9, 0xbd, 0xa4, 0x5c, 0xd6, 0x83,
+	0xe5, 0x94, 0x30, 0x56, 0x94, 0xe8, 0xff, 0x62, 0xc0, 0xf6, 0xe0, 0x25, 0x99, 0xc6, 0x92, 0x71,
+	0xf4, 0x2d, 0xd4, 0x46, 0x04, 0x07, 0x72, 0x7e, 0x30, 0x27, 0xd3, 0x73, 0xd4, 0x5c, 0x7b, 0x68,
+	0x6d, 0x74, 0x79, 0x18, 0x38, 0x77, 0x7f, 0xfe, 0xfd, 0xcf, 0x5f, 0x8d, 0xb6, 0x73, 0x5b, 0xff,
+	0x8a, 0xbc, 0x78, 0xbf, 0xb7, 0xc0, 0xd3, 0x39, 0x0d, 0x49, 0x6f, 0xae, 0x99, 0xa6, 0x8a, 0xe9,
+	0x51, 0xe1, 0xfe, 0x7b, 0x05, 0x74, 0x02, 0xe6, 0x58, 0x3d, 0xba, 0x6f, 0x44, 0x7b, 0x47, 0xd3,
+	0xde, 0x72, 0x76, 0xd6, 0x69, 0xd5, 0xbb, 0x9d, 0xf0, 0x8d, 0xa1, 0xb4, 0x17, 0x45, 0xc1, 0xf2,
+	0xcd, 0x08, 0xdb, 0x9a, 0xd0, 0x76, 0x6e, 0xac, 0x13, 0x62, 0xc5, 0xa1, 0x19, 0xfb, 0xaf, 0x0a,
+	0x50, 0x77, 0x49, 0x52, 0x03, 0x23, 0x26, 0x24, 0xfa, 0x1a, 0xaa, 0x4f, 0x88, 0xdc, 0xa7, 0x21,
+	0xe6, 0x4b, 0xb4, 0xdb, 0x4d, 0xfe, 0xaa, 0xba, 0xd9, 0x5f, 0x55, 0x77, 0xa0, 0xfe, 0xaa, 0xec,
+	0xeb, 0x2a, 0xda, 0xda, 0x03, 0x98, 0x85, 0x43, 0x56, 0x16, 0x8e, 0xa7, 0xbc, 0xa2, 0x37, 0x49,
+	0xe8, 0x26, 0x9a, 0xfb, 0x29, 0xf3, 0xe2, 0x80, 0x5c, 0x3e, 0xc2, 0x46, 0xd2, 0x9e, 0x26, 0x7d,
+	0x1b, 0xdd, 0xbb, 0x4c, 0xba, 0xd0, 0x3c, 0xa2, 0xf7, 0x63, 0xf6, 0xeb, 0xf6, 0xf8, 0xfe, 0xfd,
+	0x9f, 0xfa, 0xdf, 0x40, 0x45, 0xb7, 0x13, 0xe1, 0x4a, 0x2d, 0xfd, 0x79, 0x85, 0x5a, 0xab, 0x5d,
+	0x77, 0xb5, 0x5a, 0xbe, 0xc2, 0x25, 0x6a, 0x9d, 0x81, 0x79, 0x14, 0xce, 0x18, 0x3a, 0x06, 0x73,
+	0xac, 0x9e, 0x8e, 0xab, 0xf4, 0xb9, 0x62, 0xdd, 0xd9, 0xd1, 0x31, 0x1a, 0xa8, 0x9e, 0xc5, 0x88,
+	0x68, 0xe8, 0xf7, 0x4f, 0xa1, 0x92, 0x96, 0x37, 0x1a, 0x81, 0xe9, 0xc6, 0xa1, 0x40, 0x68, 0xa5,
+	0xe6, 0x73, 0xfa, 0xac, 0xf5, 0x81, 0x73, 0x53, 0x33, 0x5e, 0x43, 0xcd, 0x8c, 0x71, 0x9e, 0x00,
+	0x26, 0x65, 0x1d, 0xfa, 0xe1, 0xdf, 0x03, 0x00, 0x98, 0xcb, 0x5e, 0xdc, 0x46, 0x0b, 0x00, 0x00,
 }
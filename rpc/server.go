@@ -23,6 +23,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/asteris-llc/converge/helpers/logging"
+	"github.com/asteris-llc/converge/history"
 	"github.com/asteris-llc/converge/rpc/pb"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/pkg/errors"
@@ -38,14 +39,30 @@ type Server struct {
 	// Serving
 	ResourceRoot         string
 	EnableBinaryDownload bool
+
+	// HistoryDir is where runs are recorded. History is disabled if empty.
+	HistoryDir string
+
+	// LockFile is the host-wide lock taken around applies. Locking is disabled
+	// if empty.
+	LockFile string
+
+	// LockRequired makes applies fail if the lock file cannot be opened.
+	// Otherwise they go ahead without the lock, with a warning.
+	LockRequired bool
 }
 
 // newGRPC constructs all GRPC servers and handlers
 func (s *Server) newGRPC() (*grpc.Server, error) {
 	server := grpc.NewServer(s.Security.Server()...)
 
-	pb.RegisterExecutorServer(server, &executor{})
-	pb.RegisterGrapherServer(server, &grapher{})
+	var store *history.Store
+	if s.HistoryDir != "" {
+		store = history.NewStore(s.HistoryDir)
+	}
+
+	pb.RegisterExecutorServer(server, &executor{history: store, lockFile: s.LockFile, lockRequired: s.LockRequired, root: s.ResourceRoot})
+	pb.RegisterGrapherServer(server, &grapher{root: s.ResourceRoot})
 	pb.RegisterResourceHostServer(
 		server,
 		&resourceHost{
@@ -54,6 +71,7 @@ func (s *Server) newGRPC() (*grpc.Server, error) {
 		},
 	)
 	pb.RegisterInfoServer(server, &infoServer{})
+	pb.RegisterHistoryServer(server, &historyServer{store: store})
 
 	return server, nil
 }
@@ -84,6 +102,10 @@ func (s *Server) newREST(ctx context.Context, addr *url.URL) (*http.Server, erro
 		return nil, errors.Wrap(err, "could not register info server")
 	}
 
+	if err := pb.RegisterHistoryHandlerFromEndpoint(ctx, mux, addr.Host, opts); err != nil {
+		return nil, errors.Wrap(err, "could not register history server")
+	}
+
 	handler := http.Handler(mux)
 
 	if s.Security.Token != "" {
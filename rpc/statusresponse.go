@@ -15,11 +15,18 @@
 package rpc
 
 import (
+	"fmt"
+
 	"github.com/asteris-llc/converge/graph/node"
+	"github.com/asteris-llc/converge/load"
 	"github.com/asteris-llc/converge/prettyprinters/human"
+	"github.com/asteris-llc/converge/resource"
 	"github.com/asteris-llc/converge/rpc/pb"
+	"github.com/asteris-llc/converge/secret"
 )
 
+// statusResponseFromPrintable builds a status response for a node. Values that
+// were decrypted from secrets are masked, so they never leave the server.
 func statusResponseFromPrintable(meta *node.Node, p human.Printable, stage pb.StatusResponse_Stage, run pb.StatusResponse_Run) *pb.StatusResponse {
 	resp := &pb.StatusResponse{
 		Id:    meta.ID, // TODO: deprecated, remove in 0.4.0
@@ -28,24 +35,50 @@ func statusResponseFromPrintable(meta *node.Node, p human.Printable, stage pb.St
 		Meta:  pb.MetaFromNode(meta),
 
 		Details: &pb.StatusResponse_Details{
-			Messages:   p.Messages(),
+			Messages:   secret.RedactAll(p.Messages()),
 			Changes:    map[string]*pb.DiffResponse{},
 			HasChanges: p.HasChanges(),
-			Warning:    p.Warning(),
+			Warning:    secret.Redact(p.Warning()),
+
+			IgnoreErrors: load.IgnoreErrors(meta),
 		},
 	}
 
 	if err := p.Error(); err != nil {
-		resp.Details.Error = err.Error()
+		resp.Details.Error = secret.Redact(err.Error())
+	}
+
+	if skipper, ok := p.(human.Skipper); ok {
+		resp.Details.Skipped = skipper.Skipped()
+	}
+
+	if handler, ok := p.(human.Handler); ok && handler.IsHandler() {
+		resp.Details.Handler = true
+		resp.Details.TriggeredBy = handler.TriggeredBy()
 	}
 
 	for key, diff := range p.Changes() {
 		resp.Details.Changes[key] = &pb.DiffResponse{
-			Original: diff.Original(),
-			Current:  diff.Current(),
+			Original: secret.Redact(diff.Original()),
+			Current:  secret.Redact(diff.Current()),
 			Changes:  diff.Changes(),
 		}
 	}
 
+	if tasker, ok := p.(resource.Tasker); ok && tasker.GetStatus() != nil {
+		resp.Details.Fields = map[string]string{}
+		for key, value := range tasker.GetStatus().ExportedFields() {
+			resp.Details.Fields[key] = secret.Redact(fmt.Sprintf("%v", value))
+		}
+	}
+
 	return resp
 }
+
+// statusLevel gets the level of the status of a printable, if it has one
+func statusLevel(p human.Printable) string {
+	if tasker, ok := p.(resource.Tasker); ok && tasker.GetStatus() != nil {
+		return tasker.GetStatus().StatusCode().String()
+	}
+	return ""
+}
@@ -0,0 +1,5 @@
+task "notifier" {
+  check  = "false"
+  apply  = "true"
+  notify = ["task.nonexistent"]
+}
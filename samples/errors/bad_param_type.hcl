@@ -0,0 +1,5 @@
+# the default of this param is not an int. This should produce an error.
+param "port" {
+  type    = "int"
+  default = "eighty"
+}
@@ -0,0 +1,6 @@
+/* Facts describe the system converge is running on. Run `converge facts` to
+see all of them. */
+file.content "motd" {
+  destination = "/tmp/converge-facts-motd"
+  content     = "Welcome to {{fact `fqdn`}} ({{fact `cpu.count`}} CPUs, {{fact `init`}})\n"
+}
@@ -0,0 +1,17 @@
+param "filename" {
+  default = "hosts"
+}
+
+file.block "hosts" {
+  destination = "{{param `filename`}}"
+
+  block = <<EOF
+10.0.0.10 db.internal
+10.0.0.11 cache.internal
+EOF
+}
+
+file.block "legacy" {
+  destination = "{{param `filename`}}"
+  state       = "absent"
+}
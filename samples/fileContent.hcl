@@ -10,3 +10,8 @@ file.content "render" {
   destination = "{{param `filename`}}"
   content     = "{{param `message`}}"
 }
+
+file.content "stale" {
+  destination = "{{param `filename`}}.old"
+  state       = "absent"
+}
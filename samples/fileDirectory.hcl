@@ -8,3 +8,11 @@ file.directory "deeper" {
   destination = "deeper/a/b/c"
   create_all  = true
 }
+
+# with state = "absent", the directory is removed instead. It must be empty
+# unless "recursive" is set, which removes everything in it like "rm -r"
+file.directory "old-release" {
+  destination = "releases/0.1.0"
+  state       = "absent"
+  recursive   = true
+}
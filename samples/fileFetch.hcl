@@ -5,3 +5,9 @@ file.fetch "consul.zip" {
   hash_type   = "sha256"
   hash        = "abdf0e1856292468e2c9971420d73b805e93888e006c76324ae39416edcf0627"
 }
+
+# remove a previously fetched file. source is not needed for this.
+file.fetch "consul-0.6.3.zip" {
+  destination = "/tmp/consul-0.6.3.zip"
+  state       = "absent"
+}
@@ -0,0 +1,16 @@
+param "filename" {
+  default = "sshd_config"
+}
+
+file.line "permit-root-login" {
+  destination  = "{{param `filename`}}"
+  line         = "PermitRootLogin no"
+  match        = "^#?PermitRootLogin\\s"
+  insert_after = "^#?Port\\s"
+}
+
+file.line "protocol-1" {
+  destination = "{{param `filename`}}"
+  match       = "^Protocol\\s+1"
+  state       = "absent"
+}
@@ -0,0 +1,15 @@
+param "release" {
+  default = "2"
+}
+
+file.directory "release" {
+  destination = "releases/{{param `release`}}"
+  create_all  = true
+}
+
+file.link "current" {
+  source      = "releases/{{param `release`}}"
+  destination = "current"
+  force       = true
+  depends     = ["file.directory.release"]
+}
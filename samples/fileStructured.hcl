@@ -0,0 +1,15 @@
+param "filename" {
+  default = "daemon.json"
+}
+
+file.structured "docker-logs" {
+  destination = "{{param `filename`}}"
+
+  set {
+    "log-driver"        = "json-file"
+    "log-opts.max-size" = "10m"
+    "log-opts.max-file" = "3"
+  }
+
+  remove = ["debug"]
+}
@@ -0,0 +1,14 @@
+param "filename" {
+  default = "motd.txt"
+}
+
+param "admins" {
+  type    = "list"
+  default = ["alice", "bob"]
+}
+
+file.template "motd" {
+  source      = "templates/motd.tmpl"
+  destination = "{{param `filename`}}"
+  mode        = "0644"
+}
@@ -0,0 +1,31 @@
+param "directories" {
+  default = ["logs", "cache"]
+}
+
+# one file.directory per directory: file.directory.dir.logs and
+# file.directory.dir.cache
+macro.foreach "directories" {
+  param = "directories"
+
+  file.directory "dir" {
+    destination = "foreach/{{.Value}}"
+    create_all  = true
+  }
+}
+
+macro.foreach "greetings" {
+  items = {
+    english = "hello"
+    spanish = "hola"
+  }
+
+  file.content "greeting" {
+    destination = "foreach/{{.Value}}.txt"
+    content     = "{{.Key}}: {{.Value}}"
+    depends     = ["file.directory.dir.logs"]
+  }
+}
+
+task.query "logs" {
+  query = "echo {{lookup `file.directory.dir.logs.destination`}}"
+}
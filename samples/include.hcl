@@ -0,0 +1,11 @@
+/* Includes split a module across files. The resources in included files are
+part of this module, so they can use its params and depend on each other. */
+include "include/*.hcl"
+
+param "root" {
+  default = "/tmp/converge-include"
+}
+
+file.directory "root" {
+  destination = "{{param `root`}}"
+}
@@ -0,0 +1,11 @@
+file.directory "config" {
+  destination = "{{param `root`}}/etc"
+  create_all  = true
+  depends     = ["file.directory.root"]
+}
+
+file.content "config" {
+  destination = "{{param `root`}}/etc/app.conf"
+  content     = "log = {{param `root`}}/log/app.log\n"
+  depends     = ["file.directory.config"]
+}
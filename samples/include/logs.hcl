@@ -0,0 +1,5 @@
+file.directory "logs" {
+  destination = "{{param `root`}}/log"
+  create_all  = true
+  depends     = ["file.directory.root"]
+}
@@ -0,0 +1,32 @@
+/* Locals compute a value once so that it doesn't have to be repeated in every
+resource that uses it. */
+param "registry" {
+  default = "registry.example.com"
+}
+
+param "version" {
+  default = "1.2.3"
+}
+
+param "root" {
+  default = "/tmp/converge-locals"
+}
+
+local "image" {
+  value = "{{param `registry`}}/app:{{param `version`}}"
+}
+
+local "config-dir" {
+  value = "{{param `root`}}/etc/app"
+}
+
+file.directory "config" {
+  destination = "{{local `config-dir`}}"
+  create_all  = true
+}
+
+file.content "config" {
+  destination = "{{local `config-dir`}}/app.conf"
+  content     = "image = {{local `image`}}\n"
+  depends     = ["file.directory.config"]
+}
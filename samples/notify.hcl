@@ -0,0 +1,17 @@
+file.content "config" {
+  destination = "notify.conf"
+  content     = "listen = 8080"
+  notify      = ["task.reload"]
+}
+
+# reload only runs when the configuration changes
+task "reload" {
+  check = "test -f notify.reloaded"
+  apply = "date > notify.reloaded"
+}
+
+task "announce" {
+  check      = "true"
+  apply      = "echo configuration changed"
+  subscribes = ["file.content.config"]
+}
@@ -0,0 +1,15 @@
+/* This module reads the outputs of outputsModule.hcl. Both `lookup` and
+`output` refer to the same value. */
+module "outputsModule.hcl" "greeter" {
+  params = {
+    name = "Converge"
+  }
+}
+
+task.query "lookup" {
+  query = "echo '{{lookup `module.greeter.output.greeting`}}'"
+}
+
+task.query "output" {
+  query = "echo 'greeted {{output `greeter` `name`}}'"
+}
@@ -0,0 +1,17 @@
+/* This module is called by outputs.hcl. The outputs at the bottom are its public
+interface: callers read them instead of looking up the nodes inside. */
+param "name" {
+  default = "World"
+}
+
+task.query "greeting" {
+  query = "echo 'Hello, {{param `name`}}!'"
+}
+
+output "greeting" {
+  value = "{{lookup `task.query.greeting.status.stdout`}}"
+}
+
+output "name" {
+  value = "{{param `name`}}"
+}
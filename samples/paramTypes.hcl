@@ -0,0 +1,26 @@
+param "port" {
+  type        = "int"
+  description = "the port the service listens on"
+  default     = 8080
+  min         = 1
+  max         = 65535
+}
+
+param "environment" {
+  type         = "string"
+  description  = "the environment to configure"
+  default      = "development"
+  valid_values = ["development", "staging", "production"]
+}
+
+param "users" {
+  type        = "list"
+  description = "the users to greet"
+  default     = ["alice", "bob"]
+  pattern     = "^[a-z]+$"
+  min         = 1
+}
+
+task.query "config" {
+  query = "echo '{{param `environment`}} on port {{param `port`}} for {{paramList `users` | join `, `}}'"
+}
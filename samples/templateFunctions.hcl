@@ -0,0 +1,29 @@
+# examples of the functions available in templates
+
+param "name" {
+  default = "  My-Service  "
+}
+
+param "network" {
+  default = "10.1.0.0/16"
+}
+
+param "config" {
+  default = "{\"port\": 8080, \"workers\": 4}"
+}
+
+task.query "names" {
+  query = "echo '{{param `name` | trim | lower | replace `-` `_`}} {{param `name` | trim | upper}}'"
+}
+
+task.query "network" {
+  query = "echo 'subnet {{cidrsubnet 8 3 (param `network`)}} gateway {{cidrsubnet 8 3 (param `network`) | cidrhost 1}}'"
+}
+
+task.query "config" {
+  query = "echo 'keys: {{param `config` | fromJSON | keys | join `,`}} port: {{index (param `config` | fromJSON) `port`}}'"
+}
+
+task.query "hashes" {
+  query = "echo '{{param `name` | trim | sha256}} {{param `name` | trim | base64encode}}'"
+}
@@ -0,0 +1,116 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Mask replaces sensitive values in output
+const Mask = "<sensitive>"
+
+// MinLength is the length of the shortest value that is masked. Shorter values
+// would mask every occurrence of some common text, like a single letter, in all
+// output.
+const MinLength = 4
+
+var (
+	sensitive   = map[string]struct{}{}
+	sensitiveMu sync.RWMutex
+)
+
+// Register marks a value as sensitive. Sensitive values are masked everywhere
+// Redact is called for the rest of the process. Values shorter than MinLength
+// are not masked, and Register returns false for them.
+func Register(value string) bool {
+	if len(value) < MinLength {
+		return false
+	}
+
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+
+	for _, form := range escaped(value) {
+		sensitive[form] = struct{}{}
+	}
+	return true
+}
+
+// Redact replaces every sensitive value in s with Mask
+func Redact(s string) string {
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+
+	if len(sensitive) == 0 || s == "" {
+		return s
+	}
+
+	// replace longer values first, so that a value that contains another is
+	// masked as a whole
+	values := make([]string, 0, len(sensitive))
+	for value := range sensitive {
+		values = append(values, value)
+	}
+	sort.Sort(byLength(values))
+
+	for _, value := range values {
+		s = strings.Replace(s, value, Mask, -1)
+	}
+	return s
+}
+
+// RedactAll redacts every string in a slice, returning a new slice
+func RedactAll(in []string) []string {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = Redact(s)
+	}
+	return out
+}
+
+// escaped returns the forms a value takes when it is quoted for humans or
+// serialized to JSON, so that those can be masked too
+func escaped(value string) []string {
+	out := []string{value}
+
+	quoted := strconv.Quote(value)
+	out = append(out, quoted[1:len(quoted)-1])
+
+	if content, err := json.Marshal(value); err == nil {
+		out = append(out, string(content[1:len(content)-1]))
+	}
+
+	return out
+}
+
+// byLength sorts strings longest first
+type byLength []string
+
+func (b byLength) Len() int      { return len(b) }
+func (b byLength) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byLength) Less(i, j int) bool {
+	if len(b[i]) == len(b[j]) {
+		return b[i] < b[j]
+	}
+	return len(b[i]) > len(b[j])
+}
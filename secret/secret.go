@@ -0,0 +1,86 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secret encrypts and decrypts values with the keys in the keystore,
+// and keeps track of decrypted values so they can be masked in output.
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/asteris-llc/converge/keystore"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// Encrypt a value to the keys with the given fingerprints, or to all the secret
+// keys in the keystore if there are none. The result is a base64-encoded
+// OpenPGP message, suitable for pasting into a module or passing as a param.
+func Encrypt(ks *keystore.Keystore, plaintext []byte, fingerprints []string) (string, error) {
+	recipients, err := ks.Recipients(fingerprints)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := ks.Encrypt(&buf, plaintext, recipients); err != nil {
+		return "", errors.Wrap(err, "could not encrypt")
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Decrypt a value that was encrypted with Encrypt. ASCII-armored messages, as
+// produced by `gpg --encrypt --armor`, are also accepted. The plaintext is
+// registered as sensitive, so that it will be masked in output.
+func Decrypt(ks *keystore.Keystore, ciphertext string) (string, error) {
+	message, err := decode(strings.TrimSpace(ciphertext))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := ks.Decrypt(bytes.NewReader(message))
+	if err != nil {
+		return "", errors.Wrap(err, "could not decrypt secret")
+	}
+
+	if !Register(string(plaintext)) {
+		log.WithField("component", "secret").Warnf("a decrypted secret is shorter than %d characters, so it will not be masked in output", MinLength)
+	}
+	return string(plaintext), nil
+}
+
+func decode(ciphertext string) ([]byte, error) {
+	if strings.HasPrefix(ciphertext, "-----BEGIN") {
+		block, err := armor.Decode(strings.NewReader(ciphertext))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read armored secret")
+		}
+
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(block.Body); err != nil {
+			return nil, errors.Wrap(err, "could not read armored secret")
+		}
+		return buf.Bytes(), nil
+	}
+
+	message, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, errors.Wrap(err, "secret is not base64 encoded")
+	}
+	return message, nil
+}
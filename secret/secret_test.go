@@ -0,0 +1,132 @@
+// Copyright © 2016 Asteris, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/asteris-llc/converge/keystore"
+	"github.com/asteris-llc/converge/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	ks, dir := newTestKeystore(t)
+	defer os.RemoveAll(dir)
+
+	t.Run("round trip", func(t *testing.T) {
+		encrypted, err := secret.Encrypt(ks, []byte("hunter2"), nil)
+		require.NoError(t, err)
+		assert.NotContains(t, encrypted, "hunter2")
+
+		decrypted, err := secret.Decrypt(ks, encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", decrypted)
+		assert.Equal(t, "password: <sensitive>", secret.Redact("password: hunter2"))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		encrypted, err := secret.Encrypt(ks, []byte("hunter2"), nil)
+		require.NoError(t, err)
+
+		// renders share a keystore, which loads its keys on first use
+		fresh := &keystore.Keystore{SecretUserPath: ks.SecretUserPath}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				decrypted, err := secret.Decrypt(fresh, encrypted)
+				assert.NoError(t, err)
+				assert.Equal(t, "hunter2", decrypted)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := secret.Encrypt(ks, []byte("hunter2"), []string{"0123456789abcdef"})
+		assert.EqualError(t, err, `no key in the keystore matches "0123456789abcdef"`)
+	})
+
+	t.Run("not base64", func(t *testing.T) {
+		_, err := secret.Decrypt(ks, "not a secret!")
+		assert.Error(t, err)
+	})
+
+	t.Run("no secret key", func(t *testing.T) {
+		encrypted, err := secret.Encrypt(ks, []byte("hunter2"), nil)
+		require.NoError(t, err)
+
+		empty := &keystore.Keystore{SecretUserPath: filepath.Join(dir, "empty")}
+		_, err = secret.Decrypt(empty, encrypted)
+		assert.EqualError(t, err, "could not decrypt secret: no secret key in the keystore can decrypt this value")
+	})
+}
+
+func TestRedact(t *testing.T) {
+	secret.Register("redact-test")
+	secret.Register("redact-test-longer")
+	secret.Register(`redact "quoted"`)
+
+	assert.Equal(t, "a <sensitive> b", secret.Redact("a redact-test b"))
+	assert.Equal(t, "<sensitive>", secret.Redact("redact-test-longer"))
+	assert.Equal(t, `"<sensitive>"`, secret.Redact(`"redact \"quoted\""`))
+	assert.Equal(t, "nothing to hide", secret.Redact("nothing to hide"))
+	assert.Equal(t, []string{"<sensitive>"}, secret.RedactAll([]string{"redact-test"}))
+
+	// short values would mask common text everywhere
+	assert.False(t, secret.Register("ab"))
+	assert.Equal(t, "ab", secret.Redact("ab"))
+}
+
+// newTestKeystore creates a keystore with a new secret key in a temporary
+// directory
+func newTestKeystore(t *testing.T) (*keystore.Keystore, string) {
+	dir, err := ioutil.TempDir("", "secret-test")
+	require.NoError(t, err)
+
+	ks := &keystore.Keystore{SecretUserPath: filepath.Join(dir, "secretkeys")}
+
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", &packet.Config{RSABits: 1024})
+	require.NoError(t, err)
+
+	// keys generated by gpg state the algorithms they prefer, but NewEntity
+	// doesn't, which would make openpgp fall back to RIPEMD160
+	for _, identity := range entity.Identities {
+		identity.SelfSignature.PreferredHash = []uint8{8} // SHA256
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+
+	_, err = ks.StoreSecretKey(buf.Bytes())
+	require.NoError(t, err)
+
+	return ks, dir
+}